@@ -12,9 +12,12 @@ import (
 	"github.com/docker/docker/builder"
 	buildkit "github.com/docker/docker/builder/builder-next"
 	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/daemon/imagescan"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
@@ -22,6 +25,10 @@ import (
 type ImageComponent interface {
 	SquashImage(from string, to string) (string, error)
 	TagImageWithReference(image.ID, reference.Named) error
+
+	// ScanImage runs the daemon's configured vulnerability scanner (if
+	// any) against imageID and records the result.
+	ScanImage(ctx context.Context, imageID string) (imagescan.Result, error)
 }
 
 // Builder defines interface for running a build
@@ -92,6 +99,15 @@ func (b *Backend) Build(ctx context.Context, config backend.BuildConfig) (string
 		stdout := config.ProgressWriter.StdoutFormatter
 		fmt.Fprintf(stdout, "Successfully built %s\n", stringid.TruncateID(imageID))
 	}
+
+	if imageID != "" {
+		if scanResult, scanErr := b.imageComponent.ScanImage(ctx, imageID); scanErr != nil {
+			logrus.WithError(scanErr).WithField("image", imageID).Warn("vulnerability scan of built image failed")
+		} else if scanResult.Blocked {
+			return imageID, errdefs.Forbidden(errors.Errorf("built image %s is blocked by the vulnerability scan policy: %s", stringid.TruncateID(imageID), scanResult.Reason))
+		}
+	}
+
 	if imageID != "" {
 		err = tagger.TagImages(image.ID(imageID))
 	}
@@ -0,0 +1,58 @@
+package httputils // import "github.com/docker/docker/api/server/httputils"
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// CompressResponse wraps w so that anything written through the returned
+// ResponseWriter is gzip-compressed, provided the client's Accept-Encoding
+// header allows it; otherwise w is returned unchanged. It's meant for
+// large, one-shot JSON payloads (a bulk container listing, an events
+// backfill) where the bandwidth savings are worth the CPU cost, and is
+// deliberately not used on hijacked streaming endpoints such as attach
+// or exec. The caller must call the returned close func once done
+// writing, typically via defer.
+func CompressResponse(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func() error) {
+	if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		return w, func() error { return nil }
+	}
+
+	gz := gzip.NewWriter(w)
+	return &gzipResponseWriter{ResponseWriter: w, gz: gz}, gz.Close
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	for _, enc := range strings.Split(acceptEncoding, ",") {
+		if strings.EqualFold(strings.TrimSpace(strings.SplitN(enc, ";", 2)[0]), "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter transparently gzip-compresses everything written to
+// it once headers are sent.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if !g.wroteHeader {
+		g.wroteHeader = true
+		g.Header().Del("Content-Length")
+		g.Header().Set("Content-Encoding", "gzip")
+		g.Header().Add("Vary", "Accept-Encoding")
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	return g.gz.Write(p)
+}
@@ -26,7 +26,14 @@ type APIFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, v
 // HijackConnection interrupts the http response writer to get the
 // underlying connection and operate with it.
 func HijackConnection(w http.ResponseWriter) (io.ReadCloser, io.Writer, error) {
-	conn, _, err := w.(http.Hijacker).Hijack()
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		// Notably, this is the case for an HTTP/2 connection: HTTP/2
+		// doesn't support hijacking, so attach/exec-style endpoints
+		// require the client to speak HTTP/1.1.
+		return nil, nil, errors.New("unable to hijack connection, client must use HTTP/1.1")
+	}
+	conn, _, err := hijacker.Hijack()
 	if err != nil {
 		return nil, nil, err
 	}
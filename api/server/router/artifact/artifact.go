@@ -0,0 +1,32 @@
+package artifact // import "github.com/docker/docker/api/server/router/artifact"
+
+import (
+	"github.com/docker/docker/api/server/router"
+)
+
+// artifactRouter is a router to talk with the artifact controller
+type artifactRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new artifact router
+func NewRouter(backend Backend) router.Router {
+	r := &artifactRouter{backend: backend}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes to the artifact controller
+func (r *artifactRouter) Routes() []router.Route {
+	return r.routes
+}
+
+func (r *artifactRouter) initRoutes() {
+	r.routes = []router.Route{
+		router.NewGetRoute("/artifacts/json", r.getArtifactsJSON, router.Experimental),
+		router.NewGetRoute("/artifacts/{digest:.*}/pull", r.getArtifactPull, router.Experimental),
+		router.NewPostRoute("/artifacts/push", r.postArtifactPush, router.Experimental),
+		router.NewDeleteRoute("/artifacts/{digest:.*}", r.deleteArtifact, router.Experimental),
+	}
+}
@@ -0,0 +1,95 @@
+package artifact // import "github.com/docker/docker/api/server/router/artifact"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/errdefs"
+	digest "github.com/opencontainers/go-digest"
+)
+
+var errArtifactTypeRequired = errors.New("artifactType is required")
+
+func (r *artifactRouter) postArtifactPush(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(req); err != nil {
+		return err
+	}
+
+	var subject digest.Digest
+	if s := req.Form.Get("subject"); s != "" {
+		var err error
+		subject, err = digest.Parse(s)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+	}
+
+	artifactType := req.Form.Get("artifactType")
+	if artifactType == "" {
+		return errdefs.InvalidParameter(errArtifactTypeRequired)
+	}
+
+	summary, err := r.backend.ArtifactPush(ctx, artifactType, subject, nil, req.Body)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, summary)
+}
+
+func (r *artifactRouter) getArtifactPull(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	dgst, err := digest.Parse(vars["digest"])
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+
+	content, summary, err := r.backend.ArtifactPull(ctx, dgst)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Docker-Artifact-Type", summary.ArtifactType)
+	_, err = io.Copy(w, content)
+	return err
+}
+
+func (r *artifactRouter) getArtifactsJSON(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(req); err != nil {
+		return err
+	}
+
+	var subject digest.Digest
+	if s := req.Form.Get("subject"); s != "" {
+		var err error
+		subject, err = digest.Parse(s)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+	}
+
+	summaries, err := r.backend.ArtifactList(ctx, subject)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, summaries)
+}
+
+func (r *artifactRouter) deleteArtifact(ctx context.Context, w http.ResponseWriter, req *http.Request, vars map[string]string) error {
+	dgst, err := digest.Parse(vars["digest"])
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+
+	if err := r.backend.ArtifactDelete(ctx, dgst); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,18 @@
+package artifact // import "github.com/docker/docker/api/server/router/artifact"
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Backend is all the methods that need to be implemented to provide
+// artifact specific functionality.
+type Backend interface {
+	ArtifactPush(ctx context.Context, artifactType string, subject digest.Digest, annotations map[string]string, content io.Reader) (types.ArtifactSummary, error)
+	ArtifactPull(ctx context.Context, dgst digest.Digest) (io.ReadCloser, types.ArtifactSummary, error)
+	ArtifactList(ctx context.Context, subject digest.Digest) ([]types.ArtifactSummary, error)
+	ArtifactDelete(ctx context.Context, dgst digest.Digest) error
+}
@@ -3,12 +3,14 @@ package container // import "github.com/docker/docker/api/server/router/containe
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	containerpkg "github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/stats"
 	"github.com/docker/docker/pkg/archive"
 )
 
@@ -18,6 +20,8 @@ type execBackend interface {
 	ContainerExecInspect(id string) (*backend.ExecInspect, error)
 	ContainerExecResize(name string, height, width int) error
 	ContainerExecStart(ctx context.Context, name string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+	ContainerExecAttach(ctx context.Context, name string, stdin io.ReadCloser, stdout, stderr io.Writer) error
+	ContainerExecList(name string) ([]*backend.ExecListItem, error)
 	ExecExists(name string) (bool, error)
 }
 
@@ -28,21 +32,24 @@ type copyBackend interface {
 	ContainerExport(name string, out io.Writer) error
 	ContainerExtractToDir(name, path string, copyUIDGID, noOverwriteDirNonDir bool, content io.Reader) error
 	ContainerStatPath(name string, path string) (stat *types.ContainerPathStat, err error)
+	ContainerSnapshot(name, snapshotName string) error
+	ContainerRollback(name, snapshotName string) error
 }
 
 // stateBackend includes functions to implement to provide container state lifecycle functionality.
 type stateBackend interface {
 	ContainerCreate(config types.ContainerCreateConfig) (container.ContainerCreateCreatedBody, error)
+	ContainerDebug(ctx context.Context, name string, config *types.ContainerDebugConfig) (string, error)
 	ContainerKill(name string, sig uint64) error
 	ContainerPause(name string) error
 	ContainerRename(oldName, newName string) error
 	ContainerResize(name string, height, width int) error
 	ContainerRestart(name string, seconds *int) error
 	ContainerRm(name string, config *types.ContainerRmConfig) error
-	ContainerStart(name string, hostConfig *container.HostConfig, checkpoint string, checkpointDir string) error
+	ContainerStart(ctx context.Context, name string, hostConfig *container.HostConfig, checkpoint string, checkpointDir string) error
 	ContainerStop(name string, seconds *int) error
 	ContainerUnpause(name string) error
-	ContainerUpdate(name string, hostConfig *container.HostConfig) (container.ContainerUpdateOKBody, error)
+	ContainerUpdate(name string, hostConfig *container.HostConfig, restartInPlace bool) (container.ContainerUpdateOKBody, error)
 	ContainerWait(ctx context.Context, name string, condition containerpkg.WaitCondition) (<-chan containerpkg.StateStatus, error)
 }
 
@@ -50,9 +57,17 @@ type stateBackend interface {
 type monitorBackend interface {
 	ContainerChanges(name string) ([]archive.Change, error)
 	ContainerInspect(name string, size bool, version string) (interface{}, error)
+	ContainerInspectAll(names []string, size bool) ([]*types.ContainerJSON, error)
 	ContainerLogs(ctx context.Context, name string, config *types.ContainerLogsOptions) (msgs <-chan *backend.LogMessage, tty bool, err error)
+	ContainerLogsRotate(name string) error
+	ContainerConsoleLogs(name string, config *types.ContainerConsoleLogsOptions) (io.ReadCloser, error)
 	ContainerStats(ctx context.Context, name string, config *backend.ContainerStatsConfig) error
+	ContainerStatsHistory(name string, since time.Time) ([]*stats.HistorySample, error)
 	ContainerTop(name string, psArgs string) (*container.ContainerTopOKBody, error)
+	ContainerDiagnostics(name string) (*container.ContainerDiagnostics, error)
+	ContainerGetBundle(name string) (io.ReadCloser, error)
+	ContainerUpdateMounts(name string, config *container.MountsUpdateConfig) error
+	ContainerGeneratedProfile(name string) (*backend.GeneratedProfile, error)
 
 	Containers(config *types.ContainerListOptions) ([]*types.Container, error)
 }
@@ -2,12 +2,15 @@ package container // import "github.com/docker/docker/api/server/router/containe
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api/server/httputils"
@@ -15,6 +18,7 @@ import (
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/api/types/versions"
 	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
@@ -95,7 +99,11 @@ func (s *containerRouter) getContainersJSON(ctx context.Context, w http.Response
 		return err
 	}
 
-	return httputils.WriteJSON(w, http.StatusOK, containers)
+	// This listing can be large, so compress it for clients that advertise
+	// support for it.
+	cw, closeCW := httputils.CompressResponse(w, r)
+	defer closeCW()
+	return httputils.WriteJSON(cw, http.StatusOK, containers)
 }
 
 func (s *containerRouter) getContainersStats(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -122,6 +130,37 @@ func (s *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	return s.backend.ContainerStats(ctx, vars["name"], config)
 }
 
+func (s *containerRouter) getContainersStatsHistory(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	since, err := statsHistoryTime(r.Form.Get("since"))
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+
+	samples, err := s.backend.ContainerStatsHistory(vars["name"], since)
+	if err != nil {
+		return err
+	}
+
+	cw, closeCW := httputils.CompressResponse(w, r)
+	defer closeCW()
+	return httputils.WriteJSON(cw, http.StatusOK, samples)
+}
+
+func statsHistoryTime(formTime string) (time.Time, error) {
+	t, tNano, err := timetypes.ParseTimestamps(formTime, -1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t == -1 {
+		return time.Time{}, nil
+	}
+	return time.Unix(t, tNano), nil
+}
+
 func (s *containerRouter) getContainersLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -162,6 +201,39 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 	return nil
 }
 
+func (s *containerRouter) postContainersLogsRotate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerLogsRotate(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *containerRouter) getContainersConsoleLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	consoleLogsConfig := &types.ContainerConsoleLogsOptions{
+		Follow: httputils.BoolValue(r, "follow"),
+		Tail:   r.Form.Get("tail"),
+	}
+
+	out, err := s.backend.ContainerConsoleLogs(vars["name"], consoleLogsConfig)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.docker.raw-stream")
+	_, err = io.Copy(w, out)
+	return err
+}
+
 func (s *containerRouter) getContainersExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	return s.backend.ContainerExport(vars["name"], w)
 }
@@ -207,7 +279,7 @@ func (s *containerRouter) postContainersStart(ctx context.Context, w http.Respon
 
 	checkpoint := r.Form.Get("checkpoint")
 	checkpointDir := r.Form.Get("checkpoint-dir")
-	if err := s.backend.ContainerStart(vars["name"], hostConfig, checkpoint, checkpointDir); err != nil {
+	if err := s.backend.ContainerStart(ctx, vars["name"], hostConfig, checkpoint, checkpointDir); err != nil {
 		return err
 	}
 
@@ -342,6 +414,10 @@ func (s *containerRouter) postContainersWait(ctx context.Context, w http.Respons
 		case container.WaitConditionRemoved:
 			waitCondition = containerpkg.WaitConditionRemoved
 			legacyRemovalWaitPre134 = versions.LessThan(version, "1.34")
+		case container.WaitConditionHealthy:
+			waitCondition = containerpkg.WaitConditionHealthy
+		case container.WaitConditionUnhealthy:
+			waitCondition = containerpkg.WaitConditionUnhealthy
 		}
 	}
 
@@ -382,6 +458,80 @@ func (s *containerRouter) postContainersWait(ctx context.Context, w http.Respons
 	})
 }
 
+// postContainersWaitMultiple waits on several containers in a single call,
+// streaming each one's result back as a newline-delimited JSON object as
+// soon as it's ready, in whatever order the waits complete rather than the
+// order the containers were listed in. It saves orchestration scripts from
+// having to open one connection per container they need to wait on.
+func (s *containerRouter) postContainersWaitMultiple(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	names := r.Form["names"]
+	if len(names) == 0 {
+		return errdefs.InvalidParameter(errors.New("at least one container name is required"))
+	}
+
+	waitCondition := containerpkg.WaitConditionNotRunning
+	switch container.WaitCondition(r.Form.Get("condition")) {
+	case container.WaitConditionNextExit:
+		waitCondition = containerpkg.WaitConditionNextExit
+	case container.WaitConditionRemoved:
+		waitCondition = containerpkg.WaitConditionRemoved
+	case container.WaitConditionHealthy:
+		waitCondition = containerpkg.WaitConditionHealthy
+	case container.WaitConditionUnhealthy:
+		waitCondition = containerpkg.WaitConditionUnhealthy
+	}
+
+	type waitResult struct {
+		name   string
+		status containerpkg.StateStatus
+		err    error
+	}
+	resultC := make(chan waitResult, len(names))
+
+	for _, name := range names {
+		waitC, err := s.backend.ContainerWait(ctx, name, waitCondition)
+		if err != nil {
+			resultC <- waitResult{name: name, err: err}
+			continue
+		}
+		go func(name string) {
+			resultC <- waitResult{name: name, status: <-waitC}
+		}(name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+	output.Flush()
+	enc := json.NewEncoder(output)
+
+	for range names {
+		res := <-resultC
+
+		body := container.ContainersWaitResult{Name: res.name}
+		switch {
+		case res.err != nil:
+			body.Error = &container.ContainerWaitOKBodyError{Message: res.err.Error()}
+		case res.status.Err() != nil:
+			body.Error = &container.ContainerWaitOKBodyError{Message: res.status.Err().Error()}
+		default:
+			body.StatusCode = int64(res.status.ExitCode())
+		}
+
+		if err := enc.Encode(&body); err != nil {
+			return err
+		}
+		output.Flush()
+	}
+
+	return nil
+}
+
 func (s *containerRouter) getContainersChanges(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	changes, err := s.backend.ContainerChanges(vars["name"])
 	if err != nil {
@@ -404,6 +554,36 @@ func (s *containerRouter) getContainersTop(ctx context.Context, w http.ResponseW
 	return httputils.WriteJSON(w, http.StatusOK, procList)
 }
 
+func (s *containerRouter) getContainersDiagnostics(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	report, err := s.backend.ContainerDiagnostics(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
+func (s *containerRouter) getContainersGeneratedProfile(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	profile, err := s.backend.ContainerGeneratedProfile(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, profile)
+}
+
+func (s *containerRouter) getContainersBundle(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	bundle, err := s.backend.ContainerGetBundle(vars["name"])
+	if err != nil {
+		return err
+	}
+	defer bundle.Close()
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	_, err = io.Copy(w, bundle)
+	return err
+}
+
 func (s *containerRouter) postContainerRename(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -418,6 +598,30 @@ func (s *containerRouter) postContainerRename(ctx context.Context, w http.Respon
 	return nil
 }
 
+func (s *containerRouter) postContainersSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerSnapshot(vars["name"], r.Form.Get("name")); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *containerRouter) postContainersRollback(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerRollback(vars["name"], r.Form.Get("name")); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -449,7 +653,7 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 	}
 
 	name := vars["name"]
-	resp, err := s.backend.ContainerUpdate(name, hostConfig)
+	resp, err := s.backend.ContainerUpdate(name, hostConfig, updateConfig.RestartInPlace)
 	if err != nil {
 		return err
 	}
@@ -457,6 +661,27 @@ func (s *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 	return httputils.WriteJSON(w, http.StatusOK, resp)
 }
 
+func (s *containerRouter) postContainerUpdateMounts(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var mountsConfig container.MountsUpdateConfig
+	if err := json.NewDecoder(r.Body).Decode(&mountsConfig); err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerUpdateMounts(vars["name"], &mountsConfig); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (s *containerRouter) postContainersCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -531,6 +756,16 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 		hostConfig.PidsLimit = nil
 	}
 
+	authConfig := &types.AuthConfig{}
+	if authEncoded := r.Header.Get("X-Registry-Auth"); authEncoded != "" {
+		authJSON := base64.NewDecoder(base64.URLEncoding, strings.NewReader(authEncoded))
+		if err := json.NewDecoder(authJSON).Decode(authConfig); err != nil {
+			// Not fatal: a pull triggered by ImagePullPolicy against a public
+			// image doesn't need credentials.
+			authConfig = &types.AuthConfig{}
+		}
+	}
+
 	ccr, err := s.backend.ContainerCreate(types.ContainerCreateConfig{
 		Name:             name,
 		Config:           config,
@@ -538,6 +773,8 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 		NetworkingConfig: networkingConfig,
 		AdjustCPUShares:  adjustCPUShares,
 		Platform:         platform,
+		AuthConfig:       authConfig,
+		Replace:          httputils.BoolValue(r, "replace"),
 	})
 	if err != nil {
 		return err
@@ -546,6 +783,30 @@ func (s *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 	return httputils.WriteJSON(w, http.StatusCreated, ccr)
 }
 
+func (s *containerRouter) postContainersDebug(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	debugConfig := &types.ContainerDebugConfig{}
+	if err := json.NewDecoder(r.Body).Decode(debugConfig); err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	if debugConfig.Image == "" {
+		return errdefs.InvalidParameter(errors.New("image is required"))
+	}
+
+	id, err := s.backend.ContainerDebug(ctx, vars["name"], debugConfig)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, &types.IDResponse{ID: id})
+}
+
 func (s *containerRouter) deleteContainers(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
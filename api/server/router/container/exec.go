@@ -139,6 +139,15 @@ func (s *containerRouter) postContainerExecStart(ctx context.Context, w http.Res
 	return nil
 }
 
+// postContainerExecResize changes the TTY size of a running exec. It's a
+// separate HTTP call from exec start, so a resize issued immediately after
+// start can race the exec process actually coming up; ContainerExecResize
+// waits for the exec to report ready before applying it, but there's no way
+// for this endpoint to replay a resize that arrived while a client was
+// disconnected and reconnecting, short of the client calling it again once
+// reconnected. Prefer setting ExecConfig.ConsoleSize at exec create time
+// for the initial size, which is applied atomically with process start and
+// avoids the race entirely for that case.
 func (s *containerRouter) postContainerExecResize(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -154,3 +163,58 @@ func (s *containerRouter) postContainerExecResize(ctx context.Context, w http.Re
 
 	return s.backend.ContainerExecResize(vars["name"], height, width)
 }
+
+// postContainerExecAttach reattaches to an already-running exec, replaying
+// its scrollback before forwarding live output. It only makes sense for an
+// exec started with Persistent set, since a non-Persistent exec's process
+// has typically already ended by the time a client reconnects.
+func (s *containerRouter) postContainerExecAttach(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	execName := vars["name"]
+	eConfig, err := s.backend.ContainerExecInspect(execName)
+	if err != nil {
+		return err
+	}
+
+	inStream, outStream, err := httputils.HijackConnection(w)
+	if err != nil {
+		return err
+	}
+	defer httputils.CloseStreams(inStream, outStream)
+
+	if _, ok := r.Header["Upgrade"]; ok {
+		fmt.Fprint(outStream, "HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n")
+	} else {
+		fmt.Fprint(outStream, "HTTP/1.1 200 OK\r\nContent-Type: application/vnd.docker.raw-stream\r\n")
+	}
+	if err := w.Header().WriteSubset(outStream, nil); err != nil {
+		return err
+	}
+	fmt.Fprint(outStream, "\r\n")
+
+	var stdout, stderr io.Writer = outStream, nil
+	if eConfig.ProcessConfig == nil || !eConfig.ProcessConfig.Tty {
+		stdout = stdcopy.NewStdWriter(outStream, stdcopy.Stdout)
+		stderr = stdcopy.NewStdWriter(outStream, stdcopy.Stderr)
+	}
+
+	if err := s.backend.ContainerExecAttach(ctx, execName, inStream, stdout, stderr); err != nil {
+		stdout.Write([]byte(err.Error() + "\r\n"))
+		logrus.Errorf("Error attaching to exec %s: %v", execName, err)
+	}
+	return nil
+}
+
+// getContainerExecs lists every exec instance the daemon still tracks for
+// the given container, whether running or awaiting garbage collection
+// after exit.
+func (s *containerRouter) getContainerExecs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	items, err := s.backend.ContainerExecList(vars["name"])
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, items)
+}
@@ -19,3 +19,21 @@ func (s *containerRouter) getContainersByName(ctx context.Context, w http.Respon
 
 	return httputils.WriteJSON(w, http.StatusOK, json)
 }
+
+// getContainersInspectAll inspects many containers at once, computed from a
+// single consistent snapshot of the container store, for callers that would
+// otherwise have to issue one request per container. With no "names" given
+// it inspects every container.
+func (s *containerRouter) getContainersInspectAll(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	displaySize := httputils.BoolValue(r, "size")
+
+	json, err := s.backend.ContainerInspectAll(r.Form["names"], displaySize)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, json)
+}
@@ -2,11 +2,33 @@ package debug // import "github.com/docker/docker/api/server/router/debug"
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/pprof"
+
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/pkg/bootprofile"
 )
 
 func handlePprof(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	pprof.Handler(vars["name"]).ServeHTTP(w, r)
 	return nil
 }
+
+// handleBoot reports the timing of each recorded daemon startup phase. With
+// ?format=flamegraph it writes collapsed-stack lines (name microseconds)
+// that flamegraph.pl and similar tools consume directly; otherwise it
+// writes JSON.
+func handleBoot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	phases := bootprofile.Phases()
+
+	if r.URL.Query().Get("format") == "flamegraph" {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, p := range phases {
+			fmt.Fprintf(w, "%s %d\n", p.Name, p.Duration.Microseconds())
+		}
+		return nil
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, phases)
+}
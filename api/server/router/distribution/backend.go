@@ -6,10 +6,18 @@ import (
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // Backend is all the methods that need to be implemented
 // to provide image specific functionality.
 type Backend interface {
 	GetRepository(context.Context, reference.Named, *types.AuthConfig) (distribution.Repository, bool, error)
+
+	// PushManifestList assembles an OCI image index out of sources and
+	// pushes it to target, returning its digest. See
+	// daemon/images.ImageService.PushManifestList for the constraints
+	// this places on target and sources.
+	PushManifestList(ctx context.Context, target string, sources []types.ManifestListSource, platformFilter []specs.Platform, metaHeaders map[string][]string, authConfig *types.AuthConfig) (digest.Digest, error)
 }
@@ -27,5 +27,7 @@ func (r *distributionRouter) initRoutes() {
 	r.routes = []router.Route{
 		// GET
 		router.NewGetRoute("/distribution/{name:.*}/json", r.getDistributionInfo),
+		// POST
+		router.NewPostRoute("/distribution/manifests/create", r.postManifestListCreate, router.Experimental),
 	}
 }
@@ -148,3 +148,38 @@ func (s *distributionRouter) getDistributionInfo(ctx context.Context, w http.Res
 
 	return httputils.WriteJSON(w, http.StatusOK, distributionInspect)
 }
+
+// postManifestListCreate assembles an OCI image index out of existing,
+// already-pushed manifests and pushes it to the repository named in the
+// request body.
+func (s *distributionRouter) postManifestListCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var req types.ManifestListCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	if req.Target == "" {
+		return errdefs.InvalidParameter(errors.New("target is required"))
+	}
+	if len(req.Sources) == 0 {
+		return errdefs.InvalidParameter(errors.New("sources must not be empty"))
+	}
+
+	var authConfig *types.AuthConfig
+	if authEncoded := r.Header.Get("X-Registry-Auth"); authEncoded != "" {
+		authJSON := base64.NewDecoder(base64.URLEncoding, strings.NewReader(authEncoded))
+		if err := json.NewDecoder(authJSON).Decode(&authConfig); err != nil {
+			authConfig = &types.AuthConfig{}
+		}
+	}
+
+	dgst, err := s.backend.PushManifestList(ctx, req.Target, req.Sources, req.Platforms, nil, authConfig)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, types.ManifestListCreateResponse{Digest: dgst})
+}
@@ -3,6 +3,7 @@ package image // import "github.com/docker/docker/api/server/router/image"
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -26,12 +27,21 @@ type imageBackend interface {
 	LookupImage(name string) (*types.ImageInspect, error)
 	TagImage(imageName, repository, tag string) (string, error)
 	ImagesPrune(ctx context.Context, pruneFilters filters.Args) (*types.ImagesPruneReport, error)
+	SquashImage(id, parent string) (string, error)
+	PinImage(imageRef string, reverifyInterval time.Duration) (*types.ImagePin, error)
+	UnpinImage(imageRef string) error
+	ImagePins() []types.ImagePin
+	AddPrePullImage(imageRef string, interval time.Duration) (*types.PrePullEntry, error)
+	RemovePrePullImage(imageRef string) error
+	PrePullImages() []types.PrePullEntry
 }
 
 type importExportBackend interface {
 	LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error
 	ImportImage(src string, repository, platform string, tag string, msg string, inConfig io.ReadCloser, outStream io.Writer, changes []string) error
 	ExportImage(names []string, outStream io.Writer) error
+	ExportImageOCI(names []string, outStream io.Writer, compression string) error
+	ExportImageDelta(names []string, deltaFrom string, outStream io.Writer) error
 }
 
 type registryBackend interface {
@@ -32,13 +32,20 @@ func (r *imageRouter) initRoutes() {
 		router.NewGetRoute("/images/{name:.*}/get", r.getImagesGet),
 		router.NewGetRoute("/images/{name:.*}/history", r.getImagesHistory),
 		router.NewGetRoute("/images/{name:.*}/json", r.getImagesByName),
+		router.NewGetRoute("/images/pins", r.getImagePins, router.Experimental),
+		router.NewGetRoute("/images/pre-pull", r.getPrePullImages, router.Experimental),
 		// POST
 		router.NewPostRoute("/images/load", r.postImagesLoad),
 		router.NewPostRoute("/images/create", r.postImagesCreate),
 		router.NewPostRoute("/images/{name:.*}/push", r.postImagesPush),
 		router.NewPostRoute("/images/{name:.*}/tag", r.postImagesTag),
+		router.NewPostRoute("/images/{name:.*}/squash", r.postImagesSquash, router.Experimental),
+		router.NewPostRoute("/images/{name:.*}/pin", r.postImagePin, router.Experimental),
+		router.NewPostRoute("/images/{name:.*}/pre-pull", r.postPrePullImage, router.Experimental),
 		router.NewPostRoute("/images/prune", r.postImagesPrune),
 		// DELETE
 		router.NewDeleteRoute("/images/{name:.*}", r.deleteImages),
+		router.NewDeleteRoute("/images/{name:.*}/pin", r.deleteImagePin, router.Experimental),
+		router.NewDeleteRoute("/images/{name:.*}/pre-pull", r.deletePrePullImage, router.Experimental),
 	}
 }
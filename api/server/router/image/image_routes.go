@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api/server/httputils"
@@ -157,11 +158,19 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	var exportErr error
+	if deltaFrom := r.Form.Get("delta-from"); deltaFrom != "" {
+		exportErr = s.backend.ExportImageDelta(names, deltaFrom, output)
+	} else if format := r.Form.Get("format"); format == "oci" {
+		exportErr = s.backend.ExportImageOCI(names, output, r.Form.Get("compression"))
+	} else {
+		exportErr = s.backend.ExportImage(names, output)
+	}
+	if exportErr != nil {
 		if !output.Flushed() {
-			return err
+			return exportErr
 		}
-		_, _ = output.Write(streamformatter.FormatError(err))
+		_, _ = output.Write(streamformatter.FormatError(exportErr))
 	}
 	return nil
 }
@@ -268,6 +277,96 @@ func (s *imageRouter) postImagesTag(ctx context.Context, w http.ResponseWriter,
 	return nil
 }
 
+// postImagesSquash flattens the layers between vars["name"] and an
+// optional "parent" form value into a single new layer, the same
+// merge `docker build --squash` performs, without requiring a build.
+// If parent is omitted, every layer of the image is merged into one
+// new, parentless layer. The source image is left untouched; the
+// merged result is returned as a new, untagged image ID.
+func (s *imageRouter) postImagesSquash(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	imgID, err := s.backend.SquashImage(vars["name"], r.Form.Get("parent"))
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, &types.IDResponse{ID: imgID})
+}
+
+// postImagePin pins vars["name"], which must be a tag reference, to the
+// image it currently names. An optional "reverify-interval" form value
+// (a Go duration string, e.g. "1h") has the daemon periodically check
+// whether the tag's upstream registry manifest digest has since changed.
+func (s *imageRouter) postImagePin(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var reverifyInterval time.Duration
+	if v := r.Form.Get("reverify-interval"); v != "" {
+		var err error
+		reverifyInterval, err = time.ParseDuration(v)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid reverify-interval"))
+		}
+	}
+
+	pin, err := s.backend.PinImage(vars["name"], reverifyInterval)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, pin)
+}
+
+// deleteImagePin removes the pin recorded for vars["name"].
+func (s *imageRouter) deleteImagePin(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.UnpinImage(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// getImagePins lists every currently pinned reference.
+func (s *imageRouter) getImagePins(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.ImagePins())
+}
+
+// postPrePullImage adds vars["name"], which must be a tag reference, to the
+// daemon's pre-pull list. The required "interval" form value (a Go duration
+// string, e.g. "1h") controls how often the daemon refreshes it.
+func (s *imageRouter) postPrePullImage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	interval, err := time.ParseDuration(r.Form.Get("interval"))
+	if err != nil {
+		return errdefs.InvalidParameter(errors.Wrap(err, "invalid interval"))
+	}
+
+	entry, err := s.backend.AddPrePullImage(vars["name"], interval)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, entry)
+}
+
+// deletePrePullImage removes vars["name"] from the pre-pull list.
+func (s *imageRouter) deletePrePullImage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.RemovePrePullImage(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// getPrePullImages lists every entry on the pre-pull list.
+func (s *imageRouter) getPrePullImages(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.PrePullImages())
+}
+
 func (s *imageRouter) getImagesSearch(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -2,6 +2,7 @@ package system // import "github.com/docker/docker/api/server/router/system"
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -16,6 +17,22 @@ type Backend interface {
 	SystemInfo() *types.Info
 	SystemVersion() types.Version
 	SystemDiskUsage(ctx context.Context) (*types.DiskUsage, error)
+	SystemDiskUsageDedup(ctx context.Context) (*types.DedupReport, error)
+	SystemClockSync(ctx context.Context) (*types.ClockSyncStatus, error)
+	SystemContainerdInfo(ctx context.Context) ([]types.ContainerdPlugin, error)
+	SystemExport(ctx context.Context) (io.ReadCloser, error)
+	SystemImport(ctx context.Context, in io.Reader) error
+	SystemReservePorts(req types.PortReservationRequest) (*types.PortReservation, error)
+	SystemReleasePortReservation(token string) error
+	SystemOperations() []types.Operation
+	SystemCancelOperation(id string) error
+	SystemVerify(ctx context.Context) (*types.ContentVerifyReport, error)
+	SystemMigrateSchema1(ctx context.Context) (*types.Schema1MigrationReport, error)
+	SystemBatch(ctx context.Context, req types.BatchRequest) (*types.BatchResult, error)
+	SystemReloadTrustPolicy(ctx context.Context) error
+	CreateNetworkPod(name string) error
+	RemoveNetworkPod(name string) error
+	ListNetworkPods() []string
 	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *types.AuthConfig) (string, string, error)
@@ -32,6 +32,22 @@ func NewRouter(b Backend, c ClusterBackend, builder *buildkit.Builder, features
 		router.NewGetRoute("/info", r.getInfo),
 		router.NewGetRoute("/version", r.getVersion),
 		router.NewGetRoute("/system/df", r.getDiskUsage),
+		router.NewGetRoute("/system/clocksync", r.getClockSync),
+		router.NewGetRoute("/system/containerd", r.getContainerdInfo),
+		router.NewGetRoute("/system/export", r.getSystemExport),
+		router.NewPostRoute("/system/import", r.postSystemImport),
+		router.NewPostRoute("/system/batch", r.postSystemBatch),
+		router.NewPostRoute("/system/trust-policy/reload", r.postSystemReloadTrustPolicy),
+		router.NewPostRoute("/system/verify", r.postSystemVerify),
+		router.NewPostRoute("/system/schema1-migrate", r.postSystemMigrateSchema1),
+		router.NewPostRoute("/system/ports/reserve", r.postSystemPortsReserve),
+		router.NewDeleteRoute("/system/ports/reserve/{token:.*}", r.deleteSystemPortsReserve),
+		router.NewGetRoute("/operations", r.getOperations),
+		router.NewPostRoute("/operations/{id:.*}/cancel", r.postOperationsCancel),
+		router.NewDeleteRoute("/operations/{id:.*}", r.deleteOperation),
+		router.NewGetRoute("/network-pods", r.getNetworkPods),
+		router.NewPostRoute("/network-pods/{name:.*}", r.postNetworkPodCreate),
+		router.NewDeleteRoute("/network-pods/{name:.*}", r.deleteNetworkPod),
 		router.NewPostRoute("/auth", r.postAuth),
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -90,6 +91,11 @@ func (s *systemRouter) getVersion(ctx context.Context, w http.ResponseWriter, r
 }
 
 func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	wantDedup := httputils.BoolValue(r, "dedup")
+
 	eg, ctx := errgroup.WithContext(ctx)
 
 	var du *types.DiskUsage
@@ -109,6 +115,18 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 		return nil
 	})
 
+	var dedup *types.DedupReport
+	if wantDedup {
+		eg.Go(func() error {
+			var err error
+			dedup, err = s.backend.SystemDiskUsageDedup(ctx)
+			if err != nil {
+				return pkgerrors.Wrap(err, "error computing layer dedup report")
+			}
+			return nil
+		})
+	}
+
 	if err := eg.Wait(); err != nil {
 		return err
 	}
@@ -120,10 +138,183 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 
 	du.BuilderSize = builderSize
 	du.BuildCache = buildCache
+	du.Dedup = dedup
 
 	return httputils.WriteJSON(w, http.StatusOK, du)
 }
 
+func (s *systemRouter) getClockSync(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	status, err := s.backend.SystemClockSync(ctx)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, status)
+}
+
+func (s *systemRouter) getContainerdInfo(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	plugins, err := s.backend.SystemContainerdInfo(ctx)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, plugins)
+}
+
+func (s *systemRouter) getSystemExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	export, err := s.backend.SystemExport(ctx)
+	if err != nil {
+		return err
+	}
+	defer export.Close()
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	_, err = io.Copy(w, export)
+	return err
+}
+
+func (s *systemRouter) postSystemImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.SystemImport(ctx, r.Body); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// postSystemBatch runs a sequence of create-network, create-container,
+// connect-network, and start-container operations as a single request, with
+// best-effort rollback if a later step fails. See the doc comment on
+// types.BatchRequest for exactly what that rollback does and doesn't cover.
+func (s *systemRouter) postSystemBatch(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req types.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidRequestError{err}
+	}
+
+	result, err := s.backend.SystemBatch(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, result)
+}
+
+// postSystemReloadTrustPolicy reloads the daemon's image trust policy file
+// from disk without requiring a daemon restart.
+func (s *systemRouter) postSystemReloadTrustPolicy(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.SystemReloadTrustPolicy(ctx); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// postSystemVerify re-hashes all blobs and layers in the content and
+// layer stores against their recorded digests and reports any
+// corruption found, repairing what it can by re-pulling still-tagged
+// images.
+func (s *systemRouter) postSystemVerify(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	report, err := s.backend.SystemVerify(ctx)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
+// postSystemMigrateSchema1 re-pushes the locally known tags of every
+// repository a pull has fetched a schema1 manifest for, so the registry
+// serves schema2 for it afterward.
+func (s *systemRouter) postSystemMigrateSchema1(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	report, err := s.backend.SystemMigrateSchema1(ctx)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
+// postSystemPortsReserve finds and reserves free host ports ahead of
+// container create, so orchestration layers don't race each other into
+// choosing the same port.
+func (s *systemRouter) postSystemPortsReserve(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req types.PortReservationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return invalidRequestError{err}
+	}
+
+	reservation, err := s.backend.SystemReservePorts(req)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, reservation)
+}
+
+// deleteSystemPortsReserve releases a reservation made by
+// postSystemPortsReserve before it expires on its own.
+func (s *systemRouter) deleteSystemPortsReserve(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.SystemReleasePortReservation(vars["token"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// getOperations lists in-flight long-running daemon operations.
+func (s *systemRouter) getOperations(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.SystemOperations())
+}
+
+// postOperationsCancel requests cancellation of an in-flight operation.
+func (s *systemRouter) postOperationsCancel(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.SystemCancelOperation(vars["id"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// deleteOperation is the same as postOperationsCancel, exposed as a DELETE
+// on the operation's own resource for callers that prefer that verb.
+func (s *systemRouter) deleteOperation(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.SystemCancelOperation(vars["id"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// getNetworkPods lists the named network namespace sharing groups created
+// with postNetworkPodCreate.
+func (s *systemRouter) getNetworkPods(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.ListNetworkPods())
+}
+
+// postNetworkPodCreate creates a named network namespace that containers
+// can join with --network=pod:<name>.
+func (s *systemRouter) postNetworkPodCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.CreateNetworkPod(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+// deleteNetworkPod removes a network pod, failing if any running
+// container is still using it.
+func (s *systemRouter) deleteNetworkPod(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := s.backend.RemoveNetworkPod(vars["name"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 type invalidRequestError struct {
 	Err error
 }
@@ -175,7 +366,19 @@ func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	output := ioutils.NewWriteFlusher(w)
+
+	respWriter := w
+	if onlyPastEvents {
+		// A bounded backfill behaves like any other bulk JSON response, so
+		// compress it for clients that advertise support for it. Live,
+		// indefinite event streams are left uncompressed so events are
+		// flushed to the client as they happen rather than buffered.
+		cw, closeCW := httputils.CompressResponse(w, r)
+		defer closeCW()
+		respWriter = cw
+	}
+
+	output := ioutils.NewWriteFlusher(respWriter)
 	defer output.Close()
 	output.Flush()
 
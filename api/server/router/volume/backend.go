@@ -15,6 +15,8 @@ type Backend interface {
 	List(ctx context.Context, filter filters.Args) ([]*types.Volume, []string, error)
 	Get(ctx context.Context, name string, opts ...opts.GetOption) (*types.Volume, error)
 	Create(ctx context.Context, name, driverName string, opts ...opts.CreateOption) (*types.Volume, error)
+	Snapshot(ctx context.Context, src, name string, opts ...opts.CreateOption) (*types.Volume, error)
+	Clone(ctx context.Context, src, name string, opts ...opts.CreateOption) (*types.Volume, error)
 	Remove(ctx context.Context, name string, opts ...opts.RemoveOption) error
 	Prune(ctx context.Context, pruneFilters filters.Args) (*types.VolumesPruneReport, error)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
@@ -66,6 +67,41 @@ func (v *volumeRouter) postVolumesCreate(ctx context.Context, w http.ResponseWri
 	return httputils.WriteJSON(w, http.StatusCreated, volume)
 }
 
+func (v *volumeRouter) postVolumesSnapshot(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	vol, err := v.postVolumesCopy(ctx, r, vars["name"], v.backend.Snapshot)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, vol)
+}
+
+func (v *volumeRouter) postVolumesClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	vol, err := v.postVolumesCopy(ctx, r, vars["name"], v.backend.Clone)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, vol)
+}
+
+func (v *volumeRouter) postVolumesCopy(ctx context.Context, r *http.Request, src string, do func(context.Context, string, string, ...opts.CreateOption) (*types.Volume, error)) (*types.Volume, error) {
+	if err := httputils.ParseForm(r); err != nil {
+		return nil, err
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return nil, err
+	}
+
+	var req volumetypes.VolumeCopyBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err == io.EOF {
+			return nil, errdefs.InvalidParameter(errors.New("got EOF while reading request body"))
+		}
+		return nil, errdefs.InvalidParameter(err)
+	}
+
+	return do(ctx, src, req.Name, opts.WithCreateLabels(req.Labels))
+}
+
 func (v *volumeRouter) deleteVolumes(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
 )
 
 // versionMatcher defines a variable matcher to be parsed by the router
@@ -57,10 +58,21 @@ func (s *Server) Accept(addr string, listeners ...net.Listener) {
 	for _, listener := range listeners {
 		httpServer := &HTTPServer{
 			srv: &http.Server{
-				Addr: addr,
+				Addr:      addr,
+				TLSConfig: s.cfg.TLSConfig,
 			},
 			l: listener,
 		}
+		if s.cfg.TLSConfig != nil {
+			// Advertise HTTP/2 over TLS so clients can negotiate it,
+			// which mainly benefits large JSON responses. Endpoints that
+			// hijack the connection (attach, exec) don't work over
+			// HTTP/2 and return an error to any client that insists on
+			// negotiating it; see httputils.HijackConnection.
+			if err := http2.ConfigureServer(httpServer.srv, nil); err != nil {
+				logrus.WithError(err).Warn("unable to configure HTTP/2 support for the API server")
+			}
+		}
 		s.servers = append(s.servers, httpServer)
 	}
 }
@@ -0,0 +1,21 @@
+package types // import "github.com/docker/docker/api/types"
+
+import (
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ArtifactSummary describes a non-runnable OCI artifact (an SBOM, a
+// signature, a Helm chart, and similar) held in the daemon's local artifact
+// store. Unlike an image, an artifact is addressed by its own content
+// digest and, optionally, attached to another piece of content (typically
+// an image manifest) via Subject.
+type ArtifactSummary struct {
+	Digest       digest.Digest
+	ArtifactType string
+	Subject      digest.Digest `json:",omitempty"`
+	Size         int64
+	Annotations  map[string]string `json:",omitempty"`
+	CreatedAt    time.Time
+}
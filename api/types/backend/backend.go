@@ -104,6 +104,34 @@ type ExecProcessConfig struct {
 	User       string   `json:"user,omitempty"`
 }
 
+// ExecListItem summarizes one exec instance for
+// GET /containers/{id}/execs. Unlike ExecInspect it omits the detail
+// needed to drive a running attach (DetachKeys, Pid) since it's meant for
+// browsing, not reattaching.
+type ExecListItem struct {
+	ID            string
+	Running       bool
+	ExitCode      *int
+	Persistent    bool
+	StartedAt     time.Time
+	ProcessConfig *ExecProcessConfig
+}
+
+// GeneratedProfile reports on a container's --security-opt
+// generate-profile profile-learning session.
+type GeneratedProfile struct {
+	// Enabled reports whether the container was started with
+	// --security-opt generate-profile.
+	Enabled bool
+	// SeccompAction is the seccomp action applied, instead of the default
+	// profile's usual block, to syscalls outside its allow-list while the
+	// container runs.
+	SeccompAction string
+	// Note explains how to turn the resulting kernel audit log entries into
+	// a profile, and what isn't covered yet.
+	Note string
+}
+
 // CreateImageConfig is the configuration for creating an image from a
 // container.
 type CreateImageConfig struct {
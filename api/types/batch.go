@@ -0,0 +1,122 @@
+package types // import "github.com/docker/docker/api/types"
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+// BatchOpKind names one of the operation kinds accepted in a
+// BatchRequest.Operations list. See the doc comment on BatchRequest for the
+// operations this batch endpoint does and does not support.
+type BatchOpKind string
+
+// Supported BatchOp kinds.
+const (
+	BatchOpCreateNetwork   BatchOpKind = "create-network"
+	BatchOpCreateContainer BatchOpKind = "create-container"
+	BatchOpConnectNetwork  BatchOpKind = "connect-network"
+	BatchOpStartContainer  BatchOpKind = "start-container"
+)
+
+// BatchOp is a single step of a BatchRequest. Exactly one of the Create*
+// fields should be set, matching Kind.
+type BatchOp struct {
+	Kind BatchOpKind
+
+	// Ref names this step so later steps can refer to resources it
+	// creates, in place of a real ID the client can't know ahead of time.
+	// A BatchOpCreateNetwork or BatchOpCreateContainer step should set
+	// Ref; a BatchOpConnectNetwork or BatchOpStartContainer step refers
+	// back to one with ContainerRef/NetworkRef.
+	Ref string
+
+	CreateNetwork   *NetworkCreateRequest `json:",omitempty"`
+	CreateContainer *BatchCreateContainer `json:",omitempty"`
+	ConnectNetwork  *BatchConnectNetwork  `json:",omitempty"`
+	StartContainer  *BatchStartContainer  `json:",omitempty"`
+}
+
+// BatchCreateContainer is the parameters for a BatchOpCreateContainer step.
+type BatchCreateContainer struct {
+	Name       string
+	Config     *container.Config
+	HostConfig *container.HostConfig
+}
+
+// BatchConnectNetwork is the parameters for a BatchOpConnectNetwork step.
+// NetworkRef and ContainerRef each hold either the Ref of an earlier step in
+// this same batch, or the name/ID of a pre-existing network or container.
+type BatchConnectNetwork struct {
+	NetworkRef     string
+	ContainerRef   string
+	EndpointConfig *network.EndpointSettings `json:",omitempty"`
+}
+
+// BatchStartContainer is the parameters for a BatchOpStartContainer step.
+// ContainerRef holds either the Ref of an earlier create-container step in
+// this same batch, or the name/ID of a pre-existing container.
+type BatchStartContainer struct {
+	ContainerRef string
+}
+
+// BatchRequest is the request body for POST /system/batch. It runs a
+// sequence of create-network, create-container, connect-network, and
+// start-container operations as a single request, so a deployment script
+// doesn't have to make them one HTTP call at a time and hand-roll cleanup
+// when an early step succeeds but a later one fails.
+//
+// If DryRun is true, no operation is actually performed: each step's
+// parameters are validated (the same validation its real endpoint would do
+// up front, e.g. network name conflicts, container config sanity) and the
+// response reports what would happen, with no side effects at all.
+//
+// Rollback on failure is best-effort compensation, not a database-style
+// transaction: there is no daemon-wide lock held across the whole batch, so
+// a concurrent request can still observe and interact with the partial
+// state between steps, and the compensating action for a step is the
+// obvious inverse (remove a created container or network, disconnect a
+// connected endpoint) rather than a true undo of every side effect (for
+// example, image pulls triggered by a create-container step are not rolled
+// back). If a compensating action itself fails, BatchResult.Results records
+// that failure rather than hiding it, and later compensations still run.
+type BatchRequest struct {
+	Operations []BatchOp
+	DryRun     bool `json:",omitempty"`
+}
+
+// BatchOpResult is the outcome of a single BatchOp.
+type BatchOpResult struct {
+	Kind BatchOpKind
+	Ref  string
+	// ID is the ID of the resource the step created (for
+	// BatchOpCreateNetwork/BatchOpCreateContainer), empty otherwise.
+	ID string
+	// ContainerID and NetworkID are the container and network a
+	// BatchOpConnectNetwork or BatchOpStartContainer step acted on, after
+	// resolving BatchOp Refs: the ID an earlier step of the batch created,
+	// or the name/ID given for a pre-existing resource as is. They are
+	// recorded so a later rollback knows exactly
+	// what to undo without having to re-resolve refs that may have
+	// changed meaning.
+	ContainerID string `json:",omitempty"`
+	NetworkID   string `json:",omitempty"`
+	// Error is the step's failure, if any. It is also set on the step
+	// that a rollback or dry-run validation failed on.
+	Error string `json:",omitempty"`
+	// RolledBack is true if this step's effect was undone because a later
+	// step in the same batch failed.
+	RolledBack bool `json:",omitempty"`
+}
+
+// BatchResult is the response body for POST /system/batch.
+type BatchResult struct {
+	// DryRun echoes BatchRequest.DryRun.
+	DryRun bool
+	// OK is true if every operation (and, on failure, every rollback)
+	// succeeded.
+	OK bool
+	// FailedStep is the index into Results of the operation that failed,
+	// or -1 if none did.
+	FailedStep int
+	Results    []BatchOpResult
+}
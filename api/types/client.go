@@ -81,6 +81,13 @@ type ContainerLogsOptions struct {
 	Details    bool
 }
 
+// ContainerConsoleLogsOptions holds parameters to filter the captured
+// guest console/serial output of VM-backed runtimes with.
+type ContainerConsoleLogsOptions struct {
+	Follow bool
+	Tail   string
+}
+
 // ContainerRemoveOptions holds parameters to remove containers.
 type ContainerRemoveOptions struct {
 	RemoveVolumes bool
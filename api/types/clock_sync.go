@@ -0,0 +1,17 @@
+package types // import "github.com/docker/docker/api/types"
+
+// ClockSyncStatus describes the host's time synchronization state as
+// reported by the system clock daemon (e.g. chronyd or a PTP hardware
+// clock). It is surfaced to containers that opt in via
+// HostConfig.ClockSyncStatusFile so that latency-sensitive or TLS-strict
+// applications can detect a bad clock without host access.
+type ClockSyncStatus struct {
+	// Synced reports whether the host clock is currently synchronized.
+	Synced bool
+	// Source identifies the time source backing this status, e.g. "chrony" or "ptp".
+	Source string
+	// OffsetSeconds is the last measured offset from the reference clock, in seconds.
+	OffsetSeconds float64
+	// Stratum is the NTP stratum of the reference clock, where applicable.
+	Stratum int
+}
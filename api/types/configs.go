@@ -18,6 +18,15 @@ type ContainerCreateConfig struct {
 	NetworkingConfig *network.NetworkingConfig
 	Platform         *specs.Platform
 	AdjustCPUShares  bool
+	// AuthConfig is used to authenticate against the registry when
+	// Config.ImagePullPolicy requires the daemon to pull the image.
+	AuthConfig *AuthConfig
+	// Replace allows this create to reuse Name: if a container already
+	// exists under that name, it is stopped and renamed out of the way
+	// before the new container is created, and renamed back if the new
+	// container's creation fails. This removes the stop/rm/create race
+	// that deployment scripts otherwise have to hand-roll themselves.
+	Replace bool
 }
 
 // ContainerRmConfig holds arguments for the container remove
@@ -41,6 +50,47 @@ type ExecConfig struct {
 	Env          []string // Environment variables
 	WorkingDir   string   // Working directory
 	Cmd          []string // Execution commands and args
+	// ConsoleSize is an initial size for the TTY, given as [height, width],
+	// applied atomically with process start instead of through a later,
+	// separate resize call. Ignored if Tty is false. A nil value leaves the
+	// TTY at whatever default size the runtime gives it, to be resized
+	// later the same way it always has been.
+	ConsoleSize *[2]uint `json:",omitempty"`
+	// Persistent keeps the exec process and its stdin open across a client
+	// disconnect instead of ending it, buffering recent stdout/stderr in a
+	// server-side scrollback. The session can then be listed via
+	// GET /containers/{id}/execs and reattached by exec ID via
+	// POST /exec/{id}/attach.
+	Persistent bool `json:",omitempty"`
+	// NanoCPUs is the CPU quota, in units of 1e-9 CPUs, to confine the
+	// exec'd process to via a dedicated sub-cgroup of the container's own
+	// cgroup, so it can't starve the container's main workload. Only
+	// supported on Linux with cgroup v1 and the cgroupfs driver; see
+	// Daemon.execScopeResources.
+	NanoCPUs int64 `json:",omitempty"`
+	// Memory is the memory limit, in bytes, for the exec's dedicated
+	// sub-cgroup. See NanoCPUs.
+	Memory int64 `json:",omitempty"`
+}
+
+// ContainerDebugConfig holds the configuration for launching a debug
+// sidecar container that shares selected namespaces with a target
+// container, for `docker debug`.
+type ContainerDebugConfig struct {
+	// Image is the tools image the sidecar is created from.
+	Image string
+	// Cmd is the command to run in the sidecar, e.g. a shell. Defaults to
+	// []string{"/bin/sh"} if empty.
+	Cmd []string
+	// ShareNamespaceNet, ShareNamespacePID and ShareNamespaceMount select which
+	// of the target container's namespaces the sidecar joins. Mount is
+	// joined by bind-mounting the target's root filesystem read-only at
+	// /target in the sidecar, since the daemon has no way to join an
+	// existing mount namespace directly; the target's own filesystem is
+	// never modified.
+	ShareNamespaceNet   bool
+	ShareNamespacePID   bool
+	ShareNamespaceMount bool
 }
 
 // PluginRmConfig holds arguments for plugin remove.
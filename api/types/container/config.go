@@ -34,6 +34,27 @@ type HealthConfig struct {
 	Retries int `json:",omitempty"`
 }
 
+// PullPolicy describes when the daemon should pull an image before creating
+// a container from it.
+type PullPolicy string
+
+const (
+	// PullPolicyNever means the daemon never pulls; it uses whatever image is
+	// already present locally and fails if it is not. This is the default,
+	// and matches the historical behavior of ContainerCreate.
+	PullPolicyNever PullPolicy = "never"
+	// PullPolicyIfNotPresent pulls the image only if it is not already
+	// present locally.
+	PullPolicyIfNotPresent PullPolicy = "ifnotpresent"
+	// PullPolicyAlways always pulls the image before creating the container,
+	// even if a local copy is already present.
+	PullPolicyAlways PullPolicy = "always"
+	// PullPolicyDigest behaves like PullPolicyAlways, but additionally
+	// requires that Config.Image be pinned to a digest (e.g.
+	// "name@sha256:...") so the pulled content can be verified against it.
+	PullPolicyDigest PullPolicy = "digest"
+)
+
 // Config contains the configuration data about a container.
 // It should hold only portable information about the container.
 // Here, "portable" means "independent from the host we are running on".
@@ -56,6 +77,7 @@ type Config struct {
 	Healthcheck     *HealthConfig       `json:",omitempty"` // Healthcheck describes how to check the container is healthy
 	ArgsEscaped     bool                `json:",omitempty"` // True if command is already escaped (meaning treat as a command line) (Windows specific).
 	Image           string              // Name of the image as it was passed by the operator (e.g. could be symbolic)
+	ImagePullPolicy PullPolicy          `json:",omitempty"` // When the daemon should pull Image before creating the container
 	Volumes         map[string]struct{} // List of volumes (mounts) used for the container
 	WorkingDir      string              // Current directory (PWD) in the command will be launched
 	Entrypoint      strslice.StrSlice   // Entrypoint to run when starting the container
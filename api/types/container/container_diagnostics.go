@@ -0,0 +1,41 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+// ZombieProcess describes a single zombie (terminated-but-unreaped) process
+// found in a container's pid namespace.
+type ZombieProcess struct {
+	// PID is the host-visible process ID of the zombie.
+	PID int
+	// Comm is the process's command name, as reported by /proc/<pid>/stat.
+	Comm string
+	// PPid is the host-visible process ID of the zombie's parent, which is
+	// responsible for reaping it.
+	PPid int
+}
+
+// FDWarning describes a single process whose open file descriptor count has
+// crossed ContainerDiagnostics' reporting threshold.
+type FDWarning struct {
+	// PID is the host-visible process ID of the process.
+	PID int
+	// Comm is the process's command name, as reported by /proc/<pid>/stat.
+	Comm string
+	// OpenFDs is the number of open file descriptors found for the process.
+	OpenFDs int
+}
+
+// ContainerDiagnostics is the response to the ContainerDiagnostics
+// operation. It reports zombie processes and abnormal file descriptor
+// counts observed within a container's pid namespace, to help diagnose
+// images that leak zombies or file descriptors through a missing or
+// misbehaving init process.
+type ContainerDiagnostics struct {
+	// Zombies lists the zombie processes currently present in the
+	// container's pid namespace.
+	Zombies []ZombieProcess
+	// FDWarnings lists processes whose open file descriptor count meets or
+	// exceeds the configured warning threshold.
+	FDWarnings []FDWarning
+	// UnreapedChildren is the number of zombie processes found under init
+	// (pid 1) specifically, i.e. children that init itself failed to reap.
+	UnreapedChildren int
+}
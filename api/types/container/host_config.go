@@ -53,6 +53,12 @@ func (i Isolation) IsProcess() bool {
 	return strings.ToLower(string(i)) == "process"
 }
 
+// IsSandbox indicates the use of a VM-isolated sandbox runtime (e.g. Kata
+// Containers, gVisor) for isolation
+func (i Isolation) IsSandbox() bool {
+	return strings.ToLower(string(i)) == "sandbox"
+}
+
 const (
 	// IsolationEmpty is unspecified (same behavior as default)
 	IsolationEmpty = Isolation("")
@@ -62,6 +68,8 @@ const (
 	IsolationProcess = Isolation("process")
 	// IsolationHyperV is HyperV isolation mode
 	IsolationHyperV = Isolation("hyperv")
+	// IsolationSandbox is VM-isolated sandbox runtime isolation mode
+	IsolationSandbox = Isolation("sandbox")
 )
 
 // IpcMode represents the container ipc stack.
@@ -127,7 +135,7 @@ func (n NetworkMode) IsDefault() bool {
 
 // IsPrivate indicates whether container uses its private network stack.
 func (n NetworkMode) IsPrivate() bool {
-	return !(n.IsHost() || n.IsContainer())
+	return !(n.IsHost() || n.IsContainer() || n.IsPod())
 }
 
 // IsContainer indicates whether container uses a container network stack.
@@ -136,6 +144,14 @@ func (n NetworkMode) IsContainer() bool {
 	return len(parts) > 1 && parts[0] == "container"
 }
 
+// IsPod indicates whether the container joins a named network namespace
+// sharing group created ahead of time, independent of any one container's
+// lifetime (e.g. "pod:mygroup").
+func (n NetworkMode) IsPod() bool {
+	parts := strings.SplitN(string(n), ":", 2)
+	return len(parts) > 1 && parts[0] == "pod"
+}
+
 // ConnectedContainer is the id of the container which network this container is connected to.
 func (n NetworkMode) ConnectedContainer() string {
 	parts := strings.SplitN(string(n), ":", 2)
@@ -145,6 +161,15 @@ func (n NetworkMode) ConnectedContainer() string {
 	return ""
 }
 
+// ConnectedPod is the name of the network pod this container is connected to.
+func (n NetworkMode) ConnectedPod() string {
+	parts := strings.SplitN(string(n), ":", 2)
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return ""
+}
+
 // UserDefined indicates user-created network
 func (n NetworkMode) UserDefined() string {
 	if n.IsUserDefined() {
@@ -153,6 +178,13 @@ func (n NetworkMode) UserDefined() string {
 	return ""
 }
 
+// IDMap represents a single entry in a user namespace UID or GID mapping.
+type IDMap struct {
+	ContainerID int `json:"container_id"`
+	HostID      int `json:"host_id"`
+	Size        int `json:"size"`
+}
+
 // UsernsMode represents userns mode in the container.
 type UsernsMode string
 
@@ -205,7 +237,7 @@ type UTSMode string
 
 // IsPrivate indicates whether the container uses its private UTS namespace.
 func (n UTSMode) IsPrivate() bool {
-	return !(n.IsHost())
+	return !(n.IsHost() || n.IsContainer())
 }
 
 // IsHost indicates whether the container uses the host's UTS namespace.
@@ -213,17 +245,65 @@ func (n UTSMode) IsHost() bool {
 	return n == "host"
 }
 
+// IsContainer indicates whether the container uses a container's UTS namespace.
+func (n UTSMode) IsContainer() bool {
+	parts := strings.SplitN(string(n), ":", 2)
+	return len(parts) > 1 && parts[0] == "container"
+}
+
 // Valid indicates whether the UTS namespace is valid.
 func (n UTSMode) Valid() bool {
 	parts := strings.Split(string(n), ":")
 	switch mode := parts[0]; mode {
 	case "", "host":
+	case "container":
+		if len(parts) != 2 || parts[1] == "" {
+			return false
+		}
 	default:
 		return false
 	}
 	return true
 }
 
+// Container returns the name of the container whose UTS namespace is going
+// to be used.
+func (n UTSMode) Container() string {
+	parts := strings.SplitN(string(n), ":", 2)
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return ""
+}
+
+// TimeMode represents the time namespace of the container.
+//
+// The time namespace can currently only be requested as private (the
+// default) or shared with the host; joining another container's time
+// namespace isn't supported since it has no meaningful host PID to attach
+// to the way the other namespaces here do.
+type TimeMode string
+
+// IsPrivate indicates whether the container uses its own private time namespace.
+func (n TimeMode) IsPrivate() bool {
+	return !n.IsHost()
+}
+
+// IsHost indicates whether the container uses the host's time namespace.
+func (n TimeMode) IsHost() bool {
+	return n == "host"
+}
+
+// Valid indicates whether the time namespace mode is valid.
+func (n TimeMode) Valid() bool {
+	switch n {
+	case "", "host":
+		return true
+	default:
+		return false
+	}
+}
+
 // PidMode represents the pid namespace of the container.
 type PidMode string
 
@@ -335,6 +415,49 @@ const (
 type LogConfig struct {
 	Type   string
 	Config map[string]string
+
+	// Stdout and Stderr, when set, override Type/Config for that single
+	// stream, so applications that use stdout/stderr semantically (for
+	// example sending only warnings to stderr) can route them to
+	// different log drivers or options. A stream with no override uses
+	// Type/Config above. `docker logs` continues to read from the
+	// stdout stream's driver.
+	Stdout *LogStreamConfig `json:",omitempty"`
+	Stderr *LogStreamConfig `json:",omitempty"`
+}
+
+// LogStreamConfig overrides the log driver and options for a single
+// container output stream. See LogConfig.Stdout/Stderr.
+type LogStreamConfig struct {
+	Type   string
+	Config map[string]string
+}
+
+// StdioOverflowPolicy is a type to define the available policies for what
+// to do once a container's stdout/stderr buffer fills up because nothing is
+// reading from it.
+type StdioOverflowPolicy string
+
+// Available stdio overflow policies
+const (
+	StdioOverflowUnset         StdioOverflowPolicy = ""
+	StdioOverflowBlock         StdioOverflowPolicy = "block"
+	StdioOverflowDropOldest    StdioOverflowPolicy = "drop-oldest"
+	StdioOverflowKillContainer StdioOverflowPolicy = "kill-container"
+)
+
+// StdioConfig bounds how much unread stdout/stderr data the daemon will
+// buffer on behalf of a container, and what to do once that bound is hit.
+// It sits alongside LogConfig because it governs the same in-memory copy
+// path that feeds both log drivers and `docker attach`/`docker logs -f`
+// readers.
+type StdioConfig struct {
+	// MaxBufferSize is the maximum number of bytes to buffer per stream
+	// before applying OverflowPolicy. Zero uses the daemon's default.
+	MaxBufferSize int64
+	// OverflowPolicy says what to do once MaxBufferSize is reached.
+	// Unset behaves like "block".
+	OverflowPolicy StdioOverflowPolicy
 }
 
 // Resources contains container's resources (cgroups config, ulimits...)
@@ -360,6 +483,7 @@ type Resources struct {
 	CpusetMems           string          // CpusetMems 0-2, 0,1
 	Devices              []DeviceMapping // List of devices to map inside the container
 	DeviceCgroupRules    []string        // List of rule to be added to the device cgroup
+	DeviceHotplugRules   []DeviceMapping // Rules matched against host devices that appear after the container has started; PathOnHost may use shell glob wildcards (e.g. "/dev/ttyUSB*")
 	DeviceRequests       []DeviceRequest // List of device requests for device drivers
 	KernelMemory         int64           // Kernel memory limit (in bytes), Deprecated: kernel 5.4 deprecated kmem.limit_in_bytes
 	KernelMemoryTCP      int64           // Hard limit for kernel TCP buffer memory (in bytes)
@@ -369,6 +493,8 @@ type Resources struct {
 	OomKillDisable       *bool           // Whether to disable OOM Killer or not
 	PidsLimit            *int64          // Setting PIDs limit for a container; Set `0` or `-1` for unlimited, or `null` to not change.
 	Ulimits              []*units.Ulimit // List of ulimits to be set in the container
+	NetworkEgressRate    int64           // Egress bandwidth limit on the container's network endpoint, in bytes/sec. 0 means unlimited.
+	NetworkIngressRate   int64           // Ingress bandwidth limit on the container's network endpoint, in bytes/sec. 0 means unlimited.
 
 	// Applicable to Windows
 	CPUCount           int64  `json:"CpuCount"`   // CPU count
@@ -383,6 +509,69 @@ type UpdateConfig struct {
 	// Contains container's resources (cgroups, ulimits)
 	Resources
 	RestartPolicy RestartPolicy
+
+	// RestartInPlace requests that, if the container is running, the
+	// daemon gracefully stop and start it after applying the update so
+	// the new configuration takes effect immediately, without requiring
+	// the container to be recreated under a new ID.
+	RestartInPlace bool
+}
+
+// MountsUpdateConfig describes a request to attach or detach bind or volume
+// mounts from a running container's mount namespace, without requiring the
+// container to be recreated.
+type MountsUpdateConfig struct {
+	// Add lists mounts to bind into the container.
+	Add []mount.Mount `json:",omitempty"`
+	// Remove lists the in-container destination paths of mounts to detach.
+	Remove []string `json:",omitempty"`
+	// TmpfsResize maps the in-container destination path of an existing
+	// tmpfs mount to its new size (e.g. "512m"), to grow or shrink it
+	// without unmounting it or recreating the container.
+	TmpfsResize map[string]string `json:",omitempty"`
+}
+
+// NamedPipePublishConfig describes a container named pipe that is published
+// to the host, analogous to publishing a TCP/UDP port or, on Linux, bind
+// mounting a unix socket out of a container.
+type NamedPipePublishConfig struct {
+	// ContainerPipe is the full path of the named pipe inside the container,
+	// e.g. `\\.\pipe\foo`.
+	ContainerPipe string
+	// HostPipe is the full path of the named pipe to create on the host,
+	// e.g. `\\.\pipe\bar`.
+	HostPipe string
+	// SecurityDescriptor is a Windows security descriptor in SDDL format,
+	// applied to HostPipe. If empty, the daemon's default is used.
+	SecurityDescriptor string
+}
+
+// LifecycleHookFailurePolicy controls how the daemon reacts when a
+// LifecycleHook's command exits non-zero or times out.
+type LifecycleHookFailurePolicy string
+
+const (
+	// LifecycleHookFailurePolicyIgnore logs the failure and continues the
+	// lifecycle transition the hook is attached to. This is the default.
+	LifecycleHookFailurePolicyIgnore LifecycleHookFailurePolicy = "ignore"
+	// LifecycleHookFailurePolicyFail treats the failure as fatal: a failing
+	// PostStartHook kills the container, and a failing PreStopHook aborts
+	// the stop request.
+	LifecycleHookFailurePolicyFail LifecycleHookFailurePolicy = "fail"
+)
+
+// LifecycleHook describes a command run inside a container at a lifecycle
+// transition (e.g. after start, before stop), mirroring Kubernetes
+// container lifecycle hooks.
+type LifecycleHook struct {
+	// Exec is the command to run inside the container.
+	Exec []string
+	// Timeout bounds how long the hook is allowed to run, in seconds. If
+	// zero or negative, a daemon default is used.
+	Timeout int `json:",omitempty"`
+	// FailurePolicy controls what happens if the hook fails. Defaults to
+	// LifecycleHookFailurePolicyIgnore if empty.
+	FailurePolicy LifecycleHookFailurePolicy `json:",omitempty"`
 }
 
 // HostConfig the non-portable Config structure of a container.
@@ -393,6 +582,7 @@ type HostConfig struct {
 	Binds           []string      // List of volume bindings for this container
 	ContainerIDFile string        // File (path) where the containerId is written
 	LogConfig       LogConfig     // Configuration of the logs for this container
+	Stdio           StdioConfig   // Buffering and overflow policy for this container's stdout/stderr
 	NetworkMode     NetworkMode   // Network mode to use for the container
 	PortBindings    nat.PortMap   // Port mapping between the exposed port (container) and the host
 	RestartPolicy   RestartPolicy // Restart policy to be used for the container
@@ -400,6 +590,13 @@ type HostConfig struct {
 	VolumeDriver    string        // Name of the volume driver used to mount volumes
 	VolumesFrom     []string      // List of volumes to take from other container
 
+	// PostStartHook, if set, is executed inside the container immediately
+	// after it starts.
+	PostStartHook *LifecycleHook `json:",omitempty"`
+	// PreStopHook, if set, is executed inside the container before the
+	// daemon sends it a stop signal.
+	PreStopHook *LifecycleHook `json:",omitempty"`
+
 	// Applicable to UNIX platforms
 	CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
 	CapDrop         strslice.StrSlice // List of kernel capabilities to remove from the container
@@ -419,16 +616,36 @@ type HostConfig struct {
 	ReadonlyRootfs  bool              // Is the container root filesystem in read-only
 	SecurityOpt     []string          // List of string values to customize labels for MLS systems, such as SELinux.
 	StorageOpt      map[string]string `json:",omitempty"` // Storage driver options per container.
+	DiskQuota       int64             `json:",omitempty"` // Size limit, in bytes, of the container's writable layer. Equivalent to setting StorageOpt["size"]; only enforced by storage drivers with project-quota support (overlay2, devicemapper, btrfs, zfs).
 	Tmpfs           map[string]string `json:",omitempty"` // List of tmpfs (mounts) used for the container
 	UTSMode         UTSMode           // UTS namespace to use for the container
+	TimeMode        TimeMode          `json:",omitempty"` // Time namespace to use for the container
 	UsernsMode      UsernsMode        // The user namespace to use for the container
-	ShmSize         int64             // Total shm memory usage
-	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
-	Runtime         string            `json:",omitempty"` // Runtime to use with this container
+	// UIDMappings and GIDMappings give this container its own user
+	// namespace mapping instead of the daemon-wide one set by the
+	// userns-remap daemon flag, so mappings can differ per container on a
+	// multi-tenant node. Only meaningful with UsernsMode set to a private
+	// (non-"host") value; both must be set together, or not at all.
+	//
+	// Only the container's own writable layer, init layer and working
+	// directory are chowned to match - shared, read-only image layers keep
+	// whatever ownership they were pulled with, since a single on-disk
+	// copy can't satisfy two different mappings at once without idmapped
+	// mounts, which this daemon does not implement. Files in those layers
+	// not owned by a uid/gid in range will appear owned by the overflow
+	// uid/gid (typically 65534) inside the container.
+	UIDMappings []IDMap           `json:",omitempty"`
+	GIDMappings []IDMap           `json:",omitempty"`
+	ShmSize     int64             // Total shm memory usage
+	Sysctls     map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
+	Runtime     string            `json:",omitempty"` // Runtime to use with this container
+	RuntimeArgs []string          `json:",omitempty"` // Extra arguments to pass to the OCI runtime binary, overriding any arguments configured for Runtime in the daemon
 
 	// Applicable to Windows
-	ConsoleSize [2]uint   // Initial console size (height,width)
-	Isolation   Isolation // Isolation technology of the container (e.g. default, hyperv)
+	ConsoleSize [2]uint                  // Initial console size (height,width)
+	Isolation   Isolation                // Isolation technology of the container (e.g. default, hyperv)
+	HostProcess bool                     // Run as a Windows host-process container, sharing the host's filesystem and kernel instead of a container sandbox
+	NamedPipes  []NamedPipePublishConfig // Named pipes to publish from the container to the host
 
 	// Contains container's resources (cgroups, ulimits)
 	Resources
@@ -442,6 +659,45 @@ type HostConfig struct {
 	// ReadonlyPaths is the list of paths to be set as read-only inside the container (this overrides the default set of paths)
 	ReadonlyPaths []string
 
+	// MaskedPathsAdd and MaskedPathsDrop add and remove entries from the
+	// default set of masked paths (the daemon's --default-masked-paths, or
+	// the built-in list if that isn't set), instead of replacing it wholesale
+	// like MaskedPaths does. Ignored if MaskedPaths is also set, or the
+	// container is privileged.
+	MaskedPathsAdd  []string `json:",omitempty"`
+	MaskedPathsDrop []string `json:",omitempty"`
+
+	// ReadonlyPathsAdd and ReadonlyPathsDrop add and remove entries from the
+	// default set of read-only paths (the daemon's --default-readonly-paths,
+	// or the built-in list if that isn't set), instead of replacing it
+	// wholesale like ReadonlyPaths does. Ignored if ReadonlyPaths is also
+	// set, or the container is privileged.
+	ReadonlyPathsAdd  []string `json:",omitempty"`
+	ReadonlyPathsDrop []string `json:",omitempty"`
+
 	// Run a custom init inside the container, if null, use the daemon's configured settings
 	Init *bool `json:",omitempty"`
+
+	// ClockSyncStatusFile makes the daemon inject a file reporting the
+	// host's clock synchronization status (offset, stratum) into the
+	// container. The file is written when the container starts and is not
+	// refreshed while it runs.
+	ClockSyncStatusFile bool `json:",omitempty"`
+
+	// DependsOn lists names or IDs of containers that must be running
+	// (and healthy, if they have a healthcheck) before this container is
+	// started. It is honored both for manual `start` and for the
+	// daemon's restart-on-boot restore.
+	DependsOn []string `json:",omitempty"`
+
+	// WaitFor lists host-level services the daemon must observe as ready
+	// before starting this container. It is honored both for manual
+	// `start` and for the daemon's restart-on-boot restore, and exists
+	// to close the race between docker.service and the network/storage
+	// services a container depends on when everything starts at boot.
+	//
+	// Each entry has one of the following forms:
+	//   - "unit:<name>": wait for the systemd unit <name> to become active
+	//   - "tcp:<host>:<port>": wait until a TCP connection to host:port succeeds
+	WaitFor []string `json:",omitempty"`
 }
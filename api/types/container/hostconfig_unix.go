@@ -1,3 +1,4 @@
+//go:build !windows
 // +build !windows
 
 package container // import "github.com/docker/docker/api/types/container"
@@ -15,6 +16,8 @@ func (n NetworkMode) NetworkName() string {
 		return "host"
 	} else if n.IsContainer() {
 		return "container"
+	} else if n.IsPod() {
+		return "pod"
 	} else if n.IsNone() {
 		return "none"
 	} else if n.IsDefault() {
@@ -37,5 +40,5 @@ func (n NetworkMode) IsHost() bool {
 
 // IsUserDefined indicates user-created network
 func (n NetworkMode) IsUserDefined() bool {
-	return !n.IsDefault() && !n.IsBridge() && !n.IsHost() && !n.IsNone() && !n.IsContainer()
+	return !n.IsDefault() && !n.IsBridge() && !n.IsHost() && !n.IsNone() && !n.IsContainer() && !n.IsPod()
 }
@@ -0,0 +1,16 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+// ContainersWaitResult is one container's result from a multi-container
+// wait request, identified by Name so the caller can tell which container
+// it belongs to.
+type ContainersWaitResult struct {
+	// Name is the name or ID that was passed in the wait request.
+	Name string `json:"Name"`
+
+	// StatusCode is the exit code of the container.
+	StatusCode int64 `json:"StatusCode"`
+
+	// Error is non-nil if an error occurred either starting the wait or
+	// while waiting for this specific container.
+	Error *ContainerWaitOKBodyError `json:"Error,omitempty"`
+}
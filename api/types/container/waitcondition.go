@@ -15,8 +15,14 @@ type WaitCondition string
 // or is removed.
 //
 // WaitConditionRemoved is used to wait for the container to be removed.
+//
+// WaitConditionHealthy and WaitConditionUnhealthy are used to wait for the
+// container's healthcheck to report the matching status. A container
+// without a healthcheck configured never satisfies either of these.
 const (
 	WaitConditionNotRunning WaitCondition = "not-running"
 	WaitConditionNextExit   WaitCondition = "next-exit"
 	WaitConditionRemoved    WaitCondition = "removed"
+	WaitConditionHealthy    WaitCondition = "healthy"
+	WaitConditionUnhealthy  WaitCondition = "unhealthy"
 )
@@ -0,0 +1,31 @@
+package types // import "github.com/docker/docker/api/types"
+
+// DuplicateFileLocation identifies one occurrence of a file whose content
+// is duplicated across layers in the layer store.
+type DuplicateFileLocation struct {
+	// ChainID is the layer the file was found in.
+	ChainID string
+	// Path is the file's path within that layer.
+	Path string
+}
+
+// DuplicateFileGroup groups the locations of a file whose content is
+// identical across two or more layers.
+type DuplicateFileGroup struct {
+	// Digest is the content hash (sha256) shared by every file in Locations.
+	Digest string
+	// Size is the size, in bytes, of a single copy of the file.
+	Size int64
+	// Locations lists every layer/path pair found to have this content.
+	Locations []DuplicateFileLocation
+}
+
+// DedupReport is the response to a `docker system df --dedup` request. It
+// reports files whose content is duplicated across layers in the layer
+// store, along with the space that could be reclaimed by deduplicating
+// them, e.g. by rewriting the duplicate copies as reflinks on a supporting
+// filesystem.
+type DedupReport struct {
+	DuplicateFiles  []DuplicateFileGroup
+	ReclaimableSize int64
+}
@@ -0,0 +1,16 @@
+package types // import "github.com/docker/docker/api/types"
+
+import "time"
+
+// ImagePin describes a reference pinned to a specific image digest, which
+// prune and retag operations refuse to move or remove until it's
+// explicitly unpinned.
+type ImagePin struct {
+	Reference        string
+	Digest           string
+	PinnedAt         time.Time
+	ReverifyInterval time.Duration `json:",omitempty"`
+	RemoteDigest     string        `json:",omitempty"`
+	LastCheckedAt    time.Time     `json:",omitempty"`
+	Moved            bool          `json:",omitempty"`
+}
@@ -0,0 +1,36 @@
+package types // import "github.com/docker/docker/api/types"
+
+import (
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ManifestListSource names one image, already present in the target
+// repository under Ref (by tag or digest), to include as an entry of a
+// manifest list created by ManifestListCreateRequest.
+type ManifestListSource struct {
+	Ref string
+	// Annotations are attached to this entry's descriptor in the
+	// resulting manifest list.
+	Annotations map[string]string `json:",omitempty"`
+}
+
+// ManifestListCreateRequest is the request body of POST
+// /distribution/manifests/create. Target and every entry in Sources must
+// already exist in the same repository; this call only assembles and
+// pushes an OCI image index over existing manifests, it does not push
+// image content.
+type ManifestListCreateRequest struct {
+	Target  string
+	Sources []ManifestListSource
+	// Platforms, if non-empty, restricts the manifest list to sources
+	// matching one of these platforms. A zero-value field within an
+	// entry (e.g. an empty Variant) matches any value for that field.
+	Platforms []specs.Platform `json:",omitempty"`
+}
+
+// ManifestListCreateResponse is the response body of POST
+// /distribution/manifests/create.
+type ManifestListCreateResponse struct {
+	Digest digest.Digest
+}
@@ -17,6 +17,8 @@ const (
 	TypeTmpfs Type = "tmpfs"
 	// TypeNamedPipe is the type for mounting Windows named pipes
 	TypeNamedPipe Type = "npipe"
+	// TypeImage is the type for mounting another image's filesystem
+	TypeImage Type = "image"
 )
 
 // Mount represents a mount (volume).
@@ -25,6 +27,7 @@ type Mount struct {
 	// Source specifies the name of the mount. Depending on mount type, this
 	// may be a volume name or a host path, or even ignored.
 	// Source is not supported for tmpfs (must be an empty value)
+	// For TypeImage, Source is the image reference to mount from.
 	Source      string      `json:",omitempty"`
 	Target      string      `json:",omitempty"`
 	ReadOnly    bool        `json:",omitempty"`
@@ -33,6 +36,7 @@ type Mount struct {
 	BindOptions   *BindOptions   `json:",omitempty"`
 	VolumeOptions *VolumeOptions `json:",omitempty"`
 	TmpfsOptions  *TmpfsOptions  `json:",omitempty"`
+	ImageOptions  *ImageOptions  `json:",omitempty"`
 }
 
 // Propagation represents the propagation of a mount.
@@ -96,6 +100,13 @@ type Driver struct {
 	Options map[string]string `json:",omitempty"`
 }
 
+// ImageOptions represents the options for a mount of type "image".
+type ImageOptions struct {
+	// Subpath is the path inside of the image to mount, relative to
+	// the image's root. Empty means the whole image.
+	Subpath string `json:",omitempty"`
+}
+
 // TmpfsOptions defines options specific to mounts of type "tmpfs".
 type TmpfsOptions struct {
 	// Size sets the size of the tmpfs, in bytes.
@@ -0,0 +1,35 @@
+package types // import "github.com/docker/docker/api/types"
+
+import "time"
+
+// Operation describes the progress of one long-running daemon operation,
+// for the /operations API. It's a deliberately small, generic schema
+// (id, stage, current/total, message) that's meant to fit pull, push,
+// build, load, prune, and similar operations, so scripts can poll or list
+// in-flight work without having to understand each operation's own
+// progress format.
+type Operation struct {
+	// ID identifies this operation. It's opaque and only has meaning to
+	// the /operations API.
+	ID string
+
+	// Kind is a short, stable name for the type of operation, such as
+	// "container-prune" or "image-prune".
+	Kind string
+
+	// Stage is a short, human-readable description of what the operation
+	// is currently doing.
+	Stage string
+
+	// Current and Total describe progress within the current stage, when
+	// known. Total is 0 if the size of the work isn't known up front.
+	Current int64
+	Total   int64
+
+	// Message carries additional detail, such as an item currently being
+	// processed.
+	Message string
+
+	// StartedAt is when the operation began.
+	StartedAt time.Time
+}
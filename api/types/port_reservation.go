@@ -0,0 +1,31 @@
+package types // import "github.com/docker/docker/api/types"
+
+// PortReservationRequest is the request body for POST /system/ports/reserve.
+type PortReservationRequest struct {
+	// Proto is the transport protocol to reserve ports for, "tcp" or
+	// "udp". Defaults to "tcp".
+	Proto string
+
+	// HostIP is the host address the ports would be bound to. Empty means
+	// any address (0.0.0.0).
+	HostIP string
+
+	// RangeStart and RangeEnd bound the inclusive range of host ports to
+	// search for free ports in.
+	RangeStart uint16
+	RangeEnd   uint16
+
+	// Count is how many free ports to find and reserve within the range.
+	Count int
+}
+
+// PortReservation is the response body for POST /system/ports/reserve.
+type PortReservation struct {
+	// Token identifies this reservation so it can be released early with
+	// DELETE /system/ports/reserve/{token}. An unreleased reservation
+	// expires on its own after a short time.
+	Token string
+
+	// Ports are the host ports that were reserved, in ascending order.
+	Ports []int
+}
@@ -0,0 +1,12 @@
+package types // import "github.com/docker/docker/api/types"
+
+import "time"
+
+// PrePullEntry describes one tag the daemon keeps pulled and up to date on
+// a schedule.
+type PrePullEntry struct {
+	Reference    string
+	Interval     time.Duration
+	LastPulledAt time.Time `json:",omitempty"`
+	LastError    string    `json:",omitempty"`
+}
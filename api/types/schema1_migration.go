@@ -0,0 +1,16 @@
+package types // import "github.com/docker/docker/api/types"
+
+// Schema1MigrationFailure records a tag that couldn't be migrated off a
+// schema1 manifest.
+type Schema1MigrationFailure struct {
+	Tag    string
+	Reason string
+}
+
+// Schema1MigrationReport is the response to a `docker system
+// schema1-migrate` request.
+type Schema1MigrationReport struct {
+	RepositoriesChecked int
+	Migrated            []string
+	Failed              []Schema1MigrationFailure
+}
@@ -52,6 +52,49 @@ type ImageInspect struct {
 // ImageMetadata contains engine-local data about the image
 type ImageMetadata struct {
 	LastTagTime time.Time `json:",omitempty"`
+	// Verification is the trust policy decision recorded for this image's
+	// repository the last time it was pulled, or nil if the daemon has no
+	// trust policy configured or this repository was never pulled under
+	// one. See daemon/trustpolicy for what Verified does and doesn't mean.
+	Verification *ImageVerification `json:",omitempty"`
+	// VulnerabilityScan is the vulnerability scan result recorded for
+	// this image the last time it was pulled or built, or nil if the
+	// daemon has no vulnerability scanner configured or this image
+	// hasn't been scanned since the daemon last started. See
+	// daemon/imagescan.
+	VulnerabilityScan *ImageVulnerabilityScan `json:",omitempty"`
+}
+
+// ImageVerification is the trust policy decision made for the repository an
+// image was pulled from.
+type ImageVerification struct {
+	Repository        string
+	PolicyMatched     bool
+	RequireSignatures []string `json:",omitempty"`
+	Verified          bool
+	Reason            string
+}
+
+// ImageVulnerabilityScan is the result of running the daemon's configured
+// vulnerability scanner plugin against an image.
+type ImageVulnerabilityScan struct {
+	Findings []VulnerabilityFinding `json:",omitempty"`
+	// Blocked reports whether a Finding was at or above the configured
+	// block severity at scan time. It does not change retroactively if
+	// the policy is reconfigured afterwards.
+	Blocked   bool
+	Reason    string `json:",omitempty"`
+	ScannedAt time.Time
+}
+
+// VulnerabilityFinding is a single issue the vulnerability scanner plugin
+// reported against an image.
+type VulnerabilityFinding struct {
+	ID          string
+	Severity    string
+	Package     string
+	Version     string
+	Description string
 }
 
 // Container contains response of Engine API:
@@ -66,10 +109,15 @@ type Container struct {
 	Ports      []Port
 	SizeRw     int64 `json:",omitempty"`
 	SizeRootFs int64 `json:",omitempty"`
-	Labels     map[string]string
-	State      string
-	Status     string
-	HostConfig struct {
+	// SizeRwQuota is the configured size limit, in bytes, of the container's
+	// writable layer (see HostConfig.DiskQuota), or 0 if none is set. It is
+	// reported alongside SizeRw so `docker system df -v` can show usage
+	// against quota.
+	SizeRwQuota int64 `json:",omitempty"`
+	Labels      map[string]string
+	State       string
+	Status      string
+	HostConfig  struct {
 		NetworkMode string `json:",omitempty"`
 	}
 	NetworkSettings *SummaryNetworkSettings
@@ -146,14 +194,19 @@ type Commit struct {
 // Info contains response of Engine API:
 // GET "/info"
 type Info struct {
-	ID                 string
-	Containers         int
-	ContainersRunning  int
-	ContainersPaused   int
-	ContainersStopped  int
-	Images             int
-	Driver             string
-	DriverStatus       [][2]string
+	ID                string
+	Containers        int
+	ContainersRunning int
+	ContainersPaused  int
+	ContainersStopped int
+	Images            int
+	Driver            string
+	DriverStatus      [][2]string
+	// DriverHealth reports the results of the storage driver's self-test,
+	// such as missing d_type or native-diff support, run each time this
+	// info is gathered. It is empty if the driver does not implement a
+	// self-test.
+	DriverHealth       [][2]string `json:",omitempty"`
 	SystemStatus       [][2]string `json:",omitempty"` // SystemStatus is only propagated by the Swarm standalone API
 	Plugins            PluginsInfo
 	MemoryLimit        bool
@@ -213,6 +266,82 @@ type Info struct {
 	ProductLicense      string               `json:",omitempty"`
 	DefaultAddressPools []NetworkAddressPool `json:",omitempty"`
 	Warnings            []string
+
+	// Provisioning reports the outcome of the first-boot provisioning
+	// manifest, if the daemon was configured with one.
+	Provisioning *ProvisioningStatus `json:",omitempty"`
+
+	// ContainerdPlugins lists the containerd plugins (snapshotters,
+	// runtimes, content stores, ...) known to the containerd instance
+	// backing this daemon, including any that failed to initialize.
+	// See also the more detailed /system/containerd endpoint.
+	ContainerdPlugins []ContainerdPlugin `json:",omitempty"`
+
+	// RegistryRateLimits reports the most recently observed rate-limit
+	// quota advertised by each registry host the daemon has pulled from
+	// since it started, keyed by host. Hosts that don't advertise a quota
+	// (most self-hosted registries) are absent from this map.
+	RegistryRateLimits map[string]RegistryRateLimit `json:",omitempty"`
+
+	// UserlandProxies reports resource usage for each running userland
+	// proxy process (docker-proxy) that forwards a published port. It is
+	// only populated when the userland proxy is enabled.
+	//
+	// Note: the userland proxy can only be enabled or disabled for the
+	// whole daemon (--userland-proxy), not per published port or per
+	// network; the vendored libnetwork bridge driver bakes the setting
+	// into its driver-wide configuration rather than per-network or
+	// per-endpoint state.
+	UserlandProxies []UserlandProxyUsage `json:",omitempty"`
+}
+
+// UserlandProxyUsage reports resource usage for a single running
+// docker-proxy process.
+type UserlandProxyUsage struct {
+	HostIP     string
+	HostPort   int
+	Proto      string
+	PID        int
+	RSSBytes   uint64
+	CPUSeconds float64
+}
+
+// RegistryRateLimit reports a registry's rate-limit quota, as last observed
+// on a response from that registry.
+type RegistryRateLimit struct {
+	// Limit is the size of the quota window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// WindowSeconds is the length of the quota window, in seconds, as
+	// reported by the registry. It is 0 if the registry didn't report one.
+	WindowSeconds int
+}
+
+// ContainerdPlugin describes a single plugin reported by containerd's
+// introspection API.
+type ContainerdPlugin struct {
+	// Type is the containerd plugin type, e.g. "io.containerd.snapshotter.v1".
+	Type string
+	// ID identifies the plugin within its type, e.g. "overlayfs".
+	ID string
+	// Requires lists the plugin types this plugin depends on.
+	Requires []string `json:",omitempty"`
+	// InitErr holds the error containerd reported while initializing this
+	// plugin, if any. Plugins with a non-empty InitErr cannot be used.
+	InitErr string `json:",omitempty"`
+}
+
+// ProvisioningStatus reports the outcome of applying a first-boot
+// provisioning manifest.
+type ProvisioningStatus struct {
+	// Source is the file path or URL the manifest was loaded from.
+	Source string
+	// Applied is true once every object in the manifest has been
+	// created without error.
+	Applied bool
+	// Error holds the error that aborted provisioning, if any.
+	Error string `json:",omitempty"`
 }
 
 // KeyValue holds a key/value pair
@@ -361,8 +490,53 @@ type ContainerJSONBase struct {
 	ExecIDs         []string
 	HostConfig      *container.HostConfig
 	GraphDriver     GraphDriverData
-	SizeRw          *int64 `json:",omitempty"`
-	SizeRootFs      *int64 `json:",omitempty"`
+	// CapabilityAudit reports the container's effective Linux capability
+	// set, for review under `docker inspect --security`. Nil on platforms
+	// without Linux capabilities.
+	CapabilityAudit *CapabilityAudit `json:",omitempty"`
+	SizeRw          *int64           `json:",omitempty"`
+	SizeRootFs      *int64           `json:",omitempty"`
+
+	// Runtime exposes the OCI runtime state of the container's init
+	// process, below the Docker abstraction, for operators debugging
+	// issues that can't be diagnosed from the normal State alone. It's
+	// only populated while the container has a live task.
+	Runtime *ContainerRuntimeInfo `json:",omitempty"`
+}
+
+// CapabilityAudit reports a container's effective Linux capability set, to
+// help an operator trim it down to CapAdd/CapDrop values the workload
+// actually needs.
+type CapabilityAudit struct {
+	// Granted is the final effective capability set: the daemon's default
+	// capabilities plus CapAdd, minus CapDrop (or the full capability set,
+	// if the container is privileged).
+	Granted []string
+	// Note explains that Granted is the configured set, not a
+	// usage-derived suggestion: telling which of these capabilities the
+	// container actually exercises needs kprobe/eBPF instrumentation this
+	// daemon doesn't have, so it can't be narrowed down automatically.
+	Note string
+}
+
+// ContainerRuntimeInfo describes the OCI runtime (e.g. runc, via
+// containerd) state of a container's init process.
+type ContainerRuntimeInfo struct {
+	BundlePath  string
+	Pid         int
+	Status      string
+	CgroupsPath string
+	Annotations map[string]string `json:",omitempty"`
+	// Events holds the most recent runtime-level events observed for the
+	// container (start, exit, oom, pause, resume, ...), oldest first.
+	Events []ContainerRuntimeEvent `json:",omitempty"`
+}
+
+// ContainerRuntimeEvent is a single runtime-level event observed for a
+// container, as reported by libcontainerd.
+type ContainerRuntimeEvent struct {
+	Type string
+	Time time.Time
 }
 
 // ContainerJSON is newly used struct along with MountPoint
@@ -519,6 +693,14 @@ type Runtime struct {
 	Path string   `json:"path"`
 	Args []string `json:"runtimeArgs,omitempty"`
 
+	// Sandboxed marks this as a VM-isolated runtime (e.g. Kata Containers,
+	// gVisor) rather than a regular, host-namespace-sharing OCI runtime.
+	// Containers using it report Isolation: "sandbox" on inspect, and the
+	// daemon skips host-specific spec tweaks that don't apply to a
+	// separately-kernelled sandbox, such as bind-mounting the host's
+	// /dev/shm for --ipc=host or wiring up host cgroup paths.
+	Sandboxed bool `json:"sandboxed,omitempty"`
+
 	// This is exposed here only for internal use
 	// It is not currently supported to specify custom shim configs
 	Shim *ShimConfig `json:"-"`
@@ -539,6 +721,9 @@ type DiskUsage struct {
 	Volumes     []*Volume
 	BuildCache  []*BuildCache
 	BuilderSize int64 // deprecated
+	// Dedup is only populated when disk usage is queried with the dedup
+	// option, since walking every layer's contents is expensive.
+	Dedup *DedupReport `json:",omitempty"`
 }
 
 // ContainersPruneReport contains the response for Engine API:
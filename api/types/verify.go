@@ -0,0 +1,41 @@
+package types // import "github.com/docker/docker/api/types"
+
+// CorruptLayer identifies a layer whose on-disk content no longer hashes
+// to the digest recorded for it at registration time.
+type CorruptLayer struct {
+	// DiffID is the digest the layer was registered under.
+	DiffID string
+	// ActualDigest is what the layer's content hashes to now.
+	ActualDigest string
+	// RepoTags lists tags of images still referencing this layer, i.e.
+	// images a re-pull could repair.
+	RepoTags []string
+}
+
+// CorruptImage identifies an image config blob whose content no longer
+// hashes to its own image ID.
+type CorruptImage struct {
+	// ImageID is the image's ID, which for a healthy image is also the
+	// digest of its own raw JSON config.
+	ImageID string
+	// ActualDigest is what the config blob hashes to now.
+	ActualDigest string
+}
+
+// ContentVerifyReport is the response to a `docker system verify` request.
+// It reports layers and image config blobs whose content no longer
+// matches the digest recorded for them, which indicates on-disk
+// corruption (bit rot, an interrupted write, manual tampering with the
+// data root, and the like).
+type ContentVerifyReport struct {
+	LayersChecked int
+	ImagesChecked int
+	CorruptLayers []CorruptLayer
+	CorruptImages []CorruptImage
+	// RepairedTags lists tags that were successfully re-pulled to replace
+	// a corrupt layer. A corrupt layer/image not listed here either has
+	// no tag to re-pull from, or the re-pull attempt itself failed (for
+	// example because it requires registry credentials this check does
+	// not have).
+	RepairedTags []string
+}
@@ -0,0 +1,12 @@
+package volume // import "github.com/docker/docker/api/types/volume"
+
+// VolumeCopyBody is the request body for creating a new volume that is a
+// point-in-time copy of an existing one, via either
+// POST /volumes/{name}/snapshot or POST /volumes/{name}/clone.
+type VolumeCopyBody struct {
+	// Name of the new volume. If not specified, Docker generates a name.
+	Name string `json:"Name"`
+
+	// User-defined key/value metadata for the new volume.
+	Labels map[string]string `json:"Labels,omitempty"`
+}
@@ -16,6 +16,19 @@ const (
 	defaultShutdownTimeout = 15
 	// defaultTrustKeyFile is the default filename for the trust key
 	defaultTrustKeyFile = "key.json"
+	// defaultStatsHistoryInterval is the default sampling interval, in
+	// seconds, for the daemon's container stats history store
+	defaultStatsHistoryInterval = 10
+	// defaultStatsHistoryMaxSamples is the default number of stats history
+	// samples retained per container
+	defaultStatsHistoryMaxSamples = 360
+	// defaultLockWatchdogThreshold is the default duration, in seconds, a
+	// container lock may be held before the lock watchdog warns about it
+	defaultLockWatchdogThreshold = 30
+	// defaultFailedBundleRetentionHours is the default number of hours to
+	// retain the libcontainerd bundle of a container that failed to start.
+	// 0 disables retention.
+	defaultFailedBundleRetentionHours = 0
 )
 
 // installCommonConfigFlags adds flags to the pflag.FlagSet to configure the daemon
@@ -38,6 +51,7 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) error {
 
 	flags.Var(opts.NewNamedListOptsRef("storage-opts", &conf.GraphOptions, nil), "storage-opt", "Storage driver options")
 	flags.Var(opts.NewNamedListOptsRef("authorization-plugins", &conf.AuthorizationPlugins, nil), "authorization-plugin", "Authorization plugins to load")
+	flags.Var(opts.NewNamedListOptsRef("container-hooks-plugins", &conf.ContainerHooksPlugins, nil), "container-hooks-plugin", "Container-hooks plugins to load")
 	flags.Var(opts.NewNamedListOptsRef("exec-opts", &conf.ExecOptions, nil), "exec-opt", "Runtime execution options")
 	flags.StringVarP(&conf.Pidfile, "pidfile", "p", defaultPidFile, "Path to use for daemon PID file")
 	flags.StringVarP(&conf.Root, "graph", "g", defaultDataRoot, "Root of the Docker runtime")
@@ -64,7 +78,11 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) error {
 	flags.Var(opts.NewListOptsRef(&conf.DNS, opts.ValidateIPAddress), "dns", "DNS server to use")
 	flags.Var(opts.NewNamedListOptsRef("dns-opts", &conf.DNSOptions, nil), "dns-opt", "DNS options to use")
 	flags.Var(opts.NewListOptsRef(&conf.DNSSearch, opts.ValidateDNSSearch), "dns-search", "DNS search domains to use")
+	flags.Var(opts.NewListOptsRef(&conf.ExtraHostsLabels, nil), "extra-hosts-label", "Container label key whose value adds extra /etc/hosts entries (comma-separated host:IP pairs, same syntax as --add-host)")
 	flags.Var(opts.NewIPOpt(&conf.HostGatewayIP, ""), "host-gateway-ip", "IP address that the special 'host-gateway' string in --add-host resolves to. Defaults to the IP address of the default bridge")
+	flags.IntVar(&conf.DNSCacheSize, "dns-cache-size", 0, "Size of the embedded DNS server's cache (not implemented by this build)")
+	flags.IntVar(&conf.DNSCachePositiveTTL, "dns-cache-positive-ttl", 0, "TTL override for positive answers from the embedded DNS server (not implemented by this build)")
+	flags.IntVar(&conf.DNSCacheNegativeTTL, "dns-cache-negative-ttl", 0, "TTL override for negative answers from the embedded DNS server (not implemented by this build)")
 	flags.Var(opts.NewNamedListOptsRef("labels", &conf.Labels, opts.ValidateLabel), "label", "Set key=value labels to the daemon")
 	flags.StringVar(&conf.LogConfig.Type, "log-driver", "json-file", "Default driver for container logs")
 	flags.Var(opts.NewNamedMapOpts("log-opts", conf.LogConfig.Config, nil), "log-opt", "Default log driver options for containers")
@@ -81,12 +99,31 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) error {
 	flags.IntVar(&maxConcurrentUploads, "max-concurrent-uploads", config.DefaultMaxConcurrentUploads, "Set the max concurrent uploads for each push")
 	flags.IntVar(&maxDownloadAttempts, "max-download-attempts", config.DefaultDownloadAttempts, "Set the max download attempts for each pull")
 	flags.IntVar(&conf.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "Set the default shutdown timeout")
+	flags.IntVar(&conf.ShutdownParallelism, "shutdown-parallelism", 0, "Set the maximum number of containers to stop concurrently on shutdown (0 means no limit)")
+	flags.StringVar(&conf.ShutdownPriorityLabel, "shutdown-priority-label", "", "Set a container label holding an integer priority used to order container shutdown")
+	flags.BoolVar(&conf.LockWatchdogEnabled, "debug-lock-watchdog", false, "Enable the internal lock profiling watchdog to help diagnose wedged containers")
+	flags.IntVar(&conf.LockWatchdogThreshold, "debug-lock-watchdog-threshold", defaultLockWatchdogThreshold, "Set the duration (in seconds) a container lock may be held before the lock watchdog warns about it")
+	_ = flags.MarkHidden("debug-lock-watchdog")
+	_ = flags.MarkHidden("debug-lock-watchdog-threshold")
+	flags.IntVar(&conf.FailedBundleRetentionHours, "debug-failed-bundle-retention-hours", defaultFailedBundleRetentionHours, "Set the number of hours to retain the libcontainerd bundle of a container that failed to start, for post-mortem debugging (0 disables retention)")
+	_ = flags.MarkHidden("debug-failed-bundle-retention-hours")
 	flags.IntVar(&conf.NetworkDiagnosticPort, "network-diagnostic-port", 0, "TCP port number of the network diagnostic server")
 	_ = flags.MarkHidden("network-diagnostic-port")
+	flags.IntVar(&conf.StatsHistoryInterval, "stats-history-interval", defaultStatsHistoryInterval, "Set the interval (in seconds) at which container stats history is sampled")
+	flags.IntVar(&conf.StatsHistoryMaxSamples, "stats-history-max-samples", defaultStatsHistoryMaxSamples, "Set the maximum number of stats history samples retained per container")
 
 	flags.StringVar(&conf.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
+	flags.StringVar(&conf.ServeRegistryAddress, "serve-registry", "", "Serve a read-only subset of the registry v2 API (version probe and catalog only) over the local image store on this address, for peer daemons to use as a registry-mirror")
+	flags.StringVar(&conf.P2PDistributionCommand, "p2p-distribution-command", "", "External command invoked as '<command> <digest>' to fetch a layer blob from a peer-to-peer distributor before falling back to the registry")
+	flags.Var(opts.NewNamedMapOpts("credential-helpers", conf.CredentialHelpers, nil), "credential-helper", "Register a docker-credential-helpers-compatible binary to fetch credentials for a registry hostname (e.g. \"123456789.dkr.ecr.us-east-1.amazonaws.com=ecr-login\"), for pulls that have none of their own")
+	flags.Var(opts.NewNamedMapOpts("pre-pull-images", conf.PrePullImages, nil), "pre-pull-image", "Keep an image tag pulled and up to date on a schedule and exempt it from image prune (e.g. \"myorg/base:latest=1h\")")
+	flags.StringVar(&conf.TrustPolicyPath, "trust-policy-file", "", "Path to a JSON file mapping registries/repositories to required image signatures; Pull rejects images this build cannot verify against it")
+	flags.StringVar(&conf.VulnerabilityScanCommand, "vulnerability-scan-command", "", "External command run after each pull/build to scan the resulting image for vulnerabilities")
+	flags.StringVar(&conf.VulnerabilityBlockSeverity, "vulnerability-block-severity", "", "Minimum finding severity (low, medium, high, critical) from --vulnerability-scan-command that blocks running the image")
+	flags.BoolVar(&conf.ContainerNetworkMetrics, "container-network-metrics", false, "Export per-container, per-network traffic counters on the metrics endpoint (adds a container_id label)")
+	flags.BoolVar(&conf.StorageDriverHealthCheckFailFast, "storage-driver-health-check-fail-fast", false, "Abort daemon startup if the storage driver self-test reports a problem, instead of only recording it in 'docker info'")
 
 	flags.Var(opts.NewNamedListOptsRef("node-generic-resources", &conf.NodeGenericResources, opts.ValidateSingleGenericResource), "node-generic-resource", "Advertise user-defined resource")
 
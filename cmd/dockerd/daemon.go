@@ -16,6 +16,7 @@ import (
 	buildbackend "github.com/docker/docker/api/server/backend/build"
 	"github.com/docker/docker/api/server/middleware"
 	"github.com/docker/docker/api/server/router"
+	artifactrouter "github.com/docker/docker/api/server/router/artifact"
 	"github.com/docker/docker/api/server/router/build"
 	checkpointrouter "github.com/docker/docker/api/server/router/checkpoint"
 	"github.com/docker/docker/api/server/router/container"
@@ -209,6 +210,10 @@ func (cli *DaemonCli) start(opts *daemonOptions) (err error) {
 		return errors.Wrap(err, "failed to start metrics server")
 	}
 
+	if err := startRegistryCacheServer(cli.Config.ServeRegistryAddress, d); err != nil {
+		return errors.Wrap(err, "failed to start registry cache server")
+	}
+
 	c, err := createAndStartCluster(cli, d)
 	if err != nil {
 		logrus.Fatalf("Error starting cluster component: %v", err)
@@ -471,6 +476,7 @@ func initRouter(opts routerOptions) {
 		swarmrouter.NewRouter(opts.cluster),
 		pluginrouter.NewRouter(opts.daemon.PluginManager()),
 		distributionrouter.NewRouter(opts.daemon.ImageService()),
+		artifactrouter.NewRouter(opts.daemon),
 	}
 
 	grpcBackends := []grpcrouter.Backend{}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/daemon"
+	"github.com/sirupsen/logrus"
+)
+
+var registryManifestOrBlobPath = regexp.MustCompile(`^/v2/(.+)/(manifests|blobs)/[^/]+$`)
+
+// startRegistryCacheServer serves the read-only subset of the registry v2
+// API described on config.CommonConfig.ServeRegistryAddress over d's local
+// image store, if addr is set.
+func startRegistryCacheServer(addr string, d *daemon.Daemon) error {
+	if addr == "" {
+		return nil
+	}
+	if err := allocateDaemonPort(addr); err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+		switch {
+		case r.URL.Path == "/v2/" || r.URL.Path == "/v2":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v2/_catalog":
+			serveRegistryCacheCatalog(w, d)
+		case registryManifestOrBlobPath.MatchString(r.URL.Path):
+			writeRegistryCacheError(w, http.StatusNotImplemented, "UNSUPPORTED",
+				"this daemon's --serve-registry only serves the API version probe and _catalog; "+
+					"it does not retain the original manifest or blob bytes needed to answer this request")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	go func() {
+		logrus.Infof("registry cache API listening on %s", l.Addr())
+		if err := http.Serve(l, mux); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+			logrus.WithError(err).Error("error serving registry cache API")
+		}
+	}()
+	return nil
+}
+
+// serveRegistryCacheCatalog answers GET /v2/_catalog with the repository
+// names (without tags) of every image in d's local store.
+func serveRegistryCacheCatalog(w http.ResponseWriter, d *daemon.Daemon) {
+	summaries, err := d.ImageService().Images(filters.NewArgs(), false, false)
+	if err != nil {
+		writeRegistryCacheError(w, http.StatusInternalServerError, "UNKNOWN", err.Error())
+		return
+	}
+
+	seen := make(map[string]bool)
+	var repos []string
+	for _, s := range summaries {
+		for _, repoTag := range s.RepoTags {
+			name := repoTag
+			if i := strings.LastIndex(repoTag, ":"); i > strings.LastIndex(repoTag, "/") {
+				name = repoTag[:i]
+			}
+			if name == "<none>" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			repos = append(repos, name)
+		}
+	}
+	sort.Strings(repos)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"repositories": repos})
+}
+
+// writeRegistryCacheError answers with the registry API's standard error
+// envelope, so registry-mirror clients that parse it get a real reason
+// instead of an opaque failure.
+func writeRegistryCacheError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"code": code, "message": message}},
+	})
+}
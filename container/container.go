@@ -90,10 +90,15 @@ type Container struct {
 	SecretReferences       []*swarmtypes.SecretReference
 	ConfigReferences       []*swarmtypes.ConfigReference
 	// logDriver for closing
-	LogDriver      logger.Logger  `json:"-"`
-	LogCopier      *logger.Copier `json:"-"`
-	restartManager restartmanager.RestartManager
-	attachContext  *attachContext
+	LogDriver logger.Logger  `json:"-"`
+	LogCopier *logger.Copier `json:"-"`
+	// stderrLogDriver/stderrLogCopier are only set when HostConfig.LogConfig.Stdout
+	// or HostConfig.LogConfig.Stderr is set, so that stdout and stderr are copied
+	// to independent loggers instead of a shared one.
+	stderrLogDriver logger.Logger  `json:"-"`
+	stderrLogCopier *logger.Copier `json:"-"`
+	restartManager  restartmanager.RestartManager
+	attachContext   *attachContext
 
 	// Fields here are specific to Unix platforms
 	AppArmorProfile string
@@ -103,11 +108,51 @@ type Container struct {
 	ResolvConfPath  string
 	SeccompProfile  string
 	NoNewPrivileges bool
+	// GenerateProfile runs the container with a logging seccomp profile
+	// (see profiles/seccomp.GenerateProfile) instead of blocking syscalls
+	// outside the default allow-list, so the syscalls the workload actually
+	// needs show up in the kernel audit log for later profile authoring.
+	GenerateProfile bool
+	ClockSyncPath   string
 
 	// Fields here are specific to Windows
 	NetworkSharedContainerID string            `json:"-"`
 	SharedEndpointList       []string          `json:"-"`
 	LocalLogCacheMeta        localLogCacheMeta `json:",omitempty"`
+	// NamedPipeListeners holds the host-side listeners for any named pipes
+	// published from this container via HostConfig.NamedPipes, so they can be
+	// closed when the container stops.
+	NamedPipeListeners []io.Closer `json:"-"`
+
+	// RuntimeEvents holds the most recent libcontainerd runtime events
+	// observed for this container (oldest first), for low-level debugging
+	// via inspect. It's not persisted - crossing a daemon restart isn't
+	// meaningful for it, since the runtime task is also gone by then.
+	RuntimeEvents []RuntimeEvent `json:"-"`
+}
+
+// maxRuntimeEvents bounds RuntimeEvents so a long-lived container (lots of
+// execs pausing/resuming) doesn't grow it without limit.
+const maxRuntimeEvents = 20
+
+// RuntimeEvent is a single libcontainerd runtime-level event observed for
+// the container, kept for low-level debugging via inspect.
+type RuntimeEvent struct {
+	Type string
+	Time time.Time
+}
+
+// RecordRuntimeEvent appends a runtime-level event to the container's
+// recent event history, trimming the oldest entries past maxRuntimeEvents.
+// Callers must hold a Lock on the Container.
+func (container *Container) RecordRuntimeEvent(eventType string) {
+	container.RuntimeEvents = append(container.RuntimeEvents, RuntimeEvent{
+		Type: eventType,
+		Time: time.Now(),
+	})
+	if excess := len(container.RuntimeEvents) - maxRuntimeEvents; excess > 0 {
+		container.RuntimeEvents = container.RuntimeEvents[excess:]
+	}
 }
 
 type localLogCacheMeta struct {
@@ -301,9 +346,10 @@ func (container *Container) SetupWorkingDirectory(rootIdentity idtools.Identity)
 // container.
 //
 // NOTE: The returned path is *only* safely scoped inside the container's BaseFS
-//       if no component of the returned path changes (such as a component
-//       symlinking to a different path) between using this method and using the
-//       path. See symlink.FollowSymlinkInScope for more details.
+//
+//	if no component of the returned path changes (such as a component
+//	symlinking to a different path) between using this method and using the
+//	path. See symlink.FollowSymlinkInScope for more details.
 func (container *Container) GetResourcePath(path string) (string, error) {
 	if container.BaseFS == nil {
 		return "", errors.New("GetResourcePath: BaseFS of container " + container.ID + " is unexpectedly nil")
@@ -330,9 +376,10 @@ func (container *Container) GetResourcePath(path string) (string, error) {
 // other metadata files. If in doubt, use container.GetResourcePath.
 //
 // NOTE: The returned path is *only* safely scoped inside the container's root
-//       if no component of the returned path changes (such as a component
-//       symlinking to a different path) between using this method and using the
-//       path. See symlink.FollowSymlinkInScope for more details.
+//
+//	if no component of the returned path changes (such as a component
+//	symlinking to a different path) between using this method and using the
+//	path. See symlink.FollowSymlinkInScope for more details.
 func (container *Container) GetRootResourcePath(path string) (string, error) {
 	// IMPORTANT - These are paths on the OS where the daemon is running, hence
 	// any filepath operations must be done in an OS agnostic way.
@@ -361,12 +408,40 @@ func (container *Container) CheckpointDir() string {
 	return filepath.Join(container.Root, "checkpoints")
 }
 
-// StartLogger starts a new logger driver for the container.
-func (container *Container) StartLogger() (logger.Logger, error) {
-	cfg := container.HostConfig.LogConfig
+// SnapshotDir returns the directory named filesystem snapshots taken with
+// ContainerSnapshot are stored in.
+func (container *Container) SnapshotDir() string {
+	return filepath.Join(container.Root, "snapshots")
+}
+
+// ConsoleLogPath returns the path of the file that captures the guest
+// console/serial output of VM-backed runtimes (e.g. kata-style sandboxes).
+// The file only exists for containers whose runtime attaches a console
+// device; it is not related to the container's stdio logs.
+func (container *Container) ConsoleLogPath() string {
+	return filepath.Join(container.Root, "console.log")
+}
+
+// StartLogger starts a new logger driver for the container. The returned
+// attrs come from the "attrs-template" log option, if set, and must be
+// merged into every message the caller copies for this logger via
+// Copier.SetExtraAttrs.
+func (container *Container) StartLogger() (logger.Logger, []logger.LogAttr, error) {
+	return container.startLoggerForStream("", container.HostConfig.LogConfig)
+}
+
+// startLoggerForStream starts a new logger driver using cfg. streamSuffix
+// distinguishes the on-disk log path of a per-stream override (see
+// LogConfig.Stdout/Stderr) from the container's primary logger, so that
+// e.g. a stdout and a stderr json-file logger don't write to the same file.
+func (container *Container) startLoggerForStream(streamSuffix string, cfg containertypes.LogConfig) (logger.Logger, []logger.LogAttr, error) {
 	initDriver, err := logger.GetLogDriver(cfg.Type)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get logging factory")
+		return nil, nil, errors.Wrap(err, "failed to get logging factory")
+	}
+	nodeName, err := os.Hostname()
+	if err != nil {
+		nodeName = ""
 	}
 	info := logger.Info{
 		Config:              cfg.Config,
@@ -380,35 +455,43 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		ContainerEnv:        container.Config.Env,
 		ContainerLabels:     container.Config.Labels,
 		DaemonName:          "docker",
+		NodeName:            nodeName,
 	}
 
 	// Set logging file for "json-logger"
 	// TODO(@cpuguy83): Setup here based on log driver is a little weird.
 	switch cfg.Type {
 	case jsonfilelog.Name:
-		info.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-json.log", container.ID))
+		info.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s%s-json.log", container.ID, streamSuffix))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		container.LogPath = info.LogPath
+		if streamSuffix == "" {
+			container.LogPath = info.LogPath
+		}
 	case local.Name:
 		// Do not set container.LogPath for the local driver
 		// This would expose the value to the API, which should not be done as it means
 		// that the log file implementation would become a stable API that cannot change.
-		logDir, err := container.GetRootResourcePath("local-logs")
+		logDir, err := container.GetRootResourcePath("local-logs" + streamSuffix)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if err := os.MkdirAll(logDir, 0700); err != nil {
-			return nil, errdefs.System(errors.Wrap(err, "error creating local logs dir"))
+			return nil, nil, errdefs.System(errors.Wrap(err, "error creating local logs dir"))
 		}
 		info.LogPath = filepath.Join(logDir, "container.log")
 	}
 
+	attrs, err := logger.ParseAttrsTemplate(info)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing attrs-template log option")
+	}
+
 	l, err := initDriver(info)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
@@ -416,31 +499,34 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		if s, exists := cfg.Config["max-buffer-size"]; exists {
 			bufferSize, err = units.RAMInBytes(s)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 		l = logger.NewRingLogger(l, info, bufferSize)
 	}
 
+	// Remote-only drivers such as gelf, fluentd, and splunk cannot serve
+	// `docker logs`, so fall back to a local on-disk cache to keep that
+	// working regardless of which log driver is configured.
 	if _, ok := l.(logger.LogReader); !ok {
 		if cache.ShouldUseCache(cfg.Config) {
-			logPath, err := container.GetRootResourcePath("container-cached.log")
+			logPath, err := container.GetRootResourcePath("container-cached" + streamSuffix + ".log")
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			if !container.LocalLogCacheMeta.HaveNotifyEnabled {
-				logrus.WithField("container", container.ID).WithField("driver", container.HostConfig.LogConfig.Type).Info("Configured log driver does not support reads, enabling local file cache for container logs")
+				logrus.WithField("container", container.ID).WithField("driver", cfg.Type).Info("Configured log driver does not support reads, enabling local file cache for container logs")
 				container.LocalLogCacheMeta.HaveNotifyEnabled = true
 			}
 			info.LogPath = logPath
 			l, err = cache.WithLocalCache(l, info)
 			if err != nil {
-				return nil, errors.Wrap(err, "error setting up local container log cache")
+				return nil, nil, errors.Wrap(err, "error setting up local container log cache")
 			}
 		}
 	}
-	return l, nil
+	return l, attrs, nil
 }
 
 // GetProcessLabel returns the process label for the container.
@@ -632,16 +718,22 @@ func (container *Container) CancelAttachContext() {
 }
 
 func (container *Container) startLogging() error {
-	if container.HostConfig.LogConfig.Type == "none" {
+	cfg := container.HostConfig.LogConfig
+	if cfg.Type == "none" {
 		return nil // do not start logging routines
 	}
 
-	l, err := container.StartLogger()
+	if cfg.Stderr != nil || cfg.Stdout != nil {
+		return container.startLoggingPerStream(cfg)
+	}
+
+	l, attrs, err := container.StartLogger()
 	if err != nil {
 		return fmt.Errorf("failed to initialize logging driver: %v", err)
 	}
 
 	copier := logger.NewCopier(map[string]io.Reader{"stdout": container.StdoutPipe(), "stderr": container.StderrPipe()}, l)
+	copier.SetExtraAttrs(attrs)
 	container.LogCopier = copier
 	copier.Run()
 	container.LogDriver = l
@@ -649,6 +741,42 @@ func (container *Container) startLogging() error {
 	return nil
 }
 
+// startLoggingPerStream is used instead of startLogging when HostConfig.LogConfig.Stdout
+// or HostConfig.LogConfig.Stderr overrides the driver or options of that stream, so that
+// stdout and stderr are copied independently, each to its own logger.
+func (container *Container) startLoggingPerStream(cfg containertypes.LogConfig) error {
+	stdoutCfg := containertypes.LogConfig{Type: cfg.Type, Config: cfg.Config}
+	if cfg.Stdout != nil {
+		stdoutCfg = containertypes.LogConfig{Type: cfg.Stdout.Type, Config: cfg.Stdout.Config}
+	}
+	stderrCfg := containertypes.LogConfig{Type: cfg.Type, Config: cfg.Config}
+	if cfg.Stderr != nil {
+		stderrCfg = containertypes.LogConfig{Type: cfg.Stderr.Type, Config: cfg.Stderr.Config}
+	}
+
+	stdoutLogger, stdoutAttrs, err := container.startLoggerForStream("", stdoutCfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize stdout logging driver: %v", err)
+	}
+	stderrLogger, stderrAttrs, err := container.startLoggerForStream("-stderr", stderrCfg)
+	if err != nil {
+		stdoutLogger.Close()
+		return fmt.Errorf("failed to initialize stderr logging driver: %v", err)
+	}
+
+	container.LogDriver = stdoutLogger
+	container.LogCopier = logger.NewCopier(map[string]io.Reader{"stdout": container.StdoutPipe()}, stdoutLogger)
+	container.LogCopier.SetExtraAttrs(stdoutAttrs)
+	container.LogCopier.Run()
+
+	container.stderrLogDriver = stderrLogger
+	container.stderrLogCopier = logger.NewCopier(map[string]io.Reader{"stderr": container.StderrPipe()}, stderrLogger)
+	container.stderrLogCopier.SetExtraAttrs(stderrAttrs)
+	container.stderrLogCopier.Run()
+
+	return nil
+}
+
 // StdinPipe gets the stdin stream of the container
 func (container *Container) StdinPipe() io.WriteCloser {
 	return container.StreamConfig.StdinPipe()
@@ -88,7 +88,7 @@ func TestContainerLogPathSetForJSONFileLogger(t *testing.T) {
 		Root: containerRoot,
 	}
 
-	logger, err := c.StartLogger()
+	logger, _, err := c.StartLogger()
 	assert.NilError(t, err)
 	defer logger.Close()
 
@@ -116,7 +116,7 @@ func TestContainerLogPathSetForRingLogger(t *testing.T) {
 		Root: containerRoot,
 	}
 
-	logger, err := c.StartLogger()
+	logger, _, err := c.StartLogger()
 	assert.NilError(t, err)
 	defer logger.Close()
 
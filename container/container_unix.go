@@ -1,3 +1,4 @@
+//go:build !windows
 // +build !windows
 
 package container // import "github.com/docker/docker/container"
@@ -49,6 +50,17 @@ func (container *Container) TrySetNetworkMount(destination string, path string)
 	return false
 }
 
+// BuildClockSyncFile writes the container's clock sync status file, used
+// when HostConfig.ClockSyncStatusFile is enabled.
+func (container *Container) BuildClockSyncFile(data []byte) error {
+	path, err := container.GetRootResourcePath("clocksync")
+	if err != nil {
+		return err
+	}
+	container.ClockSyncPath = path
+	return ioutil.WriteFile(container.ClockSyncPath, data, 0644)
+}
+
 // BuildHostnameFile writes the container's hostname file.
 func (container *Container) BuildHostnameFile() error {
 	hostnamePath, err := container.GetRootResourcePath("hostname")
@@ -118,6 +130,14 @@ func (container *Container) NetworkMounts() []Mount {
 			})
 		}
 	}
+	if container.ClockSyncPath != "" {
+		mounts = append(mounts, Mount{
+			Source:      container.ClockSyncPath,
+			Destination: "/run/docker-clocksync.json",
+			Writable:    false,
+			Propagation: string(parser.DefaultPropagationMode()),
+		})
+	}
 	return mounts
 }
 
@@ -275,7 +295,12 @@ func (e conflictingUpdateOptions) Error() string {
 func (e conflictingUpdateOptions) Conflict() {}
 
 // UpdateContainer updates configuration of a container. Callers must hold a Lock on the Container.
-func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) error {
+// UpdateContainer applies hostConfig's mutable fields to container's
+// in-memory and on-disk HostConfig. It returns the subset of
+// hostConfig.Mounts that are newly added (not already present by
+// Target), so the caller can decide how to apply them to the running
+// container (e.g. by injecting them live while the container is frozen).
+func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) ([]mounttypes.Mount, error) {
 	// update resources of container
 	resources := hostConfig.Resources
 	cResources := &container.HostConfig.Resources
@@ -285,16 +310,16 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	// once NanoCPU is already set, updating CPUPeriod/CPUQuota will be blocked, and vice versa.
 	// In the following we make sure the intended update (resources) does not conflict with the existing (cResource).
 	if resources.NanoCPUs > 0 && cResources.CPUPeriod > 0 {
-		return conflictingUpdateOptions("Conflicting options: Nano CPUs cannot be updated as CPU Period has already been set")
+		return nil, conflictingUpdateOptions("Conflicting options: Nano CPUs cannot be updated as CPU Period has already been set")
 	}
 	if resources.NanoCPUs > 0 && cResources.CPUQuota > 0 {
-		return conflictingUpdateOptions("Conflicting options: Nano CPUs cannot be updated as CPU Quota has already been set")
+		return nil, conflictingUpdateOptions("Conflicting options: Nano CPUs cannot be updated as CPU Quota has already been set")
 	}
 	if resources.CPUPeriod > 0 && cResources.NanoCPUs > 0 {
-		return conflictingUpdateOptions("Conflicting options: CPU Period cannot be updated as NanoCPUs has already been set")
+		return nil, conflictingUpdateOptions("Conflicting options: CPU Period cannot be updated as NanoCPUs has already been set")
 	}
 	if resources.CPUQuota > 0 && cResources.NanoCPUs > 0 {
-		return conflictingUpdateOptions("Conflicting options: CPU Quota cannot be updated as NanoCPUs has already been set")
+		return nil, conflictingUpdateOptions("Conflicting options: CPU Quota cannot be updated as NanoCPUs has already been set")
 	}
 
 	if resources.BlkioWeight != 0 {
@@ -322,7 +347,7 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 		// if memory limit smaller than already set memoryswap limit and doesn't
 		// update the memoryswap limit, then error out.
 		if resources.Memory > cResources.MemorySwap && resources.MemorySwap == 0 {
-			return conflictingUpdateOptions("Memory limit should be smaller than already set memoryswap limit, update the memoryswap at the same time")
+			return nil, conflictingUpdateOptions("Memory limit should be smaller than already set memoryswap limit, update the memoryswap at the same time")
 		}
 		cResources.Memory = resources.Memory
 	}
@@ -344,16 +369,42 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.PidsLimit != nil {
 		cResources.PidsLimit = resources.PidsLimit
 	}
+	if resources.NetworkEgressRate != 0 {
+		cResources.NetworkEgressRate = resources.NetworkEgressRate
+	}
+	if resources.NetworkIngressRate != 0 {
+		cResources.NetworkIngressRate = resources.NetworkIngressRate
+	}
 
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
-			return conflictingUpdateOptions("Restart policy cannot be updated because AutoRemove is enabled for the container")
+			return nil, conflictingUpdateOptions("Restart policy cannot be updated because AutoRemove is enabled for the container")
 		}
 		container.HostConfig.RestartPolicy = hostConfig.RestartPolicy
 	}
 
-	return nil
+	// Mounts can only be added, not removed or modified, by an update: an
+	// existing mount may already be in active use by the running process,
+	// so changing or removing it can't be done without recreating the
+	// container. Additions are recorded here; it's up to the caller to
+	// apply them to the running container (see daemon.freezeAndAddMounts).
+	var addedMounts []mounttypes.Mount
+	for _, m := range hostConfig.Mounts {
+		found := false
+		for _, existing := range container.HostConfig.Mounts {
+			if existing.Target == m.Target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addedMounts = append(addedMounts, m)
+		}
+	}
+	container.HostConfig.Mounts = append(container.HostConfig.Mounts, addedMounts...)
+
+	return addedMounts, nil
 }
 
 // DetachAndUnmount uses a detached mount on all mount destinations, then
@@ -7,6 +7,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
 	swarmtypes "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/pkg/system"
 )
@@ -31,6 +32,12 @@ func (container *Container) IpcMounts() []Mount {
 	return nil
 }
 
+// BuildClockSyncFile is a NOOP on Windows, since ClockSyncStatusFile is
+// only supported on UNIX platforms.
+func (container *Container) BuildClockSyncFile(data []byte) error {
+	return nil
+}
+
 // CreateSecretSymlinks creates symlinks to files in the secret mount.
 func (container *Container) CreateSecretSymlinks() error {
 	for _, r := range container.SecretReferences {
@@ -133,7 +140,10 @@ func (container *Container) TmpfsMounts() ([]Mount, error) {
 }
 
 // UpdateContainer updates configuration of a container. Callers must hold a Lock on the Container.
-func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) error {
+// UpdateContainer applies hostConfig's mutable fields to container's
+// HostConfig. Mount additions aren't supported on Windows, so the returned
+// slice is always empty; see the unix implementation for details.
+func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfig) ([]mounttypes.Mount, error) {
 	resources := hostConfig.Resources
 	if resources.CPUShares != 0 ||
 		resources.Memory != 0 ||
@@ -164,16 +174,16 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 		resources.CPUPercent != 0 ||
 		resources.IOMaximumIOps != 0 ||
 		resources.IOMaximumBandwidth != 0 {
-		return fmt.Errorf("resource updating isn't supported on Windows")
+		return nil, fmt.Errorf("resource updating isn't supported on Windows")
 	}
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
-			return fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container")
+			return nil, fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container")
 		}
 		container.HostConfig.RestartPolicy = hostConfig.RestartPolicy
 	}
-	return nil
+	return nil, nil
 }
 
 // BuildHostnameFile writes the container's hostname file.
@@ -10,8 +10,9 @@ import (
 // Health holds the current container health-check state
 type Health struct {
 	types.Health
-	stop chan struct{} // Write struct{} to stop the monitor
-	mu   sync.Mutex
+	stop    chan struct{} // Write struct{} to stop the monitor
+	mu      sync.Mutex
+	changed chan struct{} // closed and replaced every time the status changes
 }
 
 // String returns a human-readable description of the health-check state
@@ -49,7 +50,34 @@ func (s *Health) SetStatus(new string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.setStatus(new)
+}
+
+// setStatus sets the status and wakes up any callers blocked in Wait, if the
+// status actually changed. The caller must hold s.mu.
+func (s *Health) setStatus(new string) {
+	if s.Health.Status == new {
+		return
+	}
 	s.Health.Status = new
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
+
+// Wait returns a channel that is closed the next time the health status
+// changes. The new status isn't necessarily the one the caller is waiting
+// for, so callers should loop, re-checking Status() after the channel
+// closes.
+func (s *Health) Wait() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.changed == nil {
+		s.changed = make(chan struct{})
+	}
+	return s.changed
 }
 
 // OpenMonitorChannel creates and returns a new monitor channel. If there
@@ -76,7 +104,7 @@ func (s *Health) CloseMonitorChannel() {
 		close(s.stop)
 		s.stop = nil
 		// unhealthy when the monitor has stopped for compatibility reasons
-		s.Health.Status = types.Unhealthy
+		s.setStatus(types.Unhealthy)
 		logrus.Debug("CloseMonitorChannel done")
 	}
 }
@@ -3,6 +3,7 @@ package container // import "github.com/docker/docker/container"
 import (
 	"time"
 
+	"github.com/docker/docker/daemon/logger"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,24 +27,33 @@ func (container *Container) Reset(lock bool) {
 		container.StreamConfig.NewInputPipes()
 	}
 
-	if container.LogDriver != nil {
-		if container.LogCopier != nil {
-			exit := make(chan struct{})
-			go func() {
-				container.LogCopier.Wait()
-				close(exit)
-			}()
-
-			timer := time.NewTimer(loggerCloseTimeout)
-			defer timer.Stop()
-			select {
-			case <-timer.C:
-				logrus.Warn("Logger didn't exit in time: logs may be truncated")
-			case <-exit:
-			}
+	closeLogger(container.LogDriver, container.LogCopier)
+	container.LogCopier = nil
+	container.LogDriver = nil
+
+	closeLogger(container.stderrLogDriver, container.stderrLogCopier)
+	container.stderrLogCopier = nil
+	container.stderrLogDriver = nil
+}
+
+func closeLogger(l logger.Logger, copier *logger.Copier) {
+	if l == nil {
+		return
+	}
+	if copier != nil {
+		exit := make(chan struct{})
+		go func() {
+			copier.Wait()
+			close(exit)
+		}()
+
+		timer := time.NewTimer(loggerCloseTimeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			logrus.Warn("Logger didn't exit in time: logs may be truncated")
+		case <-exit:
 		}
-		container.LogDriver.Close()
-		container.LogCopier = nil
-		container.LogDriver = nil
 	}
+	l.Close()
 }
@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/lockdebug"
 	units "github.com/docker/go-units"
 )
 
@@ -15,7 +15,7 @@ import (
 // set the state. Container has an embed, which allows all of the
 // functions defined against State to run against Container.
 type State struct {
-	sync.Mutex
+	lockdebug.Mutex
 	// Note that `Running` and `Paused` are not mutually exclusive:
 	// When pausing a container (on Linux), the freezer cgroup is used to suspend
 	// all processes in the container. Freezing the process requires the process to
@@ -164,10 +164,16 @@ type WaitCondition int
 // or is removed.
 //
 // WaitConditionRemoved is used to wait for the container to be removed.
+//
+// WaitConditionHealthy and WaitConditionUnhealthy wait for the container's
+// healthcheck to report the matching status at least once. A container
+// without a healthcheck configured never satisfies either of these.
 const (
 	WaitConditionNotRunning WaitCondition = iota
 	WaitConditionNextExit
 	WaitConditionRemoved
+	WaitConditionHealthy
+	WaitConditionUnhealthy
 )
 
 // Wait waits until the container is in a certain state indicated by the given
@@ -195,6 +201,13 @@ func (s *State) Wait(ctx context.Context, condition WaitCondition) <-chan StateS
 		return resultC
 	}
 
+	if condition == WaitConditionHealthy || condition == WaitConditionUnhealthy {
+		resultC := make(chan StateStatus)
+		waitRemove := s.waitRemove
+		go s.waitHealth(ctx, condition, waitRemove, resultC)
+		return resultC
+	}
+
 	// If we are waiting only for removal, the waitStop channel should
 	// remain nil and block forever.
 	var waitStop chan struct{}
@@ -235,6 +248,50 @@ func (s *State) Wait(ctx context.Context, condition WaitCondition) <-chan StateS
 	return resultC
 }
 
+// waitHealth blocks until the container's healthcheck reports the status
+// that condition asks for (WaitConditionHealthy or WaitConditionUnhealthy),
+// the container is removed, or ctx is cancelled. A container with no
+// healthcheck configured never reports a status, so this blocks until
+// removal or cancellation in that case.
+func (s *State) waitHealth(ctx context.Context, condition WaitCondition, waitRemove chan struct{}, resultC chan<- StateStatus) {
+	want := types.Healthy
+	if condition == WaitConditionUnhealthy {
+		want = types.Unhealthy
+	}
+
+	for {
+		s.Lock()
+		h := s.Health
+		result := StateStatus{
+			exitCode: s.ExitCode(),
+			err:      s.Err(),
+		}
+		s.Unlock()
+
+		var changed <-chan struct{}
+		if h != nil {
+			if h.Status() == want {
+				resultC <- result
+				return
+			}
+			changed = h.Wait()
+		}
+
+		select {
+		case <-ctx.Done():
+			resultC <- StateStatus{
+				exitCode: -1,
+				err:      ctx.Err(),
+			}
+			return
+		case <-waitRemove:
+			resultC <- result
+			return
+		case <-changed:
+		}
+	}
+}
+
 // IsRunning returns whether the running flag is set. Used by Container to check whether a container is running.
 func (s *State) IsRunning() bool {
 	s.Lock()
@@ -25,14 +25,30 @@ import (
 // copied and delivered to all StdoutPipe and StderrPipe consumers, using
 // a kind of "broadcaster".
 type Config struct {
-	wg        sync.WaitGroup
-	stdout    *broadcaster.Unbuffered
-	stderr    *broadcaster.Unbuffered
-	stdin     io.ReadCloser
-	stdinPipe io.WriteCloser
-	dio       *cio.DirectIO
+	wg             sync.WaitGroup
+	stdout         *broadcaster.Unbuffered
+	stderr         *broadcaster.Unbuffered
+	stdin          io.ReadCloser
+	stdinPipe      io.WriteCloser
+	dio            *cio.DirectIO
+	maxBufferSize  int64
+	overflowPolicy OverflowPolicy
+	onOverflow     func(stream string)
 }
 
+// OverflowPolicy controls what a stream does when buffered, unread data
+// reaches the limit set by SetStdioLimits.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock pauses the container's stdio until a consumer catches
+	// up. This is the default.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered, unread data to make
+	// room for new data.
+	OverflowDropOldest
+)
+
 // NewConfig creates a stream config and initializes
 // the standard err and standard out to new unbuffered broadcasters.
 func NewConfig() *Config {
@@ -62,20 +78,50 @@ func (c *Config) StdinPipe() io.WriteCloser {
 	return c.stdinPipe
 }
 
+// SetStdioLimits bounds how much unread stdout/stderr data StdoutPipe and
+// StderrPipe will buffer on behalf of a slow consumer, and what to do once
+// that bound is hit. It must be called before StdoutPipe/StderrPipe to take
+// effect. onOverflow, if non-nil, is called with "stdout" or "stderr" the
+// first time that stream overflows; it is how the daemon implements a
+// kill-container policy, since Config itself has no notion of a container
+// to kill.
+func (c *Config) SetStdioLimits(maxBufferSize int64, policy OverflowPolicy, onOverflow func(stream string)) {
+	c.maxBufferSize = maxBufferSize
+	c.overflowPolicy = policy
+	c.onOverflow = onOverflow
+}
+
+func (c *Config) newBytesPipe(stream string) *ioutils.BytesPipe {
+	if c.maxBufferSize <= 0 {
+		return ioutils.NewBytesPipe()
+	}
+	policy := ioutils.OverflowBlock
+	if c.overflowPolicy == OverflowDropOldest {
+		policy = ioutils.OverflowDropOldest
+	}
+	bytesPipe := ioutils.NewBytesPipeLimited(int(c.maxBufferSize), policy)
+	if c.onOverflow != nil {
+		bytesPipe.SetOverflowCallback(func() { c.onOverflow(stream) })
+	}
+	return bytesPipe
+}
+
 // StdoutPipe creates a new io.ReadCloser with an empty bytes pipe.
 // It adds this new out pipe to the Stdout broadcaster.
-// This will block stdout if unconsumed.
+// This will block stdout if unconsumed, unless SetStdioLimits configured a
+// different overflow policy.
 func (c *Config) StdoutPipe() io.ReadCloser {
-	bytesPipe := ioutils.NewBytesPipe()
+	bytesPipe := c.newBytesPipe("stdout")
 	c.stdout.Add(bytesPipe)
 	return bytesPipe
 }
 
 // StderrPipe creates a new io.ReadCloser with an empty bytes pipe.
 // It adds this new err pipe to the Stderr broadcaster.
-// This will block stderr if unconsumed.
+// This will block stderr if unconsumed, unless SetStdioLimits configured a
+// different overflow policy.
 func (c *Config) StderrPipe() io.ReadCloser {
-	bytesPipe := ioutils.NewBytesPipe()
+	bytesPipe := c.newBytesPipe("stderr")
 	c.stderr.Add(bytesPipe)
 	return bytesPipe
 }
@@ -325,6 +325,7 @@ func (v *memdbView) transform(container *Container) *Snapshot {
 
 	if container.HostConfig != nil {
 		snapshot.Container.HostConfig.NetworkMode = string(container.HostConfig.NetworkMode)
+		snapshot.Container.SizeRwQuota = container.HostConfig.DiskQuota
 		snapshot.HostConfig.Isolation = string(container.HostConfig.Isolation)
 		for binding := range container.HostConfig.PortBindings {
 			snapshot.PortBindings[binding] = struct{}{}
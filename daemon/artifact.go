@@ -0,0 +1,67 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/artifacts"
+	"github.com/docker/docker/errdefs"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func toArtifactSummary(desc artifacts.Descriptor) types.ArtifactSummary {
+	return types.ArtifactSummary{
+		Digest:       desc.Digest,
+		ArtifactType: desc.ArtifactType,
+		Subject:      desc.Subject,
+		Size:         desc.Size,
+		Annotations:  desc.Annotations,
+		CreatedAt:    desc.CreatedAt,
+	}
+}
+
+// ArtifactPush stores content as a new artifact, attached to subject if one
+// is given. See the artifacts package doc comment for what is and isn't
+// implemented.
+func (daemon *Daemon) ArtifactPush(ctx context.Context, artifactType string, subject digest.Digest, annotations map[string]string, content io.Reader) (types.ArtifactSummary, error) {
+	desc, err := daemon.artifactStore.Push(artifactType, subject, annotations, content)
+	if err != nil {
+		return types.ArtifactSummary{}, errdefs.System(err)
+	}
+	return toArtifactSummary(desc), nil
+}
+
+// ArtifactPull returns a stored artifact's content and summary.
+func (daemon *Daemon) ArtifactPull(ctx context.Context, dgst digest.Digest) (io.ReadCloser, types.ArtifactSummary, error) {
+	content, desc, err := daemon.artifactStore.Get(dgst)
+	if err != nil {
+		if err == artifacts.ErrNotFound {
+			return nil, types.ArtifactSummary{}, errdefs.NotFound(err)
+		}
+		return nil, types.ArtifactSummary{}, errdefs.System(err)
+	}
+	return content, toArtifactSummary(desc), nil
+}
+
+// ArtifactList returns the artifacts attached to subject, or every stored
+// artifact if subject is "".
+func (daemon *Daemon) ArtifactList(ctx context.Context, subject digest.Digest) ([]types.ArtifactSummary, error) {
+	descs := daemon.artifactStore.List(subject)
+	summaries := make([]types.ArtifactSummary, 0, len(descs))
+	for _, desc := range descs {
+		summaries = append(summaries, toArtifactSummary(desc))
+	}
+	return summaries, nil
+}
+
+// ArtifactDelete removes a stored artifact.
+func (daemon *Daemon) ArtifactDelete(ctx context.Context, dgst digest.Digest) error {
+	if err := daemon.artifactStore.Delete(dgst); err != nil {
+		if err == artifacts.ErrNotFound {
+			return errdefs.NotFound(err)
+		}
+		return errdefs.System(err)
+	}
+	return nil
+}
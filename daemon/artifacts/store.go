@@ -0,0 +1,192 @@
+// Package artifacts provides a local store for non-runnable OCI artifacts
+// (SBOMs, signatures, Helm charts, and similar) that are associated with an
+// image or other content by subject digest, rather than being runnable
+// images themselves.
+//
+// This only stores and serves artifacts locally; it does not implement the
+// registry's OCI Referrers API, so pushing or pulling an artifact to/from a
+// remote registry is out of scope here and is left to the registry client
+// code in distribution/.
+package artifacts // import "github.com/docker/docker/daemon/artifacts"
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/ioutils"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned by Get and Delete when the requested artifact is
+// not in the store.
+var ErrNotFound = errors.New("artifact not found")
+
+// Descriptor describes one artifact held in the store.
+type Descriptor struct {
+	Digest       digest.Digest
+	ArtifactType string
+	// Subject is the digest of the image (or other content) this artifact
+	// is attached to, or "" if it isn't attached to anything.
+	Subject     digest.Digest `json:",omitempty"`
+	Size        int64
+	Annotations map[string]string `json:",omitempty"`
+	CreatedAt   time.Time
+}
+
+// Store persists artifacts addressed by the digest of their own content,
+// indexed for lookup by subject digest.
+type Store struct {
+	mu        sync.RWMutex
+	root      string
+	indexPath string
+	index     map[digest.Digest]Descriptor
+}
+
+// NewStore creates or reopens an artifact store rooted at root.
+func NewStore(root string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(root, "blobs"), 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create artifact store")
+	}
+
+	s := &Store{
+		root:      root,
+		indexPath: filepath.Join(root, "index.json"),
+		index:     make(map[digest.Digest]Descriptor),
+	}
+	if err := s.reload(); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to load artifact store index")
+	}
+	return s, nil
+}
+
+func (s *Store) reload() error {
+	f, err := os.Open(s.indexPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewDecoder(f).Decode(&s.index)
+}
+
+func (s *Store) save() error {
+	return ioutils.AtomicWriteFile(s.indexPath, mustMarshal(s.index), 0600)
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// index only ever holds Descriptor values, which always marshal.
+		panic(err)
+	}
+	return b
+}
+
+func (s *Store) blobPath(dgst digest.Digest) string {
+	return filepath.Join(s.root, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// Push stores content as a new artifact of the given type, attached to
+// subject (which may be "" for an artifact not attached to anything), and
+// returns its descriptor. The artifact is addressed by the digest of
+// content itself, computed while writing it, not by a digest supplied by
+// the caller.
+func (s *Store) Push(artifactType string, subject digest.Digest, annotations map[string]string, content io.Reader) (Descriptor, error) {
+	tmp, err := ioutil.TempFile(s.root, "push-")
+	if err != nil {
+		return Descriptor{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(tmp, io.TeeReader(content, digester.Hash()))
+	if err != nil {
+		return Descriptor{}, errors.Wrap(err, "failed to write artifact content")
+	}
+	dgst := digester.Digest()
+
+	blobPath := s.blobPath(dgst)
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0700); err != nil {
+		return Descriptor{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return Descriptor{}, err
+	}
+	if err := os.Rename(tmp.Name(), blobPath); err != nil {
+		return Descriptor{}, errors.Wrap(err, "failed to store artifact content")
+	}
+
+	desc := Descriptor{
+		Digest:       dgst,
+		ArtifactType: artifactType,
+		Subject:      subject,
+		Size:         size,
+		Annotations:  annotations,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	s.index[dgst] = desc
+	err = s.save()
+	s.mu.Unlock()
+	if err != nil {
+		return Descriptor{}, errors.Wrap(err, "failed to update artifact index")
+	}
+	return desc, nil
+}
+
+// Get returns an artifact's content and descriptor. The caller must Close
+// the returned ReadCloser.
+func (s *Store) Get(dgst digest.Digest) (io.ReadCloser, Descriptor, error) {
+	s.mu.RLock()
+	desc, ok := s.index[dgst]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, Descriptor{}, ErrNotFound
+	}
+
+	f, err := os.Open(s.blobPath(dgst))
+	if err != nil {
+		return nil, Descriptor{}, errors.Wrap(err, "failed to open artifact content")
+	}
+	return f, desc, nil
+}
+
+// List returns the descriptors of every artifact in the store attached to
+// subject, or every artifact in the store if subject is "".
+func (s *Store) List(subject digest.Digest) []Descriptor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var descs []Descriptor
+	for _, desc := range s.index {
+		if subject == "" || desc.Subject == subject {
+			descs = append(descs, desc)
+		}
+	}
+	return descs
+}
+
+// Delete removes an artifact from the store.
+func (s *Store) Delete(dgst digest.Digest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[dgst]; !ok {
+		return ErrNotFound
+	}
+	if err := os.Remove(s.blobPath(dgst)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove artifact content")
+	}
+	delete(s.index, dgst)
+	return s.save()
+}
@@ -0,0 +1,223 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+)
+
+// SystemBatch runs req.Operations as a single request. See the doc comment
+// on types.BatchRequest for exactly what the ordering, rollback, and
+// dry-run guarantees are (and aren't).
+func (daemon *Daemon) SystemBatch(ctx context.Context, req types.BatchRequest) (*types.BatchResult, error) {
+	refs := make(map[string]string)
+	result := &types.BatchResult{DryRun: req.DryRun, FailedStep: -1}
+
+	for i, op := range req.Operations {
+		opResult := types.BatchOpResult{Kind: op.Kind, Ref: op.Ref}
+		var err error
+		if req.DryRun {
+			err = daemon.validateBatchOp(refs, op)
+		} else {
+			err = daemon.runBatchOp(ctx, refs, op, &opResult)
+		}
+
+		result.Results = append(result.Results, opResult)
+		if err != nil {
+			result.Results[i].Error = err.Error()
+			result.FailedStep = i
+			if !req.DryRun {
+				daemon.rollbackBatch(result)
+			}
+			return result, nil
+		}
+
+		if op.Ref != "" && opResult.ID != "" {
+			refs[op.Ref] = opResult.ID
+		} else if op.Ref != "" && req.DryRun {
+			// Nothing was created, but later steps may still refer back
+			// to this one by its Ref.
+			refs[op.Ref] = op.Ref
+		}
+	}
+
+	result.OK = true
+	return result, nil
+}
+
+// resolveBatchRef returns the ID a previous step in the same batch recorded
+// under ref, or ref itself (as the name/ID of a pre-existing resource) if no
+// step recorded one.
+func resolveBatchRef(refs map[string]string, ref string) string {
+	if id, ok := refs[ref]; ok {
+		return id
+	}
+	return ref
+}
+
+// runBatchOp executes a single BatchOp for real, filling in result with the
+// IDs of whatever it created or acted on.
+func (daemon *Daemon) runBatchOp(ctx context.Context, refs map[string]string, op types.BatchOp, result *types.BatchOpResult) error {
+	switch op.Kind {
+	case types.BatchOpCreateNetwork:
+		if op.CreateNetwork == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing CreateNetwork parameters", op.Kind))
+		}
+		resp, err := daemon.CreateNetwork(*op.CreateNetwork)
+		if err != nil {
+			return err
+		}
+		result.ID = resp.ID
+		return nil
+
+	case types.BatchOpCreateContainer:
+		if op.CreateContainer == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing CreateContainer parameters", op.Kind))
+		}
+		body, err := daemon.ContainerCreate(types.ContainerCreateConfig{
+			Name:       op.CreateContainer.Name,
+			Config:     op.CreateContainer.Config,
+			HostConfig: op.CreateContainer.HostConfig,
+		})
+		if err != nil {
+			return err
+		}
+		result.ID = body.ID
+		return nil
+
+	case types.BatchOpConnectNetwork:
+		if op.ConnectNetwork == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing ConnectNetwork parameters", op.Kind))
+		}
+		networkRef := resolveBatchRef(refs, op.ConnectNetwork.NetworkRef)
+		containerRef := resolveBatchRef(refs, op.ConnectNetwork.ContainerRef)
+		if err := daemon.ConnectContainerToNetwork(containerRef, networkRef, op.ConnectNetwork.EndpointConfig); err != nil {
+			return err
+		}
+		result.NetworkID = networkRef
+		result.ContainerID = containerRef
+		return nil
+
+	case types.BatchOpStartContainer:
+		if op.StartContainer == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing StartContainer parameters", op.Kind))
+		}
+		containerRef := resolveBatchRef(refs, op.StartContainer.ContainerRef)
+		if err := daemon.ContainerStart(ctx, containerRef, nil, "", ""); err != nil {
+			return err
+		}
+		result.ContainerID = containerRef
+		return nil
+
+	default:
+		return errdefs.InvalidParameter(fmt.Errorf("unknown batch op kind %q", op.Kind))
+	}
+}
+
+// validateBatchOp checks a single BatchOp's parameters without performing
+// it, for BatchRequest.DryRun. This only catches the same obvious mistakes
+// its real endpoint would reject up front (an unknown op kind, a missing
+// parameter block, a network name already in use, a ref that resolves to
+// nothing; container names are not checked);
+// it does not simulate the operation, so it can't catch failures that only
+// show up while actually creating the resource (an OCI runtime rejecting
+// the container config, a network driver-specific option being invalid).
+func (daemon *Daemon) validateBatchOp(refs map[string]string, op types.BatchOp) error {
+	switch op.Kind {
+	case types.BatchOpCreateNetwork:
+		if op.CreateNetwork == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing CreateNetwork parameters", op.Kind))
+		}
+		if nw, err := daemon.GetNetworkByName(op.CreateNetwork.Name); err == nil && nw != nil && op.CreateNetwork.CheckDuplicate {
+			return errdefs.InvalidParameter(fmt.Errorf("network %q already exists", op.CreateNetwork.Name))
+		}
+		if reason, ok := unimplementedNetworkDrivers[op.CreateNetwork.Driver]; ok {
+			return errdefs.InvalidParameter(fmt.Errorf("network driver %q is not implemented: %s", op.CreateNetwork.Driver, reason))
+		}
+
+	case types.BatchOpCreateContainer:
+		if op.CreateContainer == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing CreateContainer parameters", op.Kind))
+		}
+		if op.CreateContainer.Config == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing Config", op.Kind))
+		}
+
+	case types.BatchOpConnectNetwork:
+		if op.ConnectNetwork == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing ConnectNetwork parameters", op.Kind))
+		}
+		if err := daemon.checkBatchRefExists(refs, op.ConnectNetwork.NetworkRef, false); err != nil {
+			return err
+		}
+		if err := daemon.checkBatchRefExists(refs, op.ConnectNetwork.ContainerRef, true); err != nil {
+			return err
+		}
+
+	case types.BatchOpStartContainer:
+		if op.StartContainer == nil {
+			return errdefs.InvalidParameter(fmt.Errorf("batch op %q missing StartContainer parameters", op.Kind))
+		}
+		if err := daemon.checkBatchRefExists(refs, op.StartContainer.ContainerRef, true); err != nil {
+			return err
+		}
+
+	default:
+		return errdefs.InvalidParameter(fmt.Errorf("unknown batch op kind %q", op.Kind))
+	}
+	return nil
+}
+
+// checkBatchRefExists reports an error unless ref names an earlier step in
+// this batch, or an already-existing container (isContainer) or network.
+func (daemon *Daemon) checkBatchRefExists(refs map[string]string, ref string, isContainer bool) error {
+	if _, ok := refs[ref]; ok {
+		return nil
+	}
+	var err error
+	if isContainer {
+		_, err = daemon.GetContainer(ref)
+	} else {
+		_, err = daemon.GetNetworkByName(ref)
+	}
+	if err != nil {
+		kind := "network"
+		if isContainer {
+			kind = "container"
+		}
+		return errdefs.InvalidParameter(fmt.Errorf("%s %q not found among earlier steps or existing %ss", kind, ref, kind))
+	}
+	return nil
+}
+
+// rollbackBatch undoes the effect of every already-succeeded step in
+// result.Results other than the failed one, in reverse order, marking each
+// as RolledBack. A failure to roll back a given step is recorded on that
+// step's own result rather than aborting the rest of the rollback.
+func (daemon *Daemon) rollbackBatch(result *types.BatchResult) {
+	for i := len(result.Results) - 2; i >= 0; i-- {
+		r := &result.Results[i]
+		var err error
+		switch r.Kind {
+		case types.BatchOpCreateContainer:
+			err = daemon.ContainerRm(r.ID, &types.ContainerRmConfig{ForceRemove: true})
+		case types.BatchOpCreateNetwork:
+			err = daemon.DeleteNetwork(r.ID)
+		case types.BatchOpConnectNetwork:
+			err = daemon.DisconnectContainerFromNetwork(r.ContainerID, r.NetworkID, true)
+		case types.BatchOpStartContainer:
+			// Stopping the container it started is the closest available
+			// compensation; it is not removed, since this rollback only
+			// undoes the start, not the (separate, already-rolled-back or
+			// left in place) create step for it.
+			err = daemon.ContainerStop(r.ContainerID, nil)
+		}
+		if err != nil {
+			r.Error = fmt.Sprintf("rollback failed: %v", err)
+			continue
+		}
+		r.RolledBack = true
+	}
+}
@@ -0,0 +1,108 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// retainedBundlesDir returns the directory under which bundles of
+// containers that failed to start are retained for post-mortem debugging.
+func (daemon *Daemon) retainedBundlesDir() string {
+	return filepath.Join(daemon.root, "retained-bundles")
+}
+
+func (daemon *Daemon) retainedBundlePath(containerID string) string {
+	return filepath.Join(daemon.retainedBundlesDir(), containerID)
+}
+
+// retainFailedBundle copies the libcontainerd bundle of ctr aside so it can
+// be inspected after the fact, if bundle retention is enabled. It is called
+// just before the containerd container object (and its bundle) is deleted
+// following a failed start. Only the most recent failure is kept per
+// container; any previously retained bundle is replaced.
+//
+// Failures to retain the bundle are logged and otherwise ignored, since this
+// is a best-effort debugging aid and must never block container cleanup.
+func (daemon *Daemon) retainFailedBundle(ctr *container.Container) {
+	if daemon.configStore.FailedBundleRetentionHours <= 0 {
+		return
+	}
+
+	info, err := daemon.containerd.RuntimeInfo(context.Background(), ctr.ID)
+	if err != nil || info.BundlePath == "" {
+		logrus.WithError(err).WithField("container", ctr.ID).Warn("failed to locate bundle for retention")
+		return
+	}
+
+	dest := daemon.retainedBundlePath(ctr.ID)
+	if err := os.RemoveAll(dest); err != nil {
+		logrus.WithError(err).WithField("container", ctr.ID).Warn("failed to clear previous retained bundle")
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		logrus.WithError(err).WithField("container", ctr.ID).Warn("failed to create retained bundles directory")
+		return
+	}
+	if err := archive.NewDefaultArchiver().CopyWithTar(info.BundlePath, dest); err != nil {
+		logrus.WithError(err).WithField("container", ctr.ID).Warn("failed to retain bundle for post-mortem debugging")
+		return
+	}
+}
+
+// pruneExpiredRetainedBundles removes retained bundles older than the
+// configured FailedBundleRetentionHours. It is called once at daemon
+// startup; failures are logged and otherwise ignored.
+func (daemon *Daemon) pruneExpiredRetainedBundles() {
+	if daemon.configStore.FailedBundleRetentionHours <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(daemon.retainedBundlesDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.WithError(err).Warn("failed to list retained bundles")
+		}
+		return
+	}
+
+	maxAge := time.Duration(daemon.configStore.FailedBundleRetentionHours) * time.Hour
+	for _, entry := range entries {
+		path := filepath.Join(daemon.retainedBundlesDir(), entry.Name())
+		fi, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(fi.ModTime()) > maxAge {
+			if err := os.RemoveAll(path); err != nil {
+				logrus.WithError(err).WithField("path", path).Warn("failed to remove expired retained bundle")
+			}
+		}
+	}
+}
+
+// ContainerGetBundle returns a tar stream of the retained bundle for the
+// container with the given name, if one exists.
+func (daemon *Daemon) ContainerGetBundle(name string) (io.ReadCloser, error) {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	path := daemon.retainedBundlePath(ctr.ID)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("no retained bundle for container %s", ctr.ID)
+		}
+		return nil, err
+	}
+
+	return archive.Tar(path, archive.Uncompressed)
+}
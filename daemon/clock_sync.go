@@ -0,0 +1,32 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// SystemClockSync reports the host's time synchronization status, as
+// tracked by chrony/ptp, so that it can be surfaced to containers that
+// opt in via HostConfig.ClockSyncStatusFile.
+func (daemon *Daemon) SystemClockSync(ctx context.Context) (*types.ClockSyncStatus, error) {
+	return clockSyncStatus(ctx)
+}
+
+// writeClockSyncFile snapshots the current clock sync status into the
+// container's clock-sync file, which is bind-mounted in by
+// container.NetworkMounts when HostConfig.ClockSyncStatusFile is set.
+// The file reflects the status at start time; it is not kept live.
+func (daemon *Daemon) writeClockSyncFile(c *container.Container) error {
+	status, err := daemon.SystemClockSync(context.Background())
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return c.BuildClockSyncFile(data)
+}
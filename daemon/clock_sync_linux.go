@@ -0,0 +1,45 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// clockSyncStatus queries chronyd for its tracking status via chronyc's
+// CSV output (`chronyc -c tracking`). If chrony is not running or is not
+// installed, it reports an unsynced status rather than failing the
+// caller, since lacking a time sync daemon is a valid host configuration.
+func clockSyncStatus(ctx context.Context) (*types.ClockSyncStatus, error) {
+	out, err := exec.CommandContext(ctx, "chronyc", "-c", "tracking").Output()
+	if err != nil {
+		return &types.ClockSyncStatus{Synced: false, Source: "none"}, nil
+	}
+
+	// Reference ID,Ref,Stratum,Ref time,System time,Last offset,RMS offset,
+	// Frequency,Residual freq,Skew,Root delay,Root dispersion,Leap status
+	fields := strings.Split(strings.TrimSpace(string(out)), ",")
+	if len(fields) < 6 {
+		return nil, errors.New("unexpected chronyc tracking output")
+	}
+
+	stratum, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing chrony stratum")
+	}
+	offset, err := strconv.ParseFloat(fields[5], 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing chrony offset")
+	}
+
+	return &types.ClockSyncStatus{
+		Synced:        fields[len(fields)-1] == "N",
+		Source:        "chrony",
+		Stratum:       stratum,
+		OffsetSeconds: offset,
+	}, nil
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+)
+
+func clockSyncStatus(ctx context.Context) (*types.ClockSyncStatus, error) {
+	return &types.ClockSyncStatus{Synced: false, Source: "none"}, nil
+}
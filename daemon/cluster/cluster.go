@@ -122,6 +122,7 @@ type Cluster struct {
 	configEvent  chan lncluster.ConfigEventType // todo: make this array and goroutine safe
 	attachers    map[string]*attacher
 	watchStream  chan *swarmapi.WatchMessage
+	localConfigs *localConfigStore
 }
 
 // attacher manages the in-memory attachment state of a container
@@ -158,12 +159,13 @@ func New(config Config) (*Cluster, error) {
 		return nil, err
 	}
 	c := &Cluster{
-		root:        root,
-		config:      config,
-		configEvent: make(chan lncluster.ConfigEventType, 10),
-		runtimeRoot: config.RuntimeRoot,
-		attachers:   make(map[string]*attacher),
-		watchStream: config.WatchStream,
+		root:         root,
+		config:       config,
+		configEvent:  make(chan lncluster.ConfigEventType, 10),
+		runtimeRoot:  config.RuntimeRoot,
+		attachers:    make(map[string]*attacher),
+		watchStream:  config.WatchStream,
+		localConfigs: newLocalConfigStore(root),
 	}
 	return c, nil
 }
@@ -1,8 +1,6 @@
 package cluster // import "github.com/docker/docker/daemon/cluster"
 
 import (
-	"context"
-
 	apitypes "github.com/docker/docker/api/types"
 	types "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/daemon/cluster/convert"
@@ -10,31 +8,40 @@ import (
 	"google.golang.org/grpc"
 )
 
-// GetConfig returns a config from a managed swarm cluster
+// GetConfig returns a config from a managed swarm cluster, or - if this
+// node is not an active swarm manager - from the local standalone config
+// store.
 func (c *Cluster) GetConfig(input string) (types.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state := c.currentNodeState()
+	if !state.IsActiveManager() {
+		return c.localConfigs.get(input)
+	}
+
 	var config *swarmapi.Config
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
 
-	if err := c.lockedManagerAction(func(ctx context.Context, state nodeState) error {
-		s, err := getConfig(ctx, state.controlClient, input)
-		if err != nil {
-			return err
-		}
-		config = s
-		return nil
-	}); err != nil {
+	s, err := getConfig(ctx, state.controlClient, input)
+	if err != nil {
 		return types.Config{}, err
 	}
+	config = s
 	return convert.ConfigFromGRPC(config), nil
 }
 
-// GetConfigs returns all configs of a managed swarm cluster.
+// GetConfigs returns all configs of a managed swarm cluster, or - if this
+// node is not an active swarm manager - all configs in the local
+// standalone config store.
 func (c *Cluster) GetConfigs(options apitypes.ConfigListOptions) ([]types.Config, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	state := c.currentNodeState()
 	if !state.IsActiveManager() {
-		return nil, c.errNoManager(state)
+		return c.localConfigs.list()
 	}
 
 	filters, err := newListConfigsFilters(options.Filters)
@@ -60,61 +67,95 @@ func (c *Cluster) GetConfigs(options apitypes.ConfigListOptions) ([]types.Config
 	return configs, nil
 }
 
-// CreateConfig creates a new config in a managed swarm cluster.
+// CreateConfig creates a new config in a managed swarm cluster, or - if
+// this node is not an active swarm manager - in the local standalone
+// config store.
 func (c *Cluster) CreateConfig(s types.ConfigSpec) (string, error) {
-	var resp *swarmapi.CreateConfigResponse
-	if err := c.lockedManagerAction(func(ctx context.Context, state nodeState) error {
-		configSpec := convert.ConfigSpecToGRPC(s)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-		r, err := state.controlClient.CreateConfig(ctx,
-			&swarmapi.CreateConfigRequest{Spec: &configSpec})
+	state := c.currentNodeState()
+	if !state.IsActiveManager() {
+		config, err := c.localConfigs.create(s)
 		if err != nil {
-			return err
+			return "", err
 		}
-		resp = r
-		return nil
-	}); err != nil {
+		return config.ID, nil
+	}
+
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
+
+	configSpec := convert.ConfigSpecToGRPC(s)
+	r, err := state.controlClient.CreateConfig(ctx,
+		&swarmapi.CreateConfigRequest{Spec: &configSpec})
+	if err != nil {
 		return "", err
 	}
-	return resp.Config.ID, nil
+	return r.Config.ID, nil
 }
 
-// RemoveConfig removes a config from a managed swarm cluster.
+// RemoveConfig removes a config from a managed swarm cluster, or - if this
+// node is not an active swarm manager - from the local standalone config
+// store.
 func (c *Cluster) RemoveConfig(input string) error {
-	return c.lockedManagerAction(func(ctx context.Context, state nodeState) error {
-		config, err := getConfig(ctx, state.controlClient, input)
-		if err != nil {
-			return err
-		}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-		req := &swarmapi.RemoveConfigRequest{
-			ConfigID: config.ID,
-		}
+	state := c.currentNodeState()
+	if !state.IsActiveManager() {
+		return c.localConfigs.remove(input)
+	}
+
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
 
-		_, err = state.controlClient.RemoveConfig(ctx, req)
+	config, err := getConfig(ctx, state.controlClient, input)
+	if err != nil {
 		return err
-	})
+	}
+
+	req := &swarmapi.RemoveConfigRequest{
+		ConfigID: config.ID,
+	}
+
+	_, err = state.controlClient.RemoveConfig(ctx, req)
+	return err
 }
 
-// UpdateConfig updates a config in a managed swarm cluster.
+// UpdateConfig updates a config in a managed swarm cluster, or - if this
+// node is not an active swarm manager - in the local standalone config
+// store.
 // Note: this is not exposed to the CLI but is available from the API only
 func (c *Cluster) UpdateConfig(input string, version uint64, spec types.ConfigSpec) error {
-	return c.lockedManagerAction(func(ctx context.Context, state nodeState) error {
-		config, err := getConfig(ctx, state.controlClient, input)
-		if err != nil {
-			return err
-		}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-		configSpec := convert.ConfigSpecToGRPC(spec)
+	state := c.currentNodeState()
+	if !state.IsActiveManager() {
+		// The local standalone store has no swarm-style object version to
+		// check, since nothing else can race to update it concurrently from
+		// outside this engine.
+		return c.localConfigs.update(input, spec)
+	}
 
-		_, err = state.controlClient.UpdateConfig(ctx,
-			&swarmapi.UpdateConfigRequest{
-				ConfigID: config.ID,
-				ConfigVersion: &swarmapi.Version{
-					Index: version,
-				},
-				Spec: &configSpec,
-			})
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
+
+	config, err := getConfig(ctx, state.controlClient, input)
+	if err != nil {
 		return err
-	})
+	}
+
+	configSpec := convert.ConfigSpecToGRPC(spec)
+
+	_, err = state.controlClient.UpdateConfig(ctx,
+		&swarmapi.UpdateConfigRequest{
+			ConfigID: config.ID,
+			ConfigVersion: &swarmapi.Version{
+				Index: version,
+			},
+			Spec: &configSpec,
+		})
+	return err
 }
@@ -0,0 +1,140 @@
+package cluster // import "github.com/docker/docker/daemon/cluster"
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	types "github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+)
+
+// localConfigStore persists standalone config objects to disk for use when
+// this node is not an active swarm manager, so that `docker config` works
+// against a single, non-swarm engine the same way it does against a swarm.
+//
+// Configs created here are never distributed to other nodes: they live
+// only in this engine's local state directory, independently of whatever
+// configs a swarm this node later joins may have. There is deliberately no
+// mechanism to migrate a config between the two stores.
+type localConfigStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func newLocalConfigStore(root string) *localConfigStore {
+	return &localConfigStore{dir: filepath.Join(root, "local-configs")}
+}
+
+func (s *localConfigStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *localConfigStore) listLocked() ([]types.Config, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []types.Config{}, nil
+		}
+		return nil, err
+	}
+
+	configs := make([]types.Config, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var config types.Config
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (s *localConfigStore) list() ([]types.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listLocked()
+}
+
+func (s *localConfigStore) getLocked(idOrName string) (types.Config, error) {
+	configs, err := s.listLocked()
+	if err != nil {
+		return types.Config{}, err
+	}
+	for _, config := range configs {
+		if config.ID == idOrName || config.Spec.Annotations.Name == idOrName {
+			return config, nil
+		}
+	}
+	return types.Config{}, errors.Errorf("config %s not found", idOrName)
+}
+
+func (s *localConfigStore) get(idOrName string) (types.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getLocked(idOrName)
+}
+
+func (s *localConfigStore) create(spec types.ConfigSpec) (types.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return types.Config{}, err
+	}
+
+	if _, err := s.getLocked(spec.Annotations.Name); err == nil {
+		return types.Config{}, errors.Errorf("config %s already exists", spec.Annotations.Name)
+	}
+
+	now := time.Now()
+	config := types.Config{
+		ID:   stringid.GenerateRandomID(),
+		Meta: types.Meta{CreatedAt: now, UpdatedAt: now},
+		Spec: spec,
+	}
+	return config, s.writeLocked(config)
+}
+
+func (s *localConfigStore) update(idOrName string, spec types.ConfigSpec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, err := s.getLocked(idOrName)
+	if err != nil {
+		return err
+	}
+	config.Spec = spec
+	config.Meta.UpdatedAt = time.Now()
+	return s.writeLocked(config)
+}
+
+func (s *localConfigStore) writeLocked(config types.Config) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path(config.ID), data, 0600)
+}
+
+func (s *localConfigStore) remove(idOrName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, err := s.getLocked(idOrName)
+	if err != nil {
+		return err
+	}
+	return os.Remove(s.path(config.ID))
+}
@@ -54,6 +54,15 @@ const (
 	LinuxV1RuntimeName = "io.containerd.runtime.v1.linux"
 	// LinuxV2RuntimeName is the runtime used to specify the containerd v2 runc shim
 	LinuxV2RuntimeName = "io.containerd.runc.v2"
+
+	// ContainerdRestorePolicyKill is the default ContainerdRestorePolicy:
+	// containers found still running in containerd on a non-live-restore
+	// startup are shut down, as they always have been.
+	ContainerdRestorePolicyKill = "kill"
+	// ContainerdRestorePolicyAdopt leaves containers found still running in
+	// containerd on a non-live-restore startup running, reconciling the
+	// container's state against the running task instead of killing it.
+	ContainerdRestorePolicyAdopt = "adopt"
 )
 
 var builtinRuntimes = map[string]bool{
@@ -133,6 +142,20 @@ type DNSConfig struct {
 	DNSOptions    []string `json:"dns-opts,omitempty"`
 	DNSSearch     []string `json:"dns-search,omitempty"`
 	HostGatewayIP net.IP   `json:"host-gateway-ip,omitempty"`
+
+	// DNSCacheSize, DNSCachePositiveTTL, and DNSCacheNegativeTTL name the
+	// cache size and TTL overrides that would apply to the embedded DNS
+	// server (the one listening inside each container's network
+	// namespace for service-discovery lookups). They are accepted here,
+	// validated, and rejected with a clear error by Validate rather than
+	// silently ignored: the embedded resolver (vendor/github.com/docker/
+	// libnetwork/resolver.go) answers every query straight from the
+	// in-memory service table with a hardcoded response TTL and keeps no
+	// cache at all, so there is nothing in this tree to plug a cache
+	// size or TTL override into without patching that vendored package.
+	DNSCacheSize        int `json:"dns-cache-size,omitempty"`
+	DNSCachePositiveTTL int `json:"dns-cache-positive-ttl,omitempty"`
+	DNSCacheNegativeTTL int `json:"dns-cache-negative-ttl,omitempty"`
 }
 
 // CommonConfig defines the configuration of a docker daemon which is
@@ -140,24 +163,30 @@ type DNSConfig struct {
 // It includes json tags to deserialize configuration from a file
 // using the same names that the flags in the command line use.
 type CommonConfig struct {
-	AuthzMiddleware       *authorization.Middleware `json:"-"`
-	AuthorizationPlugins  []string                  `json:"authorization-plugins,omitempty"` // AuthorizationPlugins holds list of authorization plugins
-	AutoRestart           bool                      `json:"-"`
-	Context               map[string][]string       `json:"-"`
-	DisableBridge         bool                      `json:"-"`
-	ExecOptions           []string                  `json:"exec-opts,omitempty"`
-	GraphDriver           string                    `json:"storage-driver,omitempty"`
-	GraphOptions          []string                  `json:"storage-opts,omitempty"`
-	Labels                []string                  `json:"labels,omitempty"`
-	Mtu                   int                       `json:"mtu,omitempty"`
-	NetworkDiagnosticPort int                       `json:"network-diagnostic-port,omitempty"`
-	Pidfile               string                    `json:"pidfile,omitempty"`
-	RawLogs               bool                      `json:"raw-logs,omitempty"`
-	RootDeprecated        string                    `json:"graph,omitempty"`
-	Root                  string                    `json:"data-root,omitempty"`
-	ExecRoot              string                    `json:"exec-root,omitempty"`
-	SocketGroup           string                    `json:"group,omitempty"`
-	CorsHeaders           string                    `json:"api-cors-header,omitempty"`
+	AuthzMiddleware      *authorization.Middleware `json:"-"`
+	AuthorizationPlugins []string                  `json:"authorization-plugins,omitempty"` // AuthorizationPlugins holds list of authorization plugins
+	// ContainerHooksPlugins holds the list of container-hooks plugins.
+	// Each one is called synchronously, in order, around container start
+	// (where it may deny the start or contribute extra devices to the OCI
+	// spec) and container cleanup, the same way volume and network
+	// plugins are reached over a unix socket. See pkg/hooks.
+	ContainerHooksPlugins []string            `json:"container-hooks-plugins,omitempty"`
+	AutoRestart           bool                `json:"-"`
+	Context               map[string][]string `json:"-"`
+	DisableBridge         bool                `json:"-"`
+	ExecOptions           []string            `json:"exec-opts,omitempty"`
+	GraphDriver           string              `json:"storage-driver,omitempty"`
+	GraphOptions          []string            `json:"storage-opts,omitempty"`
+	Labels                []string            `json:"labels,omitempty"`
+	Mtu                   int                 `json:"mtu,omitempty"`
+	NetworkDiagnosticPort int                 `json:"network-diagnostic-port,omitempty"`
+	Pidfile               string              `json:"pidfile,omitempty"`
+	RawLogs               bool                `json:"raw-logs,omitempty"`
+	RootDeprecated        string              `json:"graph,omitempty"`
+	Root                  string              `json:"data-root,omitempty"`
+	ExecRoot              string              `json:"exec-root,omitempty"`
+	SocketGroup           string              `json:"group,omitempty"`
+	CorsHeaders           string              `json:"api-cors-header,omitempty"`
 
 	// TrustKeyPath is used to generate the daemon ID and for signing schema 1 manifests
 	// when pushing to a registry which does not support schema 2. This field is marked as
@@ -169,6 +198,16 @@ type CommonConfig struct {
 	// alive upon daemon shutdown/start
 	LiveRestoreEnabled bool `json:"live-restore,omitempty"`
 
+	// ContainerdRestorePolicy controls what happens, on daemon startup, to
+	// containers whose task containerd reports as still running even though
+	// LiveRestoreEnabled is off (i.e. the daemon went down without a clean
+	// shutdown of those containers, typically a crash). It must be one of
+	// ContainerdRestorePolicyKill (the default: shut the task down, matching
+	// this daemon's historical behavior) or ContainerdRestorePolicyAdopt
+	// (leave the task running and reconcile the container's state against
+	// it, the same as a live-restore daemon would).
+	ContainerdRestorePolicy string `json:"containerd-restore-policy,omitempty"`
+
 	// ClusterStore is the storage backend used for the cluster information. It is used by both
 	// multihost networking (to store networks and endpoints information) and by the node discovery
 	// mechanism.
@@ -202,6 +241,38 @@ type CommonConfig struct {
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
 
+	// ShutdownParallelism is the maximum number of containers the daemon will
+	// stop concurrently during shutdown. A value of 0 (the default) means no
+	// limit, stopping every container at once.
+	ShutdownParallelism int `json:"shutdown-parallelism,omitempty"`
+
+	// LockWatchdogEnabled enables the internal lock profiling watchdog,
+	// which tracks container-state lock ownership and periodically logs
+	// locks held longer than LockWatchdogThreshold or lock-wait cycles
+	// between goroutines, together with the stack each lock was acquired
+	// from. Disabled by default, since tracking adds overhead to every
+	// container state lock/unlock.
+	LockWatchdogEnabled bool `json:"lock-watchdog-enabled,omitempty"`
+
+	// LockWatchdogThreshold is how long, in seconds, a container-state
+	// lock may be held before the watchdog logs a warning about it.
+	LockWatchdogThreshold int `json:"lock-watchdog-threshold,omitempty"`
+
+	// FailedBundleRetentionHours is how long, in hours, to keep a copy of
+	// the libcontainerd bundle (config.json and runtime state) of a
+	// container whose init process failed to start, so it can be fetched
+	// for post-mortem debugging via ContainerGetBundle. 0, the default,
+	// disables retention and bundles are removed immediately as before.
+	FailedBundleRetentionHours int `json:"failed-bundle-retention-hours,omitempty"`
+
+	// ShutdownPriorityLabel is the name of a container label holding an
+	// integer priority used to order container shutdown. Containers with a
+	// higher priority are stopped, and fully exit, before containers with a
+	// lower priority are signaled to stop; containers without the label, or
+	// with a non-integer value, are treated as priority 0. An empty value
+	// (the default) disables ordering, stopping all containers together.
+	ShutdownPriorityLabel string `json:"shutdown-priority-label,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	LogLevel  string   `json:"log-level,omitempty"`
@@ -230,6 +301,108 @@ type CommonConfig struct {
 
 	MetricsAddress string `json:"metrics-addr"`
 
+	// ContainerNetworkMetrics enables exporting per-container, per-network
+	// rx/tx byte and packet counters on the Prometheus metrics endpoint.
+	// This is opt-in because it adds a container_id label, which on a host
+	// with many short-lived containers can produce a large number of
+	// distinct time series.
+	ContainerNetworkMetrics bool `json:"container-network-metrics,omitempty"`
+
+	// StorageDriverHealthCheckFailFast causes the daemon to abort startup if
+	// the storage driver's self-test reports a problem (for drivers that
+	// implement one), instead of only recording the result in `docker info`.
+	StorageDriverHealthCheckFailFast bool `json:"storage-driver-health-check-fail-fast,omitempty"`
+
+	// ExtraHostsLabels lists container label keys that, when set on a
+	// container, contribute additional /etc/hosts entries for that
+	// container. Each label's value is a comma-separated list of
+	// "host:IP" pairs, using the same syntax as --add-host. This lets
+	// orchestration tooling attach extra host entries through labels
+	// without having to also plumb them through --add-host.
+	//
+	// This does not implement a general hosts-file templating mechanism;
+	// search-domain injection should continue to use --dns-search, and
+	// controlling the ordering of IPv4/IPv6 entries in the generated file
+	// is not supported.
+	ExtraHostsLabels []string `json:"extra-hosts-labels,omitempty"`
+
+	// ServeRegistryAddress, if set, makes the daemon listen on this
+	// address and serve a read-only subset of the registry v2 HTTP API
+	// over its local image store, so peer daemons on the LAN can point
+	// their registry-mirrors at it instead of each pulling the same
+	// image from the external registry individually.
+	//
+	// Only GET /v2/ (the API version probe) and GET /v2/_catalog (the
+	// list of locally present repositories) are implemented. Manifest
+	// and blob requests are answered with a 501 rather than silently
+	// failing: this tree's image store (daemon/images, layer/layer.go)
+	// keeps decompressed layer contents and parsed image config under
+	// graphdriver-assigned IDs, not the original compressed blobs or
+	// manifest JSON a registry client needs byte-for-byte, and
+	// reconstructing those losslessly from the local store is out of
+	// scope for a change confined to this tree. A true pull-through
+	// cache needs a real content-addressable blob store underneath,
+	// which is what containerd's content store (not used by the
+	// graphdriver storage path here) is for.
+	ServeRegistryAddress string `json:"serve-registry,omitempty"`
+
+	// P2PDistributionCommand, if set, names an external program the pull
+	// path invokes to fetch a layer blob from a peer-to-peer distributor
+	// (e.g. a Dragonfly or BitTorrent client) before falling back to the
+	// registry. It is invoked as "<command> <digest>" with the blob's
+	// digest (e.g. "sha256:...") as the sole argument, and must write the
+	// blob's exact bytes to stdout. The normal pull path verifies the
+	// output against digest exactly as it does a registry response, so a
+	// peer that returns the wrong or truncated bytes is caught the same
+	// way a corrupt registry response would be, and the pull falls back
+	// to downloading from the registry instead of failing outright.
+	//
+	// This does not implement chunked/partial fetches from multiple
+	// peers at once, or peer discovery: the command is wholly responsible
+	// for locating and retrieving the blob, run once per layer, with no
+	// ability to resume a partial transfer across invocations.
+	P2PDistributionCommand string `json:"p2p-distribution-command,omitempty"`
+
+	// CredentialHelpers maps a registry hostname to the name of a
+	// docker-credential-helpers-compatible binary (without its
+	// "docker-credential-" prefix, e.g. "ecr-login" for
+	// docker-credential-ecr-login) that the daemon runs to obtain
+	// credentials for pulls that have none of their own - in particular
+	// pulls triggered by a container's restart policy or a swarm task,
+	// which run long after the client that originally authenticated the
+	// pull is gone. It has no effect on pulls and pushes made through the
+	// API with an explicit AuthConfig; those are unaffected. The helper
+	// binary must already be installed and on the daemon's PATH.
+	CredentialHelpers map[string]string `json:"credential-helpers,omitempty"`
+
+	// PrePullImages maps an image tag reference to the interval (a Go
+	// duration string, e.g. "1h") at which the daemon keeps it pulled and
+	// up to date, so a node joining an autoscaling group already has it
+	// cached instead of pulling on first use. Pulls use no registry
+	// credentials, so this only works for public repositories. While on
+	// this list, an image is also exempt from `docker image prune`,
+	// whether or not it's otherwise dangling.
+	PrePullImages map[string]string `json:"pre-pull-images,omitempty"`
+
+	// TrustPolicyPath, if set, names a JSON file mapping registries and
+	// repositories to the signatures (e.g. cosign, Notary) an image must
+	// carry before Pull will accept it. See daemon/trustpolicy for the
+	// file format and, importantly, what this build can and cannot
+	// actually verify.
+	TrustPolicyPath string `json:"trust-policy-file,omitempty"`
+
+	// VulnerabilityScanCommand, if set, is an external command run after
+	// each successful pull or build to scan the resulting image. It is
+	// sent the image's layer digests and raw JSON config as a
+	// daemon/imagescan.Request on stdin, and must print a JSON array of
+	// daemon/imagescan.Finding to stdout.
+	VulnerabilityScanCommand string `json:"vulnerability-scan-command,omitempty"`
+	// VulnerabilityBlockSeverity, if set, causes ContainerCreate to
+	// reject an image with any scan Finding at or above this severity
+	// ("low", "medium", "high", or "critical"). It has no effect unless
+	// VulnerabilityScanCommand is also set.
+	VulnerabilityBlockSeverity string `json:"vulnerability-block-severity,omitempty"`
+
 	DNSConfig
 	LogConfig
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
@@ -264,6 +437,28 @@ type CommonConfig struct {
 
 	ContainerdNamespace       string `json:"containerd-namespace,omitempty"`
 	ContainerdPluginNamespace string `json:"containerd-plugin-namespace,omitempty"`
+
+	// AllowLazyPull makes pulls recognise seekable eStargz layers. No
+	// remote snapshotter is wired into the pull path, so these layers, like
+	// layers that are not eStargz-indexed, are pulled normally in full.
+	AllowLazyPull bool `json:"allow-lazy-pull,omitempty"`
+
+	// ProvisioningManifest is the path or URL of a first-boot provisioning
+	// manifest listing images, networks and volumes for the daemon to
+	// create on its first start. It is applied once; subsequent starts
+	// skip it.
+	ProvisioningManifest string `json:"provisioning-manifest,omitempty"`
+
+	// StatsHistoryInterval is the interval, in seconds, at which the daemon
+	// samples cpu/memory/io usage for running containers into its in-memory
+	// stats history, independent of whether anything is streaming live
+	// stats for that container.
+	StatsHistoryInterval int `json:"stats-history-interval,omitempty"`
+
+	// StatsHistoryMaxSamples is the maximum number of stats history samples
+	// retained per container, oldest first discarded once the limit is
+	// reached.
+	StatsHistoryMaxSamples int `json:"stats-history-max-samples,omitempty"`
 }
 
 // IsValueSet returns true if a configuration value
@@ -281,6 +476,8 @@ func New() *Config {
 	config := Config{}
 	config.LogConfig.Config = make(map[string]string)
 	config.ClusterOpts = make(map[string]string)
+	config.CredentialHelpers = make(map[string]string)
+	config.PrePullImages = make(map[string]string)
 	return &config
 }
 
@@ -561,6 +758,16 @@ func Validate(config *Config) error {
 			return err
 		}
 	}
+
+	// DNSCacheSize, DNSCachePositiveTTL, and DNSCacheNegativeTTL are accepted
+	// in daemon.json so the error below is actionable, but the embedded
+	// resolver they would configure keeps no cache at all; see the
+	// doc comment on DNSConfig.
+	if config.DNSCacheSize != 0 || config.DNSCachePositiveTTL != 0 || config.DNSCacheNegativeTTL != 0 {
+		return fmt.Errorf("dns-cache-size, dns-cache-positive-ttl, and dns-cache-negative-ttl are not implemented by this build: " +
+			"the embedded DNS server answers every query from its in-memory service table with a fixed response TTL and no cache, " +
+			"so there is no cache to size or tune")
+	}
 	// validate MaxConcurrentDownloads
 	if config.MaxConcurrentDownloads != nil && *config.MaxConcurrentDownloads < 0 {
 		return fmt.Errorf("invalid max concurrent downloads: %d", *config.MaxConcurrentDownloads)
@@ -584,6 +791,13 @@ func Validate(config *Config) error {
 		return err
 	}
 
+	// validate ContainerdRestorePolicy
+	switch config.ContainerdRestorePolicy {
+	case "", ContainerdRestorePolicyKill, ContainerdRestorePolicyAdopt:
+	default:
+		return fmt.Errorf("invalid containerd restore policy: %q (must be %q or %q)", config.ContainerdRestorePolicy, ContainerdRestorePolicyKill, ContainerdRestorePolicyAdopt)
+	}
+
 	if defaultRuntime := config.GetDefaultRuntimeName(); defaultRuntime != "" {
 		if !builtinRuntimes[defaultRuntime] {
 			runtimes := config.GetAllRuntimes()
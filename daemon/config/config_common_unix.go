@@ -1,3 +1,4 @@
+//go:build linux || freebsd
 // +build linux freebsd
 
 package config // import "github.com/docker/docker/daemon/config"
@@ -14,6 +15,14 @@ type CommonUnixConfig struct {
 	Runtimes          map[string]types.Runtime `json:"runtimes,omitempty"`
 	DefaultRuntime    string                   `json:"default-runtime,omitempty"`
 	DefaultInitBinary string                   `json:"default-init,omitempty"`
+
+	// TrustedImageRuntimes lists the runtime names that images are allowed
+	// to request via the image.RuntimeLabel config label. An image
+	// requesting a runtime not in this list is ignored, so that pulling and
+	// running an untrusted image cannot, by itself, select a more
+	// privileged or otherwise unexpected runtime. Empty by default, meaning
+	// image-requested runtimes are disabled unless explicitly opted into.
+	TrustedImageRuntimes []string `json:"trusted-image-runtimes,omitempty"`
 }
 
 type commonUnixBridgeConfig struct {
@@ -52,6 +61,22 @@ func (conf *Config) GetAllRuntimes() map[string]types.Runtime {
 	return rts
 }
 
+// IsImageRuntimeTrusted reports whether images are allowed to request the
+// given runtime name via the image.RuntimeLabel config label.
+func (conf *Config) IsImageRuntimeTrusted(name string) bool {
+	if name == "" {
+		return false
+	}
+	conf.Lock()
+	defer conf.Unlock()
+	for _, trusted := range conf.TrustedImageRuntimes {
+		if trusted == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetExecRoot returns the user configured Exec-root
 func (conf *Config) GetExecRoot() string {
 	return conf.ExecRoot
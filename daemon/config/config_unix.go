@@ -1,3 +1,4 @@
+//go:build linux || freebsd
 // +build linux freebsd
 
 package config // import "github.com/docker/docker/daemon/config"
@@ -41,6 +42,49 @@ type Config struct {
 	// ResolvConf is the path to the configuration of the host resolver
 	ResolvConf string `json:"resolv-conf,omitempty"`
 	Rootless   bool   `json:"rootless,omitempty"`
+
+	// MaskedPaths and ReadonlyPaths override the built-in default sets of
+	// masked and read-only paths applied to non-privileged containers. A
+	// container can further adjust its own effective set with
+	// HostConfig.MaskedPathsAdd/Drop and ReadonlyPathsAdd/Drop.
+	MaskedPaths   []string `json:"default-masked-paths,omitempty"`
+	ReadonlyPaths []string `json:"default-readonly-paths,omitempty"`
+
+	// DefaultPolicyBundles applies sysctls, ulimits, and a seccomp profile
+	// to containers matching a bundle's label selector, at container
+	// creation time. Bundles are config-file only; there is no API to
+	// manage them dynamically, and changing them does not affect already
+	// running containers. When more than one bundle matches a container,
+	// bundles are consulted in the order they appear here, and the first
+	// bundle to set a given key wins. A value explicitly set on the
+	// container (HostConfig.Sysctls, HostConfig.Ulimits, or a
+	// "seccomp=..." SecurityOpt) always takes precedence over any bundle,
+	// and the merged result is stored on the container's HostConfig, so it
+	// is visible through `docker inspect` like any other setting.
+	DefaultPolicyBundles []PolicyBundle `json:"default-policy-bundles,omitempty"`
+}
+
+// PolicyBundle is a named set of defaults applied to containers whose
+// labels match LabelSelector. See Config.DefaultPolicyBundles.
+type PolicyBundle struct {
+	Name string `json:"name,omitempty"`
+	// LabelSelector lists label key/value pairs that must all be present
+	// on a container for this bundle to apply.
+	LabelSelector  map[string]string `json:"label-selector"`
+	Sysctls        map[string]string `json:"sysctls,omitempty"`
+	Ulimits        []*units.Ulimit   `json:"ulimits,omitempty"`
+	SeccompProfile string            `json:"seccomp-profile,omitempty"`
+}
+
+// Matches reports whether every key/value pair in the bundle's
+// LabelSelector is present in labels.
+func (b *PolicyBundle) Matches(labels map[string]string) bool {
+	for k, v := range b.LabelSelector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
 }
 
 // BridgeConfig stores all the bridge driver specific
@@ -59,6 +103,52 @@ type BridgeConfig struct {
 	EnableUserlandProxy bool   `json:"userland-proxy,omitempty"`
 	UserlandProxyPath   string `json:"userland-proxy-path,omitempty"`
 	FixedCIDRv6         string `json:"fixed-cidr-v6,omitempty"`
+	PortDriver          string `json:"port-driver,omitempty"`
+}
+
+// Port-forwarding backends selectable via BridgeConfig.PortDriver.
+const (
+	// PortDriverIPTables is the default, and today the only implemented,
+	// backend: published ports are forwarded with iptables DNAT rules
+	// (plus docker-proxy for userland-proxied ports).
+	PortDriverIPTables = "iptables"
+	// PortDriverNFTables selects an nftables-based backend instead of
+	// iptables rules.
+	PortDriverNFTables = "nftables"
+	// PortDriverEBPF selects an eBPF-based backend instead of iptables
+	// rules, for lower per-connection overhead on hosts with many
+	// published ports.
+	PortDriverEBPF = "ebpf"
+)
+
+// validPortDrivers are the recognized values of BridgeConfig.PortDriver.
+var validPortDrivers = map[string]bool{
+	PortDriverIPTables: true,
+	PortDriverNFTables: true,
+	PortDriverEBPF:     true,
+}
+
+// verifyPortDriver checks that driver is a known port-forwarding backend,
+// and that it's one this build actually implements.
+//
+// Only PortDriverIPTables is implemented: the port mapper that programs
+// the rules lives in the vendored libnetwork bridge driver, which only
+// knows how to speak iptables today. Teaching it nftables or eBPF is a
+// change to that vendored driver, not something reachable from here, so
+// the other two values are accepted (so daemon.json can name them and get
+// a clear error) but rejected at startup rather than silently falling
+// back to iptables.
+func verifyPortDriver(driver string) error {
+	if driver == "" {
+		return nil
+	}
+	if !validPortDrivers[driver] {
+		return fmt.Errorf("invalid port-driver: %q (must be %q, %q, or %q)", driver, PortDriverIPTables, PortDriverNFTables, PortDriverEBPF)
+	}
+	if driver != PortDriverIPTables {
+		return fmt.Errorf("port-driver %q is not implemented by this build; only %q is currently supported", driver, PortDriverIPTables)
+	}
+	return nil
 }
 
 // IsSwarmCompatible defines if swarm mode can be enabled in this config
@@ -100,6 +190,10 @@ func (conf *Config) ValidatePlatformConfig() error {
 		return err
 	}
 
+	if err := verifyPortDriver(conf.BridgeConfig.PortDriver); err != nil {
+		return err
+	}
+
 	return verifyDefaultCgroupNsMode(conf.CgroupNamespaceMode)
 }
 
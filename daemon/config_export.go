@@ -0,0 +1,119 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	volumeopts "github.com/docker/docker/volume/service/opts"
+	"github.com/pkg/errors"
+)
+
+// systemExportManifest is the set of daemon-level, non-runtime objects
+// captured by SystemExport. Containers and images are intentionally left
+// out: they carry their own export/save paths and would make the archive
+// unbounded in size.
+type systemExportManifest struct {
+	Networks []types.NetworkResource `json:"networks"`
+	Volumes  []*types.Volume         `json:"volumes"`
+}
+
+// SystemExport serializes the daemon's networks and volume definitions
+// into a portable tar archive, for host rebuilds and fleet templating.
+func (daemon *Daemon) SystemExport(ctx context.Context) (io.ReadCloser, error) {
+	networks, err := daemon.GetNetworks(filters.NewArgs(), types.NetworkListConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, _, err := daemon.volumes.List(ctx, filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := systemExportManifest{Networks: networks, Volumes: volumes}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.WriteHeader(&tar.Header{
+			Name: "manifest.json",
+			Mode: 0644,
+			Size: int64(len(data)),
+		})
+		if err == nil {
+			_, err = tw.Write(data)
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// SystemImport recreates the networks and volumes described in a
+// systemExportManifest archive produced by SystemExport. Objects whose
+// name already exists on the daemon are left untouched.
+func (daemon *Daemon) SystemImport(ctx context.Context, in io.Reader) error {
+	tr := tar.NewReader(in)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return errors.New("system import archive does not contain a manifest")
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Name != "manifest.json" {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		var manifest systemExportManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return errors.Wrap(err, "invalid system import manifest")
+		}
+		return daemon.restoreExportedObjects(ctx, manifest)
+	}
+}
+
+func (daemon *Daemon) restoreExportedObjects(ctx context.Context, manifest systemExportManifest) error {
+	for _, v := range manifest.Volumes {
+		if _, err := daemon.volumes.Get(ctx, v.Name); err == nil {
+			continue
+		}
+		if _, err := daemon.volumes.Create(ctx, v.Name, v.Driver, volumeopts.WithCreateOptions(v.Options)); err != nil {
+			return errors.Wrapf(err, "importing volume %s", v.Name)
+		}
+	}
+
+	for _, n := range manifest.Networks {
+		if _, err := daemon.GetNetworkByName(n.Name); err == nil {
+			continue
+		}
+		if _, err := daemon.CreateNetwork(types.NetworkCreateRequest{
+			Name: n.Name,
+			NetworkCreate: types.NetworkCreate{
+				Driver:  n.Driver,
+				IPAM:    &n.IPAM,
+				Options: n.Options,
+				Labels:  n.Labels,
+			},
+		}); err != nil {
+			return errors.Wrapf(err, "importing network %s", n.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/tailfile"
+	"github.com/pkg/errors"
+)
+
+// ContainerConsoleLogs returns the captured guest console/serial output of
+// a container, for VM-backed runtimes (e.g. kata-style sandboxes) that
+// attach a console device. It is independent of the container's regular
+// stdio logs, which are handled by ContainerLogs.
+func (daemon *Daemon) ContainerConsoleLogs(containerName string, config *types.ContainerConsoleLogsOptions) (io.ReadCloser, error) {
+	ctr, err := daemon.GetContainer(containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Follow {
+		return nil, errdefs.NotImplemented(errors.New("following console logs is not yet supported"))
+	}
+
+	f, err := os.Open(ctr.ConsoleLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errdefs.InvalidParameter(errors.Errorf("container %s has no captured console output", ctr.ID))
+		}
+		return nil, errors.Wrap(err, "failed to open console log")
+	}
+
+	if config.Tail == "" {
+		return f, nil
+	}
+
+	defer f.Close()
+	n, err := strconv.Atoi(config.Tail)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(errors.Wrap(err, "invalid tail value"))
+	}
+	lines, err := tailfile.TailFile(f, n)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to tail console log")
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return ioutil.NopCloser(&buf), nil
+}
@@ -12,6 +12,7 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/container/stream"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
@@ -30,11 +31,11 @@ import (
 
 // GetContainer looks for a container using the provided information, which could be
 // one of the following inputs from the caller:
-//  - A full container ID, which will exact match a container in daemon's list
-//  - A container name, which will only exact match via the GetByName() function
-//  - A partial container ID prefix (e.g. short ID) of any length that is
-//    unique enough to only return a single container object
-//  If none of these searches succeed, an error is returned
+//   - A full container ID, which will exact match a container in daemon's list
+//   - A container name, which will only exact match via the GetByName() function
+//   - A partial container ID prefix (e.g. short ID) of any length that is
+//     unique enough to only return a single container object
+//     If none of these searches succeed, an error is returned
 func (daemon *Daemon) GetContainer(prefixOrName string) (*container.Container, error) {
 	if len(prefixOrName) == 0 {
 		return nil, errors.WithStack(invalidIdentifier(prefixOrName))
@@ -115,6 +116,8 @@ func (daemon *Daemon) Register(c *container.Container) error {
 		c.StreamConfig.NewNopInputPipe()
 	}
 
+	daemon.configureStdioLimits(c)
+
 	// once in the memory store it is visible to other goroutines
 	// grab a Lock until it has been checkpointed to avoid races
 	c.Lock()
@@ -125,6 +128,32 @@ func (daemon *Daemon) Register(c *container.Container) error {
 	return c.CheckpointTo(daemon.containersReplica)
 }
 
+// configureStdioLimits applies the container's HostConfig.Stdio settings to
+// its StreamConfig, translating the kill-container policy into a callback
+// since StreamConfig itself has no notion of killing a container.
+func (daemon *Daemon) configureStdioLimits(c *container.Container) {
+	stdio := c.HostConfig.Stdio
+	if stdio.MaxBufferSize <= 0 {
+		return
+	}
+
+	policy := stream.OverflowBlock
+	var onOverflow func(string)
+	switch stdio.OverflowPolicy {
+	case containertypes.StdioOverflowDropOldest:
+		policy = stream.OverflowDropOldest
+	case containertypes.StdioOverflowKillContainer:
+		onOverflow = func(streamName string) {
+			logrus.WithField("container", c.ID).Warnf("%s buffer overflowed, killing container per stdio overflow policy", streamName)
+			if err := daemon.Kill(c); err != nil {
+				logrus.WithError(err).WithField("container", c.ID).Warn("killing container after stdio buffer overflow")
+			}
+		}
+	}
+
+	c.StreamConfig.SetStdioLimits(stdio.MaxBufferSize, policy, onOverflow)
+}
+
 func (daemon *Daemon) newContainer(name string, operatingSystem string, config *containertypes.Config, hostConfig *containertypes.HostConfig, imgID image.ID, managed bool) (*container.Container, error) {
 	var (
 		id             string
@@ -232,6 +261,14 @@ func (daemon *Daemon) setHostConfig(container *container.Container, hostConfig *
 // verifyContainerSettings performs validation of the hostconfig and config
 // structures.
 func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *containertypes.HostConfig, config *containertypes.Config, update bool) (warnings []string, err error) {
+	return daemon.verifyContainerSettingsForImage(platform, hostConfig, config, nil, update)
+}
+
+// verifyContainerSettingsForImage is like verifyContainerSettings but also
+// takes the image the container is being created from, so that platform
+// verification can apply image-requested defaults (such as RuntimeLabel)
+// before falling back to daemon-wide defaults.
+func (daemon *Daemon) verifyContainerSettingsForImage(platform string, hostConfig *containertypes.HostConfig, config *containertypes.Config, img *image.Image, update bool) (warnings []string, err error) {
 	// First perform verification of settings common across all platforms.
 	if err = validateContainerConfig(config, platform); err != nil {
 		return warnings, err
@@ -241,7 +278,7 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 	}
 
 	// Now do platform-specific verification
-	warnings, err = verifyPlatformContainerSettings(daemon, hostConfig, update)
+	warnings, err = verifyPlatformContainerSettings(daemon, hostConfig, img, update)
 	for _, w := range warnings {
 		logrus.Warn(w)
 	}
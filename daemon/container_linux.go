@@ -1,4 +1,5 @@
-//+build !windows
+//go:build !windows
+// +build !windows
 
 package daemon // import "github.com/docker/docker/daemon"
 
@@ -28,3 +29,13 @@ func (daemon *Daemon) saveAppArmorConfig(container *container.Container) error {
 	}
 	return nil
 }
+
+// publishNamedPipes is a no-op on non-Windows platforms: named pipes are a
+// Windows-only concept, so HostConfig.NamedPipes is never populated here.
+func (daemon *Daemon) publishNamedPipes(container *container.Container) error {
+	return nil
+}
+
+// unpublishNamedPipes is a no-op on non-Windows platforms; see publishNamedPipes.
+func (daemon *Daemon) unpublishNamedPipes(container *container.Container) {
+}
@@ -45,6 +45,13 @@ func (daemon *Daemon) getDNSSearchSettings(container *container.Container) []str
 	return nil
 }
 
+// isSandboxedRuntime reports whether name is configured in the daemon as a
+// VM-isolated sandbox runtime (e.g. Kata Containers, gVisor).
+func (daemon *Daemon) isSandboxedRuntime(name string) bool {
+	rt := daemon.configStore.GetRuntime(name)
+	return rt != nil && rt.Sandboxed
+}
+
 func (daemon *Daemon) buildSandboxOptions(container *container.Container) ([]libnetwork.SandboxOption, error) {
 	var (
 		sboxOptions []libnetwork.SandboxOption
@@ -60,11 +67,14 @@ func (daemon *Daemon) buildSandboxOptions(container *container.Container) ([]lib
 	sboxOptions = append(sboxOptions, libnetwork.OptionHostname(container.Config.Hostname),
 		libnetwork.OptionDomainname(container.Config.Domainname))
 
-	if container.HostConfig.NetworkMode.IsHost() {
+	if container.HostConfig.NetworkMode.IsHost() && !daemon.isSandboxedRuntime(container.HostConfig.Runtime) {
 		sboxOptions = append(sboxOptions, libnetwork.OptionUseDefaultSandbox())
 	} else {
-		// OptionUseExternalKey is mandatory for userns support.
-		// But optional for non-userns support
+		// OptionUseExternalKey is mandatory for userns support. But
+		// optional for non-userns support. It is also required for
+		// VM-isolated sandbox runtimes, which set up their own network
+		// namespace inside the guest instead of joining the one
+		// libnetwork would otherwise create.
 		sboxOptions = append(sboxOptions, libnetwork.OptionUseExternalKey())
 	}
 
@@ -76,6 +86,8 @@ func (daemon *Daemon) buildSandboxOptions(container *container.Container) ([]lib
 		dns = container.HostConfig.DNS
 	} else if len(daemon.configStore.DNS) > 0 {
 		dns = daemon.configStore.DNS
+	} else {
+		dns = daemon.networkDNSServers(container)
 	}
 
 	for _, d := range dns {
@@ -128,6 +140,20 @@ func (daemon *Daemon) buildSandboxOptions(container *container.Container) ([]lib
 		sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(parts[0], parts[1]))
 	}
 
+	for _, labelKey := range daemon.configStore.ExtraHostsLabels {
+		value, ok := container.Config.Labels[labelKey]
+		if !ok || value == "" {
+			continue
+		}
+		for _, extraHost := range strings.Split(value, ",") {
+			if _, err := opts.ValidateExtraHost(extraHost); err != nil {
+				return nil, fmt.Errorf("invalid extra host entry in label %q: %v", labelKey, err)
+			}
+			parts := strings.SplitN(extraHost, ":", 2)
+			sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(parts[0], parts[1]))
+		}
+	}
+
 	if container.HostConfig.PortBindings != nil {
 		for p, b := range container.HostConfig.PortBindings {
 			bindings[p] = []nat.PortBinding{}
@@ -773,6 +799,25 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return err
 	}
 
+	endpointName := strings.TrimPrefix(container.Name, "/")
+
+	// On macvlan/ipvlan networks, reuse the address this same container
+	// name was assigned the last time it connected here, so recreating a
+	// container doesn't silently hand its old address to a different
+	// container. A caller-supplied address always takes priority.
+	if !hasUserDefinedIPAddress(endpointConfig.IPAMConfig) {
+		if addr, ok := daemon.stickyEndpointAddress(n, endpointName); ok {
+			if endpointConfig.IPAMConfig == nil {
+				endpointConfig.IPAMConfig = &networktypes.EndpointIPAMConfig{}
+			}
+			endpointConfig.IPAMConfig.IPv4Address = addr.IPv4
+			endpointConfig.IPAMConfig.IPv6Address = addr.IPv6
+			if addr.MAC != "" {
+				endpointConfig.MacAddress = addr.MAC
+			}
+		}
+	}
+
 	controller := daemon.netController
 	sb := daemon.getNetworkSandbox(container)
 	createOptions, err := buildCreateEndpointOptions(container, n, endpointConfig, sb, daemon.configStore.DNS)
@@ -780,7 +825,6 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return err
 	}
 
-	endpointName := strings.TrimPrefix(container.Name, "/")
 	ep, err := n.CreateEndpoint(endpointName, createOptions...)
 	if err != nil {
 		return err
@@ -803,6 +847,14 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return err
 	}
 
+	if es := container.NetworkSettings.Networks[n.Name()]; es != nil {
+		daemon.rememberEndpointAddress(n, endpointName, stickyAddress{
+			IPv4: es.IPAddress,
+			IPv6: es.GlobalIPv6Address,
+			MAC:  es.MacAddress,
+		})
+	}
+
 	if sb == nil {
 		sbOptions, err := daemon.buildSandboxOptions(container)
 		if err != nil {
@@ -825,6 +877,8 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return err
 	}
 
+	daemon.announceGratuitousARP(n, sb, ep)
+
 	if !container.Managed {
 		// add container name/alias to DNS
 		if err := daemon.ActivateContainerServiceBinding(container.Name); err != nil {
@@ -975,6 +1029,16 @@ func (daemon *Daemon) initializeNetworking(container *container.Container) error
 		return nil
 	}
 
+	if container.HostConfig.NetworkMode.IsPod() {
+		// The namespace itself is resolved and joined when the OCI spec
+		// is built (see WithNamespaces in oci_linux.go); check here only
+		// so a missing pod fails fast instead of partway through start.
+		if _, err := daemon.netPodPath(container.HostConfig.NetworkMode.ConnectedPod()); err != nil {
+			return err
+		}
+		return container.BuildHostnameFile()
+	}
+
 	if container.HostConfig.NetworkMode.IsHost() {
 		if container.Config.Hostname == "" {
 			container.Config.Hostname, err = os.Hostname()
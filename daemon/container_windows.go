@@ -1,9 +1,120 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	winio "github.com/Microsoft/go-winio"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func (daemon *Daemon) saveAppArmorConfig(container *container.Container) error {
 	return nil
 }
+
+// defaultNamedPipeSecurityDescriptor grants full access to SYSTEM and
+// Administrators, and generic read/write to the interactive user, mirroring
+// the defaults applied to other daemon-managed pipes.
+const defaultNamedPipeSecurityDescriptor = "D:P(A;;GA;;;SY)(A;;GA;;;BA)(A;;GA;;;IU)"
+
+// publishNamedPipes creates a host-side named pipe listener, with the
+// configured (or default) ACL, for every entry in the container's
+// HostConfig.NamedPipes, and starts forwarding connections made to it into
+// the corresponding named pipe inside the container. This is the named pipe
+// equivalent of publishing a TCP/UDP port.
+//
+// Forwarding is done by dialing the container's named pipe directly from the
+// host, which only succeeds for process-isolated containers: their named
+// pipe namespace is visible to the host. Hyper-V isolated containers run in
+// a separate utility VM, so their named pipes cannot be reached this way;
+// connections accepted on the host listener for such a container will fail
+// to forward and are logged as an error rather than published silently.
+func (daemon *Daemon) publishNamedPipes(c *container.Container) (err error) {
+	pipes := c.HostConfig.NamedPipes
+	if len(pipes) == 0 {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			daemon.unpublishNamedPipes(c)
+		}
+	}()
+
+	for _, p := range pipes {
+		if p.ContainerPipe == "" || p.HostPipe == "" {
+			return errdefs.InvalidParameter(errors.New("named pipe publish config requires both ContainerPipe and HostPipe"))
+		}
+
+		sd := p.SecurityDescriptor
+		if sd == "" {
+			sd = defaultNamedPipeSecurityDescriptor
+		}
+
+		l, err := winio.ListenPipe(p.HostPipe, &winio.PipeConfig{SecurityDescriptor: sd})
+		if err != nil {
+			return errdefs.System(errors.Wrapf(err, "publishing named pipe %s", p.HostPipe))
+		}
+
+		c.NamedPipeListeners = append(c.NamedPipeListeners, l)
+		go daemon.forwardNamedPipeConns(c.ID, l, p.ContainerPipe, p.HostPipe)
+	}
+
+	return nil
+}
+
+// forwardNamedPipeConns accepts connections on l and forwards them to the
+// container's named pipe at containerPipe, until l is closed.
+func (daemon *Daemon) forwardNamedPipeConns(containerID string, l net.Listener, containerPipe, hostPipe string) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go daemon.forwardNamedPipeConn(containerID, conn, containerPipe, hostPipe)
+	}
+}
+
+func (daemon *Daemon) forwardNamedPipeConn(containerID string, hostConn net.Conn, containerPipe, hostPipe string) {
+	defer hostConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ctrConn, err := winio.DialPipeContext(ctx, containerPipe)
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"container": containerID,
+			"hostPipe":  hostPipe,
+		}).Error("failed to connect published named pipe to container named pipe")
+		return
+	}
+	defer ctrConn.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(ctrConn, hostConn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(hostConn, ctrConn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// unpublishNamedPipes closes all named pipe listeners published on behalf of
+// the container.
+func (daemon *Daemon) unpublishNamedPipes(c *container.Container) {
+	for _, l := range c.NamedPipeListeners {
+		if err := l.Close(); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Warn("error closing published named pipe listener")
+		}
+	}
+	c.NamedPipeListeners = nil
+}
@@ -1,12 +1,16 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	networktypes "github.com/docker/docker/api/types/network"
@@ -14,6 +18,7 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/runconfig"
 	"github.com/opencontainers/selinux/go-selinux"
@@ -59,10 +64,15 @@ func (daemon *Daemon) containerCreate(opts createOpts) (containertypes.Container
 	}
 
 	os := runtime.GOOS
+	var img *image.Image
 	if opts.params.Config.Image != "" {
-		img, err := daemon.imageService.GetImage(opts.params.Config.Image, opts.params.Platform)
+		var err error
+		img, err = daemon.imageService.GetImage(opts.params.Config.Image, opts.params.Platform)
 		if err == nil {
 			os = img.OS
+			if result, ok := daemon.imageService.VulnerabilityScanResult(img.ID().String()); ok && result.Blocked {
+				return containertypes.ContainerCreateCreatedBody{}, errdefs.Forbidden(errors.Errorf("image %s is blocked by the vulnerability scan policy: %s", opts.params.Config.Image, result.Reason))
+			}
 		}
 	} else {
 		// This mean scratch. On Windows, we can safely assume that this is a linux
@@ -72,7 +82,7 @@ func (daemon *Daemon) containerCreate(opts createOpts) (containertypes.Container
 		}
 	}
 
-	warnings, err := daemon.verifyContainerSettings(os, opts.params.HostConfig, opts.params.Config, false)
+	warnings, err := daemon.verifyContainerSettingsForImage(os, opts.params.HostConfig, opts.params.Config, img, false)
 	if err != nil {
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
@@ -85,13 +95,25 @@ func (daemon *Daemon) containerCreate(opts createOpts) (containertypes.Container
 	if opts.params.HostConfig == nil {
 		opts.params.HostConfig = &containertypes.HostConfig{}
 	}
+	daemon.applyDefaultPolicyBundles(opts.params.Config.Labels, opts.params.HostConfig)
 	err = daemon.adaptContainerSettings(opts.params.HostConfig, opts.params.AdjustCPUShares)
 	if err != nil {
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
 
+	var displaced *displacedContainer
+	if opts.params.Replace && opts.params.Name != "" {
+		displaced, err = daemon.displaceForReplace(opts.params.Name)
+		if err != nil {
+			return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, err
+		}
+	}
+
 	ctr, err := daemon.create(opts)
 	if err != nil {
+		if displaced != nil {
+			daemon.restoreDisplaced(displaced)
+		}
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, err
 	}
 	containerActions.WithValues("create").UpdateSince(start)
@@ -103,6 +125,105 @@ func (daemon *Daemon) containerCreate(opts createOpts) (containertypes.Container
 	return containertypes.ContainerCreateCreatedBody{ID: ctr.ID, Warnings: warnings}, nil
 }
 
+// displacedContainer records a container that displaceForReplace renamed out
+// of the way, so restoreDisplaced can put it back.
+type displacedContainer struct {
+	id           string
+	originalName string
+}
+
+// displaceForReplace looks for an existing container registered under name.
+// If one exists, it is stopped (if running) and renamed out of the way so
+// the name is free for the new container being created, and a
+// displacedContainer is returned so the caller can restore it with
+// restoreDisplaced if the new container's creation subsequently fails. It
+// returns a nil displacedContainer and no error if name is not currently in
+// use.
+//
+// This implements the "replace" half of atomic create-or-replace: removing
+// the stop/rm/create race that deployment scripts otherwise have to
+// hand-roll. The displaced container is not removed, and if the new
+// container is created successfully the old one is not restarted, even if
+// it was running before the replace: the caller is expected to start the
+// new container explicitly, same as any other create.
+func (daemon *Daemon) displaceForReplace(name string) (*displacedContainer, error) {
+	existing, err := daemon.GetContainer(name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if existing.IsRunning() {
+		if err := daemon.containerStop(existing, existing.StopTimeout()); err != nil {
+			return nil, errdefs.System(errors.Wrapf(err, "cannot stop container %s for replacement", existing.ID))
+		}
+	}
+
+	originalName := existing.Name
+	backupName := "/" + stringid.TruncateID(existing.ID) + "-replaced"
+	if err := daemon.ContainerRename(existing.ID, backupName); err != nil {
+		return nil, errors.Wrapf(err, "failed to rename existing container %s out of the way for replacement", existing.ID)
+	}
+	return &displacedContainer{id: existing.ID, originalName: originalName}, nil
+}
+
+// restoreDisplaced renames a container displaced by displaceForReplace back
+// to its original name after the replacement container failed to create.
+func (daemon *Daemon) restoreDisplaced(displaced *displacedContainer) {
+	if err := daemon.ContainerRename(displaced.id, displaced.originalName); err != nil {
+		logrus.Errorf("failed to restore displaced container %s to its original name %s after create-or-replace failure: %v", displaced.id, displaced.originalName, err)
+	}
+}
+
+// imageForCreate resolves the image to use for a new container, honoring
+// Config.ImagePullPolicy so that pull decisions are made consistently by the
+// daemon rather than left entirely to API clients. This also means that
+// implicit re-creates, such as those driven by a restart policy, apply the
+// same pull policy every time, instead of only pulling once up front the way
+// a client-side pre-pull does.
+func (daemon *Daemon) imageForCreate(params types.ContainerCreateConfig) (*image.Image, error) {
+	policy := params.Config.ImagePullPolicy
+	if policy == "" {
+		policy = containertypes.PullPolicyNever
+	}
+
+	switch policy {
+	case containertypes.PullPolicyNever:
+	case containertypes.PullPolicyIfNotPresent:
+	case containertypes.PullPolicyAlways:
+	case containertypes.PullPolicyDigest:
+		ref, err := reference.ParseNormalizedNamed(params.Config.Image)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(errors.Wrapf(err, "invalid image reference %q for pull policy %q", params.Config.Image, policy))
+		}
+		if _, ok := ref.(reference.Canonical); !ok {
+			return nil, errdefs.InvalidParameter(errors.Errorf("pull policy %q requires Image to be pinned to a digest (e.g. name@sha256:...)", policy))
+		}
+	default:
+		return nil, errdefs.InvalidParameter(errors.Errorf("invalid pull policy: %q", policy))
+	}
+
+	if policy == containertypes.PullPolicyIfNotPresent {
+		if img, err := daemon.imageService.GetImage(params.Config.Image, params.Platform); err == nil {
+			return img, nil
+		}
+	}
+
+	if policy == containertypes.PullPolicyAlways || policy == containertypes.PullPolicyDigest || policy == containertypes.PullPolicyIfNotPresent {
+		authConfig := params.AuthConfig
+		if authConfig == nil {
+			authConfig = &types.AuthConfig{}
+		}
+		if err := daemon.imageService.PullImage(context.Background(), params.Config.Image, "", params.Platform, nil, authConfig, ioutil.Discard); err != nil {
+			return nil, errdefs.System(errors.Wrapf(err, "pulling image %s for pull policy %q", params.Config.Image, policy))
+		}
+	}
+
+	return daemon.imageService.GetImage(params.Config.Image, params.Platform)
+}
+
 // Create creates a new container from the given configuration with a given name.
 func (daemon *Daemon) create(opts createOpts) (retC *container.Container, retErr error) {
 	var (
@@ -114,7 +235,7 @@ func (daemon *Daemon) create(opts createOpts) (retC *container.Container, retErr
 
 	os := runtime.GOOS
 	if opts.params.Config.Image != "" {
-		img, err = daemon.imageService.GetImage(opts.params.Config.Image, opts.params.Platform)
+		img, err = daemon.imageForCreate(opts.params)
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +291,20 @@ func (daemon *Daemon) create(opts createOpts) (retC *container.Container, retErr
 
 	ctr.HostConfig.StorageOpt = opts.params.HostConfig.StorageOpt
 
+	// DiskQuota is a convenience field for the "size" storage option
+	// supported by drivers with project-quota support (overlay2,
+	// devicemapper, btrfs, zfs): set it here so HostConfig.DiskQuota maps
+	// onto the same enforcement path as StorageOpt["size"] without callers
+	// needing to know the driver-specific option name.
+	if ctr.HostConfig.DiskQuota > 0 {
+		if ctr.HostConfig.StorageOpt == nil {
+			ctr.HostConfig.StorageOpt = make(map[string]string)
+		}
+		if _, ok := ctr.HostConfig.StorageOpt["size"]; !ok {
+			ctr.HostConfig.StorageOpt["size"] = strconv.FormatInt(ctr.HostConfig.DiskQuota, 10)
+		}
+	}
+
 	// Fixes: https://github.com/moby/moby/issues/34074 and
 	// https://github.com/docker/for-win/issues/999.
 	// Merge the daemon's storage options if they aren't already present. We only
@@ -188,13 +323,13 @@ func (daemon *Daemon) create(opts createOpts) (retC *container.Container, retErr
 	}
 
 	// Set RWLayer for container after mount labels have been set
-	rwLayer, err := daemon.imageService.CreateLayer(ctr, setupInitLayer(daemon.idMapping))
+	rwLayer, err := daemon.imageService.CreateLayer(ctr, setupInitLayer(daemon.containerIDMapping(ctr)))
 	if err != nil {
 		return nil, errdefs.System(err)
 	}
 	ctr.RWLayer = rwLayer
 
-	rootIDs := daemon.idMapping.RootPair()
+	rootIDs := daemon.containerIDMapping(ctr).RootPair()
 
 	if err := idtools.MkdirAndChown(ctr.Root, 0700, rootIDs); err != nil {
 		return nil, err
@@ -32,11 +32,19 @@ func (daemon *Daemon) createContainerOSSpecificSettings(container *container.Con
 
 	// Set the default masked and readonly paths with regard to the host config options if they are not set.
 	if hostConfig.MaskedPaths == nil && !hostConfig.Privileged {
-		hostConfig.MaskedPaths = oci.DefaultSpec().Linux.MaskedPaths // Set it to the default if nil
+		defaultPaths := daemon.configStore.MaskedPaths
+		if defaultPaths == nil {
+			defaultPaths = oci.DefaultSpec().Linux.MaskedPaths // Set it to the default if nil
+		}
+		hostConfig.MaskedPaths = applyPathOverrides(defaultPaths, hostConfig.MaskedPathsAdd, hostConfig.MaskedPathsDrop)
 		container.HostConfig.MaskedPaths = hostConfig.MaskedPaths
 	}
 	if hostConfig.ReadonlyPaths == nil && !hostConfig.Privileged {
-		hostConfig.ReadonlyPaths = oci.DefaultSpec().Linux.ReadonlyPaths // Set it to the default if nil
+		defaultPaths := daemon.configStore.ReadonlyPaths
+		if defaultPaths == nil {
+			defaultPaths = oci.DefaultSpec().Linux.ReadonlyPaths // Set it to the default if nil
+		}
+		hostConfig.ReadonlyPaths = applyPathOverrides(defaultPaths, hostConfig.ReadonlyPathsAdd, hostConfig.ReadonlyPathsDrop)
 		container.HostConfig.ReadonlyPaths = hostConfig.ReadonlyPaths
 	}
 
@@ -75,6 +83,37 @@ func (daemon *Daemon) createContainerOSSpecificSettings(container *container.Con
 	return daemon.populateVolumes(container)
 }
 
+// applyPathOverrides returns base with add appended (skipping entries already
+// present) and any entry in drop removed, preserving the order of base.
+func applyPathOverrides(base, add, drop []string) []string {
+	if len(add) == 0 && len(drop) == 0 {
+		return base
+	}
+
+	dropped := make(map[string]bool, len(drop))
+	for _, p := range drop {
+		dropped[p] = true
+	}
+
+	paths := make([]string, 0, len(base)+len(add))
+	seen := make(map[string]bool, len(base)+len(add))
+	for _, p := range base {
+		if dropped[p] || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	for _, p := range add {
+		if dropped[p] || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	return paths
+}
+
 // populateVolumes copies data from the container's rootfs into the volume for non-binds.
 // this is only called when the container is created.
 func (daemon *Daemon) populateVolumes(c *container.Container) error {
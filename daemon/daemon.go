@@ -15,11 +15,15 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/docker/docker/pkg/bootprofile"
 	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/hooks"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 
@@ -33,11 +37,13 @@ import (
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/artifacts"
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/daemon/discovery"
 	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/images"
+	"github.com/docker/docker/daemon/imagescan"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/errdefs"
@@ -48,6 +54,8 @@ import (
 	// register graph drivers
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/stats"
+	"github.com/docker/docker/daemon/trustpolicy"
+	"github.com/docker/docker/distribution"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
@@ -55,6 +63,7 @@ import (
 	"github.com/docker/docker/libcontainerd"
 	libcontainerdtypes "github.com/docker/docker/libcontainerd/types"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/lockdebug"
 	"github.com/docker/docker/pkg/plugingetter"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/truncindex"
@@ -89,9 +98,11 @@ type Daemon struct {
 	containersReplica container.ViewDB
 	execCommands      *exec.Store
 	imageService      *images.ImageService
+	artifactStore     *artifacts.Store
 	idIndex           *truncindex.TruncIndex
 	configStore       *config.Config
 	statsCollector    *stats.Collector
+	statsHistory      *stats.History
 	defaultLogConfig  containertypes.LogConfig
 	RegistryService   registry.Service
 	EventsService     *events.Events
@@ -116,6 +127,7 @@ type Daemon struct {
 	cluster               Cluster
 	genericResources      []swarm.GenericResource
 	metricsPluginListener net.Listener
+	hooksPlugins          []hooks.Plugin
 
 	machineMemory uint64
 
@@ -129,6 +141,10 @@ type Daemon struct {
 
 	attachmentStore       network.AttachmentStore
 	attachableNetworkLock *locker.Locker
+
+	provisioningStatus *types.ProvisioningStatus
+
+	deviceHotplugStop chan struct{}
 }
 
 // StoreHosts stores the addresses the daemon is listening on
@@ -340,12 +356,14 @@ func (daemon *Daemon) restore() error {
 					logrus.WithError(err).Errorf("Failed to delete container %s from containerd", c.ID)
 					return
 				}
-			} else if !daemon.configStore.LiveRestoreEnabled {
+			} else if !daemon.configStore.LiveRestoreEnabled && daemon.configStore.ContainerdRestorePolicy != config.ContainerdRestorePolicyAdopt {
 				if err := daemon.shutdownContainer(c); err != nil && !errdefs.IsNotFound(err) {
 					logrus.WithError(err).WithField("container", c.ID).Error("error shutting down container")
 					return
 				}
 				c.ResetRestartManager(false)
+			} else if !daemon.configStore.LiveRestoreEnabled {
+				logrus.WithField("container", c.ID).Info("adopting container still running in containerd after a non-live-restore startup, per containerd-restore-policy=adopt")
 			}
 
 			if c.IsRunning() || c.IsPaused() {
@@ -458,7 +476,9 @@ func (daemon *Daemon) restore() error {
 	}
 	group.Wait()
 
+	networkInitDone := bootprofile.Track("network-init")
 	daemon.netController, err = daemon.initNetworkController(daemon.configStore, activeSandboxes)
+	networkInitDone()
 	if err != nil {
 		return fmt.Errorf("Error initializing network controller: %v", err)
 	}
@@ -502,9 +522,11 @@ func (daemon *Daemon) restore() error {
 
 			// Make sure networks are available before starting
 			daemon.waitForNetworks(c)
-			if err := daemon.containerStart(c, "", "", true); err != nil {
+			restartDone := bootprofile.Track("restart:" + c.ID)
+			if err := daemon.containerStart(context.Background(), c, "", "", true); err != nil {
 				logrus.Errorf("Failed to start container %s: %s", c.ID, err)
 			}
+			restartDone()
 			close(chNotify)
 
 			sem.Release(1)
@@ -593,7 +615,7 @@ func (daemon *Daemon) RestartSwarmContainers() {
 						return
 					}
 
-					if err := daemon.containerStart(c, "", "", true); err != nil {
+					if err := daemon.containerStart(context.Background(), c, "", "", true); err != nil {
 						logrus.Error(err)
 					}
 
@@ -784,9 +806,10 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	}
 
 	d := &Daemon{
-		configStore: config,
-		PluginStore: pluginStore,
-		startupDone: make(chan struct{}),
+		configStore:  config,
+		PluginStore:  pluginStore,
+		startupDone:  make(chan struct{}),
+		hooksPlugins: hooks.NewPlugins(pluginStore, config.ContainerHooksPlugins),
 	}
 
 	// Ensure the daemon is properly shutdown if there is a failure during
@@ -940,6 +963,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	}
 
 	// Plugin system initialization should happen before restore. Do not change order.
+	pluginInitDone := bootprofile.Track("plugin-init")
 	d.pluginManager, err = plugin.NewManager(plugin.ManagerConfig{
 		Root:               filepath.Join(config.Root, "plugins"),
 		ExecRoot:           getPluginExecRoot(config.Root),
@@ -950,6 +974,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		LogPluginEvent:     d.LogPluginEvent, // todo: make private
 		AuthzMiddleware:    config.AuthzMiddleware,
 	})
+	pluginInitDone()
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create plugin manager")
 	}
@@ -1035,6 +1060,21 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		return nil, err
 	}
 
+	d.artifactStore, err = artifacts.NewStore(filepath.Join(config.Root, "artifacts"))
+	if err != nil {
+		return nil, err
+	}
+
+	trustPolicy, err := trustpolicy.NewEngine(config.TrustPolicyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load trust policy file")
+	}
+
+	var vulnerabilityScan *imagescan.Engine
+	if config.VulnerabilityScanCommand != "" {
+		vulnerabilityScan = imagescan.NewEngine(imagescan.NewExecScanner(config.VulnerabilityScanCommand), imagescan.Severity(config.VulnerabilityBlockSeverity))
+	}
+
 	// Discovery is only enabled when the daemon is launched with an address to advertise.  When
 	// initialized, the daemon is registered and we can store the discovery backend as it's read-only
 	if err := d.initDiscovery(config); err != nil {
@@ -1058,8 +1098,28 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
 
+	statsHistoryInterval := time.Duration(config.StatsHistoryInterval) * time.Second
+	if statsHistoryInterval <= 0 {
+		statsHistoryInterval = 10 * time.Second
+	}
+	statsHistoryMaxSamples := config.StatsHistoryMaxSamples
+	if statsHistoryMaxSamples <= 0 {
+		statsHistoryMaxSamples = 360
+	}
+	d.statsHistory = stats.NewHistory(statsHistoryInterval, statsHistoryMaxSamples)
+	go d.statsHistoryCollector()
+
+	if config.LockWatchdogEnabled {
+		lockWatchdogThreshold := time.Duration(config.LockWatchdogThreshold) * time.Second
+		if lockWatchdogThreshold <= 0 {
+			lockWatchdogThreshold = 30 * time.Second
+		}
+		lockdebug.StartWatchdog(5*time.Second, lockWatchdogThreshold)
+	}
+
 	d.EventsService = events.New()
 	d.root = config.Root
+	d.pruneExpiredRetainedBundles()
 	d.idMapping = idMapping
 	d.seccompEnabled = sysInfo.Seccomp
 	d.apparmorEnabled = sysInfo.AppArmor
@@ -1081,8 +1141,39 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		ReferenceStore:            rs,
 		RegistryService:           registryService,
 		TrustKey:                  trustKey,
+		AllowLazyPull:             config.AllowLazyPull,
+		TrustPolicy:               trustPolicy,
+		VulnerabilityScan:         vulnerabilityScan,
 	})
 
+	if config.P2PDistributionCommand != "" {
+		distribution.SetP2PFetcher(distribution.NewExecP2PFetcher(config.P2PDistributionCommand))
+	}
+
+	if len(config.CredentialHelpers) > 0 {
+		registry.SetCredentialHelpers(config.CredentialHelpers)
+	}
+
+	go d.imagePinReverifyLoop()
+
+	for ref, intervalStr := range config.PrePullImages {
+		interval, err := time.ParseDuration(intervalStr)
+		if err != nil {
+			logrus.WithError(err).WithField("image", ref).Warn("invalid pre-pull-images interval, skipping")
+			continue
+		}
+		if _, err := d.imageService.AddPrePullImage(ref, interval); err != nil {
+			logrus.WithError(err).WithField("image", ref).Warn("failed to register pre-pull image")
+		}
+	}
+	go d.prePullLoop()
+
+	if config.StorageDriverHealthCheckFailFast {
+		if err := d.checkDriverHealth(); err != nil {
+			return nil, err
+		}
+	}
+
 	go d.execCommandGC()
 
 	d.containerd, err = libcontainerd.NewClient(ctx, d.containerdCli, filepath.Join(config.ExecRoot, "containerd"), config.ContainerdNamespace, d)
@@ -1090,9 +1181,11 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		return nil, err
 	}
 
+	restoreDone := bootprofile.Track("restore")
 	if err := d.restore(); err != nil {
 		return nil, err
 	}
+	restoreDone()
 	close(d.startupDone)
 
 	info := d.SystemInfo()
@@ -1127,6 +1220,12 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		"graphdriver(s)": gd,
 	}).Info("Docker daemon")
 
+	go d.runProvisioning()
+
+	d.startDeviceHotplugMonitor()
+
+	networkTrafficCtr.setDaemon(d)
+
 	return d, nil
 }
 
@@ -1153,6 +1252,93 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 	return nil
 }
 
+// shutdownContainers stops all running containers as part of daemon
+// shutdown, honoring the configured shutdown parallelism and, if
+// ShutdownPriorityLabel is set, stopping containers in descending order of
+// that label's value: all containers of a given priority are fully stopped
+// before any container of a lower priority is signaled.
+func (daemon *Daemon) shutdownContainers() {
+	for _, group := range daemon.shutdownPriorityGroups() {
+		daemon.shutdownContainerGroup(group)
+	}
+}
+
+// shutdownPriorityGroups buckets the running containers by the integer value
+// of the ShutdownPriorityLabel label (0 for containers missing the label or
+// with a non-integer value), and returns the buckets ordered from highest to
+// lowest priority. With no label configured, all running containers are
+// returned as a single group.
+func (daemon *Daemon) shutdownPriorityGroups() [][]*container.Container {
+	label := daemon.configStore.ShutdownPriorityLabel
+	running := make([]*container.Container, 0)
+	for _, c := range daemon.containers.List() {
+		if c.IsRunning() {
+			running = append(running, c)
+		}
+	}
+
+	if label == "" {
+		return [][]*container.Container{running}
+	}
+
+	byPriority := make(map[int][]*container.Container)
+	for _, c := range running {
+		priority := 0
+		if v, ok := c.Config.Labels[label]; ok {
+			if p, err := strconv.Atoi(v); err == nil {
+				priority = p
+			}
+		}
+		byPriority[priority] = append(byPriority[priority], c)
+	}
+
+	priorities := make([]int, 0, len(byPriority))
+	for p := range byPriority {
+		priorities = append(priorities, p)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+
+	groups := make([][]*container.Container, 0, len(priorities))
+	for _, p := range priorities {
+		groups = append(groups, byPriority[p])
+	}
+	return groups
+}
+
+// shutdownContainerGroup stops the given containers, honoring
+// ShutdownParallelism, and waits for all of them to stop before returning.
+func (daemon *Daemon) shutdownContainerGroup(group []*container.Container) {
+	parallelism := daemon.configStore.ShutdownParallelism
+	if parallelism <= 0 || parallelism > len(group) {
+		parallelism = len(group)
+	}
+	if parallelism == 0 {
+		return
+	}
+
+	sem := semaphore.NewWeighted(int64(parallelism))
+	var wg sync.WaitGroup
+	for _, c := range group {
+		wg.Add(1)
+		go func(c *container.Container) {
+			defer wg.Done()
+			_ = sem.Acquire(context.Background(), 1)
+			defer sem.Release(1)
+
+			logrus.Debugf("stopping %s", c.ID)
+			if err := daemon.shutdownContainer(c); err != nil {
+				logrus.Errorf("Stop container error: %v", err)
+				return
+			}
+			if mountid, err := daemon.imageService.GetLayerMountID(c.ID, c.OS); err == nil {
+				daemon.cleanupMountsByID(mountid)
+			}
+			logrus.Debugf("container stopped %s", c.ID)
+		}(c)
+	}
+	wg.Wait()
+}
+
 // ShutdownTimeout returns the timeout (in seconds) before containers are forcibly
 // killed during shutdown. The default timeout can be configured both on the daemon
 // and per container, and the longest timeout will be used. A grace-period of
@@ -1185,6 +1371,7 @@ func (daemon *Daemon) ShutdownTimeout() int {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	daemon.stopDeviceHotplugMonitor()
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -1200,20 +1387,7 @@ func (daemon *Daemon) Shutdown() error {
 	if daemon.containers != nil {
 		logrus.Debugf("daemon configured with a %d seconds minimum shutdown timeout", daemon.configStore.ShutdownTimeout)
 		logrus.Debugf("start clean shutdown of all containers with a %d seconds timeout...", daemon.ShutdownTimeout())
-		daemon.containers.ApplyAll(func(c *container.Container) {
-			if !c.IsRunning() {
-				return
-			}
-			logrus.Debugf("stopping %s", c.ID)
-			if err := daemon.shutdownContainer(c); err != nil {
-				logrus.Errorf("Stop container error: %v", err)
-				return
-			}
-			if mountid, err := daemon.imageService.GetLayerMountID(c.ID, c.OS); err == nil {
-				daemon.cleanupMountsByID(mountid)
-			}
-			logrus.Debugf("container stopped %s", c.ID)
-		})
+		daemon.shutdownContainers()
 	}
 
 	if daemon.volumes != nil {
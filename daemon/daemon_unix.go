@@ -1,3 +1,4 @@
+//go:build linux || freebsd
 // +build linux freebsd
 
 package daemon // import "github.com/docker/docker/daemon"
@@ -27,6 +28,8 @@ import (
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/daemon/initlayer"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/image"
+	libcontainerdtypes "github.com/docker/docker/libcontainerd/types"
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/containerfs"
 	"github.com/docker/docker/pkg/idtools"
@@ -220,6 +223,10 @@ func parseSecurityOpt(container *container.Container, config *containertypes.Hos
 			labelOpts = append(labelOpts, "disable")
 			continue
 		}
+		if opt == "generate-profile" {
+			container.GenerateProfile = true
+			continue
+		}
 
 		var con []string
 		if strings.Contains(opt, "=") {
@@ -393,8 +400,8 @@ func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConf
 }
 
 // adaptSharedNamespaceContainer replaces container name with its ID in hostConfig.
-// To be more precisely, it modifies `container:name` to `container:ID` of PidMode, IpcMode
-// and NetworkMode.
+// To be more precisely, it modifies `container:name` to `container:ID` of PidMode, IpcMode,
+// NetworkMode and UTSMode.
 //
 // When a container shares its namespace with another container, use ID can keep the namespace
 // sharing connection between the two containers even the another container is renamed.
@@ -420,6 +427,12 @@ func adaptSharedNamespaceContainer(daemon containerGetter, hostConfig *container
 			hostConfig.NetworkMode = containertypes.NetworkMode(containerPrefix + c.ID)
 		}
 	}
+	if hostConfig.UTSMode.IsContainer() {
+		utsContainer := hostConfig.UTSMode.Container()
+		if c, err := daemon.GetContainer(utsContainer); err == nil {
+			hostConfig.UTSMode = containertypes.UTSMode(containerPrefix + c.ID)
+		}
+	}
 }
 
 // verifyPlatformContainerResources performs platform-specific validation of the container's resource-configuration
@@ -594,6 +607,14 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, sysIn
 		resources.BlkioDeviceWriteIOps = []*pblkiodev.ThrottleDevice{}
 	}
 
+	// network bandwidth shaping checks
+	if resources.NetworkEgressRate < 0 {
+		return warnings, fmt.Errorf("invalid egress rate limit: %d", resources.NetworkEgressRate)
+	}
+	if resources.NetworkIngressRate < 0 {
+		return warnings, fmt.Errorf("invalid ingress rate limit: %d", resources.NetworkIngressRate)
+	}
+
 	return warnings, nil
 }
 
@@ -652,9 +673,60 @@ func IsRunningSystemd() bool {
 	return fi.IsDir()
 }
 
+// applyDefaultPolicyBundles merges sysctls, ulimits, and a default seccomp
+// profile from daemon-configured policy bundles (see
+// config.Config.DefaultPolicyBundles) into hostConfig, for every bundle
+// whose label selector matches labels. A value already set on hostConfig is
+// never overridden, and when multiple bundles match, the first bundle in
+// configuration order to set a given key wins.
+func (daemon *Daemon) applyDefaultPolicyBundles(labels map[string]string, hostConfig *containertypes.HostConfig) {
+	for _, bundle := range daemon.configStore.DefaultPolicyBundles {
+		if !bundle.Matches(labels) {
+			continue
+		}
+
+		for k, v := range bundle.Sysctls {
+			if hostConfig.Sysctls == nil {
+				hostConfig.Sysctls = make(map[string]string)
+			}
+			if _, exists := hostConfig.Sysctls[k]; !exists {
+				hostConfig.Sysctls[k] = v
+			}
+		}
+
+	nextUlimit:
+		for _, ul := range bundle.Ulimits {
+			for _, existing := range hostConfig.Ulimits {
+				if existing.Name == ul.Name {
+					continue nextUlimit
+				}
+			}
+			hostConfig.Ulimits = append(hostConfig.Ulimits, ul)
+		}
+
+		if bundle.SeccompProfile != "" && !hasSecurityOpt(hostConfig.SecurityOpt, "seccomp") {
+			hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+bundle.SeccompProfile)
+		}
+	}
+}
+
+// hasSecurityOpt reports whether opts already contains a "kind=..." or
+// legacy "kind:..." entry.
+func hasSecurityOpt(opts []string, kind string) bool {
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, kind+"=") || strings.HasPrefix(opt, kind+":") {
+			return true
+		}
+	}
+	return false
+}
+
 // verifyPlatformContainerSettings performs platform-specific validation of the
-// hostconfig and config structures.
-func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.HostConfig, update bool) (warnings []string, err error) {
+// hostconfig and config structures. img is the image the container is being
+// created from, or nil if none is known (e.g. when re-validating an existing
+// container on start or update); it is only consulted to resolve
+// image.RuntimeLabel when hostConfig.Runtime is not already set.
+func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.HostConfig, img *image.Image, update bool) (warnings []string, err error) {
 	if hostConfig == nil {
 		return nil, nil
 	}
@@ -673,6 +745,18 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("SHM size can not be less than 0")
 	}
 
+	if !hostConfig.TimeMode.Valid() {
+		return warnings, fmt.Errorf("invalid time namespace mode: %v", hostConfig.TimeMode)
+	}
+	if hostConfig.TimeMode.IsHost() {
+		// The OCI runtime-spec version vendored in this build predates the
+		// "time" namespace type, so there's no config.json field to ask
+		// the runtime to join (or not join) it. Rather than silently
+		// ignoring the request, fail clearly until runtime-spec support
+		// lands.
+		return warnings, fmt.Errorf("time namespace sharing is not supported by this build's OCI runtime-spec")
+	}
+
 	if hostConfig.OomScoreAdj < -1000 || hostConfig.OomScoreAdj > 1000 {
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
@@ -685,6 +769,18 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		warnings = append(warnings, "Published ports are discarded when using host network mode")
 	}
 
+	if len(hostConfig.UIDMappings) > 0 || len(hostConfig.GIDMappings) > 0 {
+		if hostConfig.UsernsMode.IsHost() {
+			return warnings, fmt.Errorf("uid/gid mappings require a private user namespace; got --userns=%s", hostConfig.UsernsMode)
+		}
+		if len(hostConfig.UIDMappings) == 0 || len(hostConfig.GIDMappings) == 0 {
+			return warnings, fmt.Errorf("uid and gid mappings must both be specified together")
+		}
+		if hostConfig.Privileged {
+			return warnings, fmt.Errorf("privileged mode is incompatible with user namespaces")
+		}
+	}
+
 	// check for various conflicting options with user namespaces
 	if daemon.configStore.RemappedRoot != "" && hostConfig.UsernsMode.IsPrivate() {
 		if hostConfig.Privileged {
@@ -704,12 +800,23 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		}
 	}
 	if hostConfig.Runtime == "" {
-		hostConfig.Runtime = daemon.configStore.GetDefaultRuntimeName()
+		if requested := img.RequestedRuntime(); requested != "" && daemon.configStore.IsImageRuntimeTrusted(requested) {
+			hostConfig.Runtime = requested
+		} else {
+			hostConfig.Runtime = daemon.configStore.GetDefaultRuntimeName()
+		}
 	}
 
-	if rt := daemon.configStore.GetRuntime(hostConfig.Runtime); rt == nil {
+	rt := daemon.configStore.GetRuntime(hostConfig.Runtime)
+	if rt == nil {
 		return warnings, fmt.Errorf("Unknown runtime specified %s", hostConfig.Runtime)
 	}
+	if rt.Sandboxed {
+		if hostConfig.IpcMode.IsHost() {
+			return warnings, fmt.Errorf("IpcMode %q is not supported by the VM-isolated runtime %q", hostConfig.IpcMode, hostConfig.Runtime)
+		}
+		hostConfig.Isolation = containertypes.IsolationSandbox
+	}
 
 	parser := volumemounts.NewParser(runtime.GOOS)
 	for dest := range hostConfig.Tmpfs {
@@ -1068,15 +1175,16 @@ func setupInitLayer(idMapping *idtools.IdentityMapping) func(containerfs.Contain
 }
 
 // Parse the remapped root (user namespace) option, which can be one of:
-//   username            - valid username from /etc/passwd
-//   username:groupname  - valid username; valid groupname from /etc/group
-//   uid                 - 32-bit unsigned int valid Linux UID value
-//   uid:gid             - uid value; 32-bit unsigned int Linux GID value
 //
-//  If no groupname is specified, and a username is specified, an attempt
-//  will be made to lookup a gid for that username as a groupname
+//	 username            - valid username from /etc/passwd
+//	 username:groupname  - valid username; valid groupname from /etc/group
+//	 uid                 - 32-bit unsigned int valid Linux UID value
+//	 uid:gid             - uid value; 32-bit unsigned int Linux GID value
 //
-//  If names are used, they are verified to exist in passwd/group
+//	If no groupname is specified, and a username is specified, an attempt
+//	will be made to lookup a gid for that username as a groupname
+//
+//	If names are used, they are verified to exist in passwd/group
 func parseRemappedRoot(usergrp string) (string, string, error) {
 
 	var (
@@ -1379,6 +1487,44 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 		}
 		return nil, err
 	}
+	return daemon.statsJSONFromMetrics(cs)
+}
+
+// statsBatch collects stats for every given container with a single call to
+// containerd's task metrics API, instead of the one-Stats-call-per-container
+// cost that GetContainerStats would otherwise incur every collection tick.
+// Containers with no running task, or whose task isn't in the batch yet
+// (e.g. just started), are simply absent from the result; the caller falls
+// back to stats/GetContainerStats for those.
+func (daemon *Daemon) statsBatch(containers []*container.Container) (map[string]*types.StatsJSON, error) {
+	all, err := daemon.containerd.AllStats(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*types.StatsJSON, len(containers))
+	for _, c := range containers {
+		cs, ok := all[c.ID]
+		if !ok {
+			continue
+		}
+		s, err := daemon.statsJSONFromMetrics(cs)
+		if err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Warn("converting batched container stats")
+			continue
+		}
+		if !c.Config.NetworkDisabled {
+			if s.Networks, err = daemon.getNetworkStats(c); err != nil {
+				logrus.WithError(err).WithField("container", c.ID).Warn("collecting network stats for batched container stats")
+				continue
+			}
+		}
+		out[c.ID] = s
+	}
+	return out, nil
+}
+
+func (daemon *Daemon) statsJSONFromMetrics(cs *libcontainerdtypes.Stats) (*types.StatsJSON, error) {
 	s := &types.StatsJSON{}
 	s.Read = cs.Read
 	stats := cs.Metrics
@@ -14,6 +14,8 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/image"
+	libcontainerdtypes "github.com/docker/docker/libcontainerd/types"
 	"github.com/docker/docker/pkg/containerfs"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/parsers"
@@ -192,12 +194,28 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, isHyp
 	return warnings, nil
 }
 
+// applyDefaultPolicyBundles is a no-op on Windows: daemon-configured policy
+// bundles (sysctls, ulimits, a default seccomp profile) have no Windows
+// equivalent.
+func (daemon *Daemon) applyDefaultPolicyBundles(labels map[string]string, hostConfig *containertypes.HostConfig) {
+}
+
 // verifyPlatformContainerSettings performs platform-specific validation of the
-// hostconfig and config structures.
-func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.HostConfig, update bool) (warnings []string, err error) {
+// hostconfig and config structures. img is accepted for signature parity
+// with the Unix implementation but is unused: Windows has no equivalent of
+// image.RuntimeLabel-driven low-level runtime selection.
+func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.HostConfig, img *image.Image, update bool) (warnings []string, err error) {
 	if hostConfig == nil {
 		return nil, nil
 	}
+
+	if hostConfig.HostProcess {
+		// Host-process containers require the container runtime to generate an
+		// OCI spec with Windows.HostProcess set, which the vendored
+		// opencontainers/runtime-spec in this build does not support.
+		return warnings, fmt.Errorf("host-process containers are not supported by this build of the daemon")
+	}
+
 	hyperv := daemon.runAsHyperVContainer(hostConfig)
 
 	// On RS5, we allow (but don't strictly support) process isolation on Client SKUs.
@@ -544,6 +562,35 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 		return nil, err
 	}
 
+	return statsJSONFromHCSStats(stats)
+}
+
+// statsBatch collects stats for every given container with a single call to
+// containerd rather than one Stats call per container. Containers with no
+// running task, or whose task metrics aren't in the batch yet, are simply
+// absent from the result; the caller falls back to stats for those.
+func (daemon *Daemon) statsBatch(containers []*container.Container) (map[string]*types.StatsJSON, error) {
+	all, err := daemon.containerd.AllStats(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*types.StatsJSON, len(containers))
+	for _, c := range containers {
+		cs, ok := all[c.ID]
+		if !ok {
+			continue
+		}
+		s, err := statsJSONFromHCSStats(cs)
+		if err != nil {
+			continue
+		}
+		out[c.ID] = s
+	}
+	return out, nil
+}
+
+func statsJSONFromHCSStats(stats *libcontainerdtypes.Stats) (*types.StatsJSON, error) {
 	// Start with an empty structure
 	s := &types.StatsJSON{}
 	s.Stats.Read = stats.Read
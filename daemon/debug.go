@@ -0,0 +1,72 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerDebug creates and starts a sidecar container from config.Image,
+// sharing the requested namespaces of the target container, without
+// modifying the target container itself. It returns the ID of the new
+// sidecar container, which the caller attaches to and removes the same way
+// as any other container.
+func (daemon *Daemon) ContainerDebug(ctx context.Context, name string, config *types.ContainerDebugConfig) (string, error) {
+	target, err := daemon.GetContainer(name)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := config.Cmd
+	if len(cmd) == 0 {
+		cmd = []string{"/bin/sh"}
+	}
+
+	hostConfig := &containertypes.HostConfig{
+		AutoRemove: true,
+	}
+	if config.ShareNamespaceNet {
+		hostConfig.NetworkMode = containertypes.NetworkMode("container:" + target.ID)
+	}
+	if config.ShareNamespacePID {
+		hostConfig.PidMode = containertypes.PidMode("container:" + target.ID)
+	}
+	if config.ShareNamespaceMount {
+		if target.BaseFS == nil {
+			return "", errdefs.System(errors.New("debug: target container has no filesystem to share"))
+		}
+		hostConfig.Binds = []string{target.BaseFS.Path() + ":/target:ro"}
+	}
+
+	body, err := daemon.ContainerCreate(types.ContainerCreateConfig{
+		Name: fmt.Sprintf("debug-%s", target.ID[:12]),
+		Config: &containertypes.Config{
+			Image:        config.Image,
+			Cmd:          cmd,
+			Tty:          true,
+			OpenStdin:    true,
+			StdinOnce:    true,
+			AttachStdin:  true,
+			AttachStdout: true,
+			AttachStderr: true,
+		},
+		HostConfig: hostConfig,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "debug: failed to create sidecar container")
+	}
+
+	if err := daemon.ContainerStart(ctx, body.ID, nil, "", ""); err != nil {
+		if rmErr := daemon.ContainerRm(body.ID, &types.ContainerRmConfig{ForceRemove: true}); rmErr != nil {
+			logrus.Errorf("debug: failed to clean up sidecar container %s after failed start: %v", body.ID, rmErr)
+		}
+		return "", errors.Wrap(err, "debug: failed to start sidecar container")
+	}
+
+	return body.ID, nil
+}
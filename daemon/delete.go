@@ -98,6 +98,7 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 	// stop collection of stats for the container regardless
 	// if stats are currently getting collected.
 	daemon.statsCollector.StopCollection(container)
+	daemon.statsHistory.Remove(container.ID)
 
 	if err = daemon.containerStop(container, 3); err != nil {
 		return err
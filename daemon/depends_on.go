@@ -0,0 +1,48 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/pkg/errors"
+)
+
+// dependsOnTimeout bounds how long containerStart waits for a
+// container's HostConfig.DependsOn entries to become ready.
+const dependsOnTimeout = 60 * time.Second
+
+// waitForDependencies blocks until every container named in
+// ctr.HostConfig.DependsOn is running, and healthy if it has a
+// healthcheck configured, or until dependsOnTimeout elapses.
+func (daemon *Daemon) waitForDependencies(ctr *container.Container) error {
+	if len(ctr.HostConfig.DependsOn) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dependsOnTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for _, name := range ctr.HostConfig.DependsOn {
+		for {
+			dep, err := daemon.GetContainer(name)
+			if err != nil {
+				return errors.Wrapf(err, "resolving dependency %q of container %s", name, ctr.ID)
+			}
+			if dep.IsRunning() && (dep.State.Health == nil || dep.State.Health.Status() == types.Healthy) {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return errors.Errorf("timed out waiting for dependency %q of container %s to become ready", name, ctr.ID)
+			case <-ticker.C:
+			}
+		}
+	}
+	return nil
+}
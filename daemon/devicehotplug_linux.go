@@ -0,0 +1,240 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	containerpkg "github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/udev"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// startDeviceHotplugMonitor watches the kernel's uevent broadcast for
+// devices appearing or disappearing, and creates or removes the matching
+// device node (and device cgroup rule) in any running container whose
+// HostConfig.Resources.DeviceHotplugRules allow it.
+//
+// Only "add" and "remove" actions for devices that end up with a /dev
+// node are handled; udev's transient "change"/"bind"/"unbind" actions and
+// device classes with no node (most network interfaces) are ignored.
+func (daemon *Daemon) startDeviceHotplugMonitor() {
+	mon, err := udev.NewMonitor()
+	if err != nil {
+		logrus.WithError(err).Warn("device hotplug monitor disabled: could not open uevent socket")
+		return
+	}
+
+	daemon.deviceHotplugStop = make(chan struct{})
+	stop := daemon.deviceHotplugStop
+
+	go func() {
+		<-stop
+		mon.Close()
+	}()
+
+	go func() {
+		for {
+			ev, err := mon.Read()
+			if err != nil {
+				select {
+				case <-stop:
+					return
+				default:
+					logrus.WithError(err).Warn("device hotplug monitor: error reading uevent, stopping")
+					return
+				}
+			}
+
+			devNode := ev.DevNode()
+			if devNode == "" || (ev.Action != "add" && ev.Action != "remove") {
+				continue
+			}
+
+			daemon.handleDeviceHotplugEvent(ev.Action, devNode)
+		}
+	}()
+}
+
+// stopDeviceHotplugMonitor stops the goroutine started by
+// startDeviceHotplugMonitor, if one is running.
+func (daemon *Daemon) stopDeviceHotplugMonitor() {
+	if daemon.deviceHotplugStop != nil {
+		close(daemon.deviceHotplugStop)
+		daemon.deviceHotplugStop = nil
+	}
+}
+
+// handleDeviceHotplugEvent matches a single uevent against every running
+// container's hotplug allow rules and attaches or detaches the device in
+// each that matches.
+func (daemon *Daemon) handleDeviceHotplugEvent(action, devNode string) {
+	for _, ctr := range daemon.List() {
+		if !ctr.IsRunning() {
+			continue
+		}
+		for _, rule := range ctr.HostConfig.Resources.DeviceHotplugRules {
+			matched, err := filepath.Match(rule.PathOnHost, devNode)
+			if err != nil || !matched {
+				continue
+			}
+
+			target := rule.PathInContainer
+			if target == "" {
+				target = devNode
+			}
+
+			switch action {
+			case "add":
+				if err := daemon.attachHotplugDevice(ctr, devNode, target, rule.CgroupPermissions); err != nil {
+					logrus.WithError(err).Warnf("failed to attach hotplugged device %s to container %s", devNode, ctr.ID)
+					continue
+				}
+				daemon.LogContainerEventWithAttributes(ctr, "device-attach", map[string]string{"device": devNode})
+			case "remove":
+				if err := daemon.detachHotplugDevice(ctr, target); err != nil {
+					logrus.WithError(err).Warnf("failed to detach hotplugged device %s from container %s", devNode, ctr.ID)
+					continue
+				}
+				daemon.LogContainerEventWithAttributes(ctr, "device-detach", map[string]string{"device": devNode})
+			}
+		}
+	}
+}
+
+// attachHotplugDevice creates a device node at target inside ctr's mount
+// namespace, mirroring the type and numbers of hostPath, and allows it in
+// the container's device cgroup.
+func (daemon *Daemon) attachHotplugDevice(ctr *containerpkg.Container, hostPath, target, cgroupPermissions string) error {
+	var st unix.Stat_t
+	if err := unix.Stat(hostPath, &st); err != nil {
+		return fmt.Errorf("stat %s: %v", hostPath, err)
+	}
+
+	var fileType uint32
+	switch st.Mode & unix.S_IFMT {
+	case unix.S_IFCHR:
+		fileType = unix.S_IFCHR
+	case unix.S_IFBLK:
+		fileType = unix.S_IFBLK
+	default:
+		return fmt.Errorf("%s is not a device node", hostPath)
+	}
+
+	major := unix.Major(st.Rdev)
+	minor := unix.Minor(st.Rdev)
+
+	if err := mknodInNamespace(ctr.State.Pid, target, fileType|(st.Mode&0777), int(unix.Mkdev(major, minor))); err != nil {
+		return err
+	}
+
+	if cgroupPermissions == "" {
+		cgroupPermissions = "rwm"
+	}
+	deviceType := "c"
+	if fileType == unix.S_IFBLK {
+		deviceType = "b"
+	}
+	return daemon.updateDeviceCgroup(ctr, specs.LinuxDeviceCgroup{
+		Allow:  true,
+		Type:   deviceType,
+		Major:  int64Ptr(int64(major)),
+		Minor:  int64Ptr(int64(minor)),
+		Access: cgroupPermissions,
+	})
+}
+
+// detachHotplugDevice removes the device node at target from ctr's mount
+// namespace. The device cgroup rule is intentionally left in place: cgroup
+// v1's device whitelist has no notion of "this specific node is gone", and
+// revoking access while other, unrelated devices might share the same
+// major/minor range (e.g. a re-plugged device reusing the number) would be
+// unsafe to infer here.
+func (daemon *Daemon) detachHotplugDevice(ctr *containerpkg.Container, target string) error {
+	return rmInNamespace(ctr.State.Pid, target)
+}
+
+// updateDeviceCgroup pushes a single device cgroup rule to the running
+// container via containerd, on top of its already-configured resources.
+func (daemon *Daemon) updateDeviceCgroup(ctr *containerpkg.Container, rule specs.LinuxDeviceCgroup) error {
+	resources := toContainerdResources(ctr.HostConfig.Resources)
+	resources.Devices = append(resources.Devices, rule)
+	return daemon.containerd.UpdateResources(context.Background(), ctr.ID, resources)
+}
+
+func int64Ptr(v int64) *int64 { return &v }
+
+// mknodInNamespace enters the mount namespace of pid and creates a device
+// node at target with the given mode and device number, then returns to
+// the caller's own mount namespace. It must run on a locked OS thread,
+// since mount namespace membership is per-thread.
+func mknodInNamespace(pid int, target string, mode uint32, dev int) error {
+	if pid <= 0 {
+		return fmt.Errorf("container has no running process to enter")
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("failed to enter mount namespace of pid %d: %v", pid, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNS)
+
+	_ = unix.Unlink(target)
+	if err := unix.Mknod(target, mode, dev); err != nil {
+		return fmt.Errorf("failed to create device node %s: %v", target, err)
+	}
+
+	return nil
+}
+
+// rmInNamespace enters the mount namespace of pid and removes target,
+// then returns to the caller's own mount namespace.
+func rmInNamespace(pid int, target string) error {
+	if pid <= 0 {
+		return fmt.Errorf("container has no running process to enter")
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("failed to enter mount namespace of pid %d: %v", pid, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNS)
+
+	if err := unix.Unlink(target); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove device node %s: %v", target, err)
+	}
+
+	return nil
+}
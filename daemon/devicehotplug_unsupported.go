@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+// startDeviceHotplugMonitor is unsupported outside Linux: there is no
+// uevent netlink broadcast to watch, so HostConfig.Resources.DeviceHotplugRules
+// is silently ignored on this platform rather than erroring daemon startup.
+func (daemon *Daemon) startDeviceHotplugMonitor() {}
+
+// stopDeviceHotplugMonitor is a no-op since startDeviceHotplugMonitor
+// never starts anything on this platform.
+func (daemon *Daemon) stopDeviceHotplugMonitor() {}
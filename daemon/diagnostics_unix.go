@@ -0,0 +1,126 @@
+//go:build !windows
+// +build !windows
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// fdWarningThreshold is the number of open file descriptors a process must
+// have for it to be reported as an FDWarning by ContainerDiagnostics.
+const fdWarningThreshold = 256
+
+// diagZombieEvent is the container event logged when ContainerDiagnostics
+// finds at least one zombie process in the container's pid namespace.
+const diagZombieEvent = "diagnostics-zombies-detected"
+
+// ContainerDiagnostics reports zombie processes and processes with an
+// abnormally high number of open file descriptors within a container's pid
+// namespace. This helps surface images that are missing a proper init
+// process and silently accumulate zombies or leak file descriptors.
+func (daemon *Daemon) ContainerDiagnostics(name string) (*container.ContainerDiagnostics, error) {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctr.IsRunning() {
+		return nil, errNotRunning(ctr.ID)
+	}
+
+	procs, err := daemon.containerd.ListPids(context.Background(), ctr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &container.ContainerDiagnostics{}
+	initPid := ctr.State.Pid
+
+	for _, p := range procs {
+		pid := int(p)
+		stat, err := readProcStat(pid)
+		if err != nil {
+			// The process may have exited between ListPids and our read; skip it.
+			continue
+		}
+
+		if stat.state == "Z" {
+			report.Zombies = append(report.Zombies, container.ZombieProcess{
+				PID:  pid,
+				Comm: stat.comm,
+				PPid: stat.ppid,
+			})
+			if stat.ppid == initPid {
+				report.UnreapedChildren++
+			}
+			continue
+		}
+
+		if n := countOpenFDs(pid); n >= fdWarningThreshold {
+			report.FDWarnings = append(report.FDWarnings, container.FDWarning{
+				PID:     pid,
+				Comm:    stat.comm,
+				OpenFDs: n,
+			})
+		}
+	}
+
+	if len(report.Zombies) > 0 {
+		daemon.LogContainerEvent(ctr, diagZombieEvent)
+	}
+
+	return report, nil
+}
+
+type procStat struct {
+	comm  string
+	state string
+	ppid  int
+}
+
+// readProcStat parses the fields of /proc/<pid>/stat that ContainerDiagnostics
+// needs. The comm field is parenthesized and may itself contain spaces or
+// parens, so it's extracted by its surrounding parens rather than by
+// whitespace-splitting the whole line.
+func readProcStat(pid int) (procStat, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return procStat{}, err
+	}
+	line := string(data)
+
+	open := strings.IndexByte(line, '(')
+	end := strings.LastIndexByte(line, ')')
+	if open < 0 || end < 0 || end < open {
+		return procStat{}, fmt.Errorf("unexpected format for /proc/%d/stat", pid)
+	}
+	comm := line[open+1 : end]
+
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 2 {
+		return procStat{}, fmt.Errorf("unexpected format for /proc/%d/stat", pid)
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return procStat{}, err
+	}
+
+	return procStat{comm: comm, state: fields[0], ppid: ppid}, nil
+}
+
+// countOpenFDs returns the number of open file descriptors for pid, as
+// reflected by the entries under /proc/<pid>/fd.
+func countOpenFDs(pid int) int {
+	entries, err := ioutil.ReadDir(fmt.Sprintf("/proc/%d/fd", pid))
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
@@ -0,0 +1,14 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ContainerDiagnostics is not supported on Windows: zombie processes and
+// /proc-style file descriptor accounting are Linux pid namespace concepts
+// with no Windows equivalent.
+func (daemon *Daemon) ContainerDiagnostics(name string) (*container.ContainerDiagnostics, error) {
+	return nil, errdefs.NotImplemented(errors.New("container diagnostics are not supported on Windows"))
+}
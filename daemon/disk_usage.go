@@ -48,3 +48,11 @@ func (daemon *Daemon) SystemDiskUsage(ctx context.Context) (*types.DiskUsage, er
 		Images:     allImages,
 	}, nil
 }
+
+// SystemDiskUsageDedup reports files whose content is duplicated across
+// layers in the layer store, for `docker system df --dedup`. Unlike
+// SystemDiskUsage, this walks every layer's contents and so is
+// considerably more expensive; it's only done when explicitly requested.
+func (daemon *Daemon) SystemDiskUsageDedup(ctx context.Context) (*types.DedupReport, error) {
+	return daemon.imageService.LayerDedupReport(ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/container/stream"
@@ -121,9 +122,13 @@ func (daemon *Daemon) ContainerExecCreate(name string, config *types.ExecConfig)
 	execConfig.Entrypoint = entrypoint
 	execConfig.Args = args
 	execConfig.Tty = config.Tty
+	execConfig.ConsoleSize = config.ConsoleSize
 	execConfig.Privileged = config.Privileged
 	execConfig.User = config.User
 	execConfig.WorkingDir = config.WorkingDir
+	execConfig.Persistent = config.Persistent
+	execConfig.NanoCPUs = config.NanoCPUs
+	execConfig.Memory = config.Memory
 
 	linkedEnv, err := daemon.setupLinkedContainers(cntr)
 	if err != nil {
@@ -173,6 +178,7 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 		return errdefs.Conflict(fmt.Errorf("Error: Exec command %s is already running", ec.ID))
 	}
 	ec.Running = true
+	ec.StartedAt = time.Now()
 	ec.Unlock()
 
 	c := daemon.containers.Get(ec.ContainerID)
@@ -217,6 +223,7 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 	} else {
 		ec.StreamConfig.NewNopInputPipe()
 	}
+	ec.RecordScrollback()
 
 	p := &specs.Process{}
 	if runtime.GOOS != "windows" {
@@ -239,6 +246,10 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 		p.Cwd = "/"
 	}
 
+	if ec.Tty && ec.ConsoleSize != nil {
+		p.ConsoleSize = &specs.Box{Height: ec.ConsoleSize[0], Width: ec.ConsoleSize[1]}
+	}
+
 	if err := daemon.execSetPlatformOpt(c, ec, p); err != nil {
 		return err
 	}
@@ -252,7 +263,10 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 		Stdout:     cStdout,
 		Stderr:     cStderr,
 		DetachKeys: ec.DetachKeys,
-		CloseStdin: true,
+		// A Persistent exec leaves its real stdin open across a client
+		// disconnect so a later reattach can keep writing to it; see
+		// ContainerExecAttach.
+		CloseStdin: !ec.Persistent,
 	}
 	ec.StreamConfig.AttachStreams(&attachConfig)
 	attachErr := ec.StreamConfig.CopyStreams(ctx, &attachConfig)
@@ -273,6 +287,11 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 	c.ExecCommands.Unlock()
 	ec.Unlock()
 
+	if err := daemon.execScopeResources(c, ec, systemPid); err != nil {
+		daemon.containerd.SignalProcess(ctx, c.ID, name, int(signal.SignalMap["KILL"]))
+		return errdefs.System(errors.Wrap(err, "exec: failed to apply resource limits"))
+	}
+
 	select {
 	case <-ctx.Done():
 		logrus.Debugf("Sending TERM signal to process %v in container %v", name, c.ID)
@@ -303,6 +322,94 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, stdin
 	return nil
 }
 
+// ContainerExecAttach reattaches to an already-running exec, replaying its
+// scrollback before forwarding live output. Unlike ContainerExecStart,
+// detaching - stdin/stdout/stderr closing or ctx cancellation - never
+// signals the exec process; it only stops this attacher's copy of the
+// streams. Reattach is only useful for a Persistent exec: a non-Persistent
+// exec's real stdin closes when its original attacher disconnects, which
+// typically ends the process the same way closing a terminal would, so by
+// the time a client tries to reattach there is usually nothing left
+// running to attach to.
+func (daemon *Daemon) ContainerExecAttach(ctx context.Context, name string, stdin io.ReadCloser, stdout, stderr io.Writer) error {
+	ec := daemon.execCommands.Get(name)
+	if ec == nil {
+		return errExecNotFound(name)
+	}
+
+	ec.Lock()
+	if !ec.Running {
+		ec.Unlock()
+		return errdefs.Conflict(fmt.Errorf("Error: Exec command %s is not running, cannot attach", ec.ID))
+	}
+	tty := ec.Tty
+	openStdin := ec.OpenStdin
+	detachKeys := ec.DetachKeys
+	scrollback := ec.Scrollback
+	ec.Unlock()
+
+	if stdout != nil {
+		if _, err := stdout.Write(scrollback.Bytes()); err != nil {
+			return errors.Wrap(err, "exec attach: failed to replay scrollback")
+		}
+	}
+
+	attachConfig := &stream.AttachConfig{
+		TTY:        tty,
+		UseStdin:   openStdin && stdin != nil,
+		UseStdout:  stdout != nil,
+		UseStderr:  stderr != nil,
+		Stdin:      stdin,
+		Stdout:     stdout,
+		Stderr:     stderr,
+		DetachKeys: detachKeys,
+		CloseStdin: false,
+	}
+	ec.StreamConfig.AttachStreams(attachConfig)
+	attachErr := ec.StreamConfig.CopyStreams(ctx, attachConfig)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-attachErr:
+		if err != nil {
+			if _, ok := err.(term.EscapeError); !ok {
+				return errdefs.System(errors.Wrap(err, "exec attach failed"))
+			}
+		}
+	}
+	return nil
+}
+
+// ContainerExecList summarizes every exec instance the daemon still tracks
+// for the given container, whether running or awaiting garbage collection
+// after exit.
+func (daemon *Daemon) ContainerExecList(name string) ([]*backend.ExecListItem, error) {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*backend.ExecListItem
+	for _, id := range ctr.ExecCommands.List() {
+		ec := ctr.ExecCommands.Get(id)
+		if ec == nil {
+			continue
+		}
+		ec.Lock()
+		items = append(items, &backend.ExecListItem{
+			ID:            ec.ID,
+			Running:       ec.Running,
+			ExitCode:      ec.ExitCode,
+			Persistent:    ec.Persistent,
+			StartedAt:     ec.StartedAt,
+			ProcessConfig: inspectExecProcessConfig(ec),
+		})
+		ec.Unlock()
+	}
+	return items, nil
+}
+
 // execCommandGC runs a ticker to clean up the daemon references
 // of exec configs that are no longer part of the container.
 func (daemon *Daemon) execCommandGC() {
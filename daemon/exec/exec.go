@@ -2,8 +2,10 @@ package exec // import "github.com/docker/docker/daemon/exec"
 
 import (
 	"context"
+	"io"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/containerd/containerd/cio"
 	"github.com/docker/docker/container/stream"
@@ -35,6 +37,27 @@ type Config struct {
 	WorkingDir   string
 	Env          []string
 	Pid          int
+	// ConsoleSize is the initial TTY size, given as [height, width], to
+	// apply atomically when the process starts rather than through a
+	// later, separate resize call. Nil if none was requested.
+	ConsoleSize *[2]uint
+	// Persistent keeps the process and its stdin open across a client
+	// disconnect instead of ending it; see Scrollback and
+	// Daemon.ContainerExecAttach.
+	Persistent bool
+	// NanoCPUs and Memory confine the exec'd process to a dedicated
+	// sub-cgroup of the container's own cgroup; see
+	// Daemon.execScopeResources. Zero means unconfined.
+	NanoCPUs int64
+	Memory   int64
+	// StartedAt records when the exec process started running. It is the
+	// zero Time until Running becomes true.
+	StartedAt time.Time
+	// Scrollback records the exec's combined stdout/stderr output so a
+	// later reattach can replay recent history. It is always recording
+	// while the exec is running, regardless of whether a client is
+	// attached.
+	Scrollback *Scrollback
 }
 
 // NewConfig initializes the a new exec configuration
@@ -43,9 +66,19 @@ func NewConfig() *Config {
 		ID:           stringid.GenerateRandomID(),
 		StreamConfig: stream.NewConfig(),
 		Started:      make(chan struct{}),
+		Scrollback:   NewScrollback(scrollbackSize),
 	}
 }
 
+// RecordScrollback attaches c.Scrollback to c.StreamConfig's stdout and
+// stderr so it keeps recording for as long as they stay open, independent
+// of any client attach. It must be called once stdio pipes are set up, and
+// before CloseStreams is called.
+func (c *Config) RecordScrollback() {
+	go io.Copy(c.Scrollback, c.StreamConfig.StdoutPipe())
+	go io.Copy(c.Scrollback, c.StreamConfig.StderrPipe())
+}
+
 type rio struct {
 	cio.IO
 
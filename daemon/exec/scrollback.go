@@ -0,0 +1,72 @@
+package exec // import "github.com/docker/docker/daemon/exec"
+
+import "sync"
+
+// scrollbackSize bounds how many bytes of combined stdout/stderr output a
+// Scrollback retains for replay to a client that reattaches to an exec
+// after the client that started it has disconnected.
+const scrollbackSize = 64 * 1024
+
+// Scrollback is a fixed-size, overwrite-oldest ring buffer recording an
+// exec's combined stdout/stderr output. It is always attached to a running
+// exec's streams, independent of whether any client is currently attached,
+// so a reattach can replay recent history before it starts forwarding live
+// output.
+type Scrollback struct {
+	mu   sync.Mutex
+	buf  []byte
+	next int
+	full bool
+}
+
+// NewScrollback creates a Scrollback retaining up to size bytes.
+func NewScrollback(size int) *Scrollback {
+	return &Scrollback{buf: make([]byte, size)}
+}
+
+// Write implements io.Writer. It never blocks and never returns an error;
+// once the buffer is full it discards the oldest retained bytes to make
+// room for p.
+func (s *Scrollback) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.buf) == 0 {
+		return len(p), nil
+	}
+
+	if len(p) >= len(s.buf) {
+		copy(s.buf, p[len(p)-len(s.buf):])
+		s.next = 0
+		s.full = true
+		return len(p), nil
+	}
+
+	n := copy(s.buf[s.next:], p)
+	if n < len(p) {
+		copy(s.buf, p[n:])
+		s.full = true
+	}
+	s.next = (s.next + len(p)) % len(s.buf)
+	if s.next == 0 {
+		s.full = true
+	}
+	return len(p), nil
+}
+
+// Bytes returns a snapshot of the currently retained output, oldest first.
+func (s *Scrollback) Bytes() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.full {
+		out := make([]byte, s.next)
+		copy(out, s.buf[:s.next])
+		return out
+	}
+
+	out := make([]byte, len(s.buf))
+	n := copy(out, s.buf[s.next:])
+	copy(out[n:], s.buf[:s.next])
+	return out
+}
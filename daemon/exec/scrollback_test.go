@@ -0,0 +1,40 @@
+package exec // import "github.com/docker/docker/daemon/exec"
+
+import "testing"
+
+func TestScrollbackBelowCapacity(t *testing.T) {
+	s := NewScrollback(10)
+	s.Write([]byte("abc"))
+	s.Write([]byte("de"))
+
+	if got, want := string(s.Bytes()), "abcde"; got != want {
+		t.Fatalf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestScrollbackOverwritesOldest(t *testing.T) {
+	s := NewScrollback(10)
+	s.Write([]byte("ABCDE"))
+	s.Write([]byte("FGHIJ"))
+	s.Write([]byte("KL"))
+
+	if got, want := string(s.Bytes()), "CDEFGHIJKL"; got != want {
+		t.Fatalf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestScrollbackSingleWriteLargerThanCapacity(t *testing.T) {
+	s := NewScrollback(4)
+	s.Write([]byte("0123456789"))
+
+	if got, want := string(s.Bytes()), "6789"; got != want {
+		t.Fatalf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestScrollbackEmpty(t *testing.T) {
+	s := NewScrollback(10)
+	if got := s.Bytes(); len(got) != 0 {
+		t.Fatalf("Bytes() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,71 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"path/filepath"
+	"time"
+
+	ctrdcgroups "github.com/containerd/cgroups"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/errdefs"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// execScopeResources places the already-started exec process pid into a
+// dedicated sub-cgroup of the container's own cgroup and applies ec's
+// NanoCPUs/Memory limits to it, so a debugging command can't starve the
+// container's main workload.
+//
+// This only supports the one combination this daemon can turn into a
+// plain cgroupfs path without extra help: cgroup v1 with the non-systemd
+// ("cgroupfs") cgroup driver. Sandboxed runtimes manage their own
+// guest-side cgroups, the systemd driver names cgroups as scope units
+// that need systemd itself to resolve to a filesystem path, and cgroup
+// v2's unified hierarchy needs its own resource-file format - none of
+// those are implemented here yet.
+func (daemon *Daemon) execScopeResources(c *container.Container, ec *exec.Config, pid int) error {
+	if ec.NanoCPUs == 0 && ec.Memory == 0 {
+		return nil
+	}
+
+	if daemon.isSandboxedRuntime(c.HostConfig.Runtime) {
+		return errdefs.NotImplemented(errors.New("exec resource limits are not supported with sandboxed runtimes"))
+	}
+	if UsingSystemd(daemon.configStore) {
+		return errdefs.NotImplemented(errors.New("exec resource limits are not supported with the systemd cgroup driver"))
+	}
+	if cgroups.IsCgroup2UnifiedMode() {
+		return errdefs.NotImplemented(errors.New("exec resource limits are not supported with cgroup v2"))
+	}
+
+	parent := "/docker"
+	if c.HostConfig.CgroupParent != "" {
+		parent = c.HostConfig.CgroupParent
+	} else if daemon.configStore.CgroupParent != "" {
+		parent = daemon.configStore.CgroupParent
+	}
+	cgroupPath := filepath.Join(parent, c.ID, "exec-"+ec.ID)
+
+	resources := &specs.LinuxResources{}
+	if ec.NanoCPUs > 0 {
+		// https://www.kernel.org/doc/Documentation/scheduler/sched-bwc.txt
+		period := uint64(100 * time.Millisecond / time.Microsecond)
+		quota := ec.NanoCPUs * int64(period) / 1e9
+		resources.CPU = &specs.LinuxCPU{Period: &period, Quota: &quota}
+	}
+	if ec.Memory > 0 {
+		mem := ec.Memory
+		resources.Memory = &specs.LinuxMemory{Limit: &mem}
+	}
+
+	ctrl, err := ctrdcgroups.New(ctrdcgroups.V1, ctrdcgroups.StaticPath(cgroupPath), resources)
+	if err != nil {
+		return errors.Wrap(err, "exec: failed to create resource-scoped sub-cgroup")
+	}
+	if err := ctrl.Add(ctrdcgroups.Process{Pid: pid}); err != nil {
+		return errors.Wrap(err, "exec: failed to move process into resource-scoped sub-cgroup")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// execScopeResources is not implemented on Windows: confining an exec'd
+// process to a resource-limited sub-cgroup is a Linux cgroups concept with
+// no Windows job-object equivalent wired up here yet.
+func (daemon *Daemon) execScopeResources(c *container.Container, ec *exec.Config, pid int) error {
+	if ec.NanoCPUs == 0 && ec.Memory == 0 {
+		return nil
+	}
+	return errdefs.NotImplemented(errors.New("exec resource limits are not supported on Windows"))
+}
@@ -0,0 +1,124 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+
+	"github.com/docker/libnetwork"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// announceGratuitousARP sends a gratuitous ARP for ep's IPv4 address over
+// its own interface once it has joined sb, so that other hosts on a
+// macvlan/ipvlan network update their ARP caches immediately instead of
+// waiting on the stale entry to time out. This matters most when a new
+// container takes over an address a previous, now-gone container held:
+// without this, traffic to that address can black-hole at upstream
+// switches/routers until their ARP cache entry expires.
+//
+// This only applies to macvlan and ipvlan: other drivers either don't put
+// the container directly on the physical L2 segment (bridge, NAT'd) or
+// already handle ARP themselves (host, overlay).
+//
+// IPv6 neighbor advertisements are not sent; this is an IPv4-only,
+// best-effort feature and failures are logged rather than returned, since
+// a container should still come up even if announcing its address fails.
+func (daemon *Daemon) announceGratuitousARP(n libnetwork.Network, sb libnetwork.Sandbox, ep libnetwork.Endpoint) {
+	if !stickyAddressDrivers[n.Type()] {
+		return
+	}
+	if sb == nil || ep == nil {
+		return
+	}
+	info := ep.Info()
+	if info == nil {
+		return
+	}
+	iface := info.Iface()
+	if iface == nil || iface.SrcName() == "" || iface.Address() == nil {
+		return
+	}
+	ip4 := iface.Address().IP.To4()
+	if ip4 == nil {
+		return
+	}
+	mac := iface.MacAddress()
+	if mac == nil {
+		return
+	}
+
+	if err := sendGratuitousARP(sb.Key(), iface.SrcName(), ip4, mac); err != nil {
+		logrus.Warnf("failed to send gratuitous ARP for %s on %s: %v", ip4, iface.SrcName(), err)
+	}
+}
+
+// sendGratuitousARP enters the network namespace at nsPath and transmits a
+// single gratuitous ARP request (sender and target protocol address both
+// set to ip) out of iface, sourced from and broadcast to mac/ff:ff:ff:ff:ff:ff.
+func sendGratuitousARP(nsPath, iface string, ip net.IP, mac net.HardwareAddr) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/net")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(nsPath)
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("failed to enter network namespace %s: %v", nsPath, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNET)
+
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return err
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return fmt.Errorf("failed to open packet socket: %v", err)
+	}
+	defer unix.Close(fd)
+
+	addr := unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ARP),
+		Ifindex:  ifi.Index,
+		Halen:    6,
+	}
+	copy(addr.Addr[:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	return unix.Sendto(fd, garpPacket(mac, ip), 0, &addr)
+}
+
+// garpPacket builds the ARP payload (excluding the Ethernet header, which
+// AF_PACKET/SOCK_DGRAM fills in from the sockaddr) for a gratuitous ARP
+// request: an ARP request where the sender and target protocol addresses
+// are the same, used purely to update peers' ARP caches.
+func garpPacket(mac net.HardwareAddr, ip net.IP) []byte {
+	pkt := make([]byte, 28)
+	binary.BigEndian.PutUint16(pkt[0:2], 1)      // hardware type: Ethernet
+	binary.BigEndian.PutUint16(pkt[2:4], 0x0800) // protocol type: IPv4
+	pkt[4] = 6                                   // hardware address length
+	pkt[5] = 4                                   // protocol address length
+	binary.BigEndian.PutUint16(pkt[6:8], 1)      // opcode: request
+	copy(pkt[8:14], mac)                         // sender hardware address
+	copy(pkt[14:18], ip.To4())                   // sender protocol address
+	copy(pkt[18:24], []byte{0, 0, 0, 0, 0, 0})   // target hardware address (unknown)
+	copy(pkt[24:28], ip.To4())                   // target protocol address == sender's
+	return pkt
+}
+
+func htons(v int) uint16 {
+	return (uint16(v)<<8)&0xff00 | (uint16(v)>>8)&0xff
+}
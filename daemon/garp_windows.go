@@ -0,0 +1,10 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/libnetwork"
+)
+
+// announceGratuitousARP is unsupported on Windows: there is no macvlan or
+// ipvlan driver on this platform, so there is nothing to announce.
+func (daemon *Daemon) announceGratuitousARP(n libnetwork.Network, sb libnetwork.Sandbox, ep libnetwork.Endpoint) {
+}
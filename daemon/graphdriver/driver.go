@@ -122,6 +122,25 @@ type CapabilityDriver interface {
 	Capabilities() Capabilities
 }
 
+// HealthCheckResult is the outcome of a single startup self-test performed
+// by a storage driver against the filesystem backing its graph root, such
+// as checking for d_type or whiteout support. Detail is empty when OK is
+// true.
+type HealthCheckResult struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// HealthChecker is the interface for drivers that can self-test their
+// prerequisites once at startup, so problems with the backing filesystem
+// (missing d_type, no native overlay support, no project quota, ...) show
+// up as a clear result in /info instead of containers failing later with
+// an obscure I/O error.
+type HealthChecker interface {
+	HealthCheck() []HealthCheckResult
+}
+
 // DiffGetterDriver is the interface for layered file system drivers that
 // provide a specialized function for getting file contents for tar-split.
 type DiffGetterDriver interface {
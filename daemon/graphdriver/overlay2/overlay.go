@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package overlay2 // import "github.com/docker/docker/daemon/graphdriver/overlay2"
@@ -265,6 +266,32 @@ func (d *Driver) Status() [][2]string {
 	}
 }
 
+// HealthCheck reports on the prerequisites this driver depends on, using
+// the state already computed at driver initialization. It implements
+// graphdriver.HealthChecker so daemon startup can surface backing
+// filesystem problems (missing d_type support, no native diff) up front
+// instead of containers failing later with an obscure I/O error. Project
+// quota support is not reported.
+func (d *Driver) HealthCheck() []graphdriver.HealthCheckResult {
+	results := []graphdriver.HealthCheckResult{
+		{
+			Name: "d_type",
+			OK:   d.supportsDType,
+		},
+		{
+			Name: "native-diff",
+			OK:   !useNaiveDiff(d.home),
+		},
+	}
+	if !results[0].OK {
+		results[0].Detail = overlayutils.ErrDTypeNotSupported("overlay2", backingFs).Error()
+	}
+	if !results[1].OK {
+		results[1].Detail = "kernel or backing filesystem does not support the overlay diff optimization; falling back to the slower naive diff"
+	}
+	return results
+}
+
 // GetMetadata returns metadata about the overlay driver such as the LowerDir,
 // UpperDir, WorkDir, and MergeDir used to store data.
 func (d *Driver) GetMetadata(id string) (map[string]string, error) {
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,7 +16,10 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
@@ -113,6 +119,164 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container
 	}, nil
 }
 
+// tcpProbe implements the "TCP" probe type. It opens a TCP connection to
+// addr (a "host:port" pair, or ":port" to probe the container's own
+// address) directly from the daemon, without spawning an exec.
+type tcpProbe struct {
+	addr string
+}
+
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	start := time.Now()
+	addr, err := resolveProbeAddr(cntr, p.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   err.Error(),
+		}, nil
+	}
+	conn.Close()
+	return &types.HealthcheckResult{
+		Start:    start,
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   "connected to " + addr,
+	}, nil
+}
+
+// httpProbe implements the "HTTP" probe type. It issues an HTTP request to
+// the container directly from the daemon, without spawning an exec, and
+// considers the container healthy if the response status matches
+// expectedStatus.
+type httpProbe struct {
+	addr           string
+	method         string
+	path           string
+	expectedStatus int
+}
+
+func (p *httpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	start := time.Now()
+	addr, err := resolveProbeAddr(cntr, p.addr)
+	if err != nil {
+		return nil, err
+	}
+	path := p.path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	url := "http://" + addr + path
+	req, err := http.NewRequest(p.method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != p.expectedStatus {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   fmt.Sprintf("expected status %d, got %d", p.expectedStatus, resp.StatusCode),
+		}, nil
+	}
+	return &types.HealthcheckResult{
+		Start:    start,
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   fmt.Sprintf("%s %s: %d", p.method, url, resp.StatusCode),
+	}, nil
+}
+
+// grpcProbe implements the "GRPC" probe type, following the standard gRPC
+// health checking protocol (grpc.health.v1.Health/Check). It talks to the
+// container directly from the daemon, without spawning an exec.
+type grpcProbe struct {
+	addr    string
+	service string
+}
+
+func (p *grpcProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	start := time.Now()
+	addr, err := resolveProbeAddr(cntr, p.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: p.service})
+	if err != nil {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   err.Error(),
+		}, nil
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return &types.HealthcheckResult{
+			Start:    start,
+			End:      time.Now(),
+			ExitCode: 1,
+			Output:   "status: " + resp.Status.String(),
+		}, nil
+	}
+	return &types.HealthcheckResult{
+		Start:    start,
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   "status: " + resp.Status.String(),
+	}, nil
+}
+
+// resolveProbeAddr fills in the container's own IP address when hostPort
+// omits a host (e.g. ":8080"), so that TCP/HTTP/GRPC probes can be
+// configured the same way across containers regardless of their address.
+func resolveProbeAddr(cntr *container.Container, hostPort string) (string, error) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid healthcheck address %q", hostPort)
+	}
+	if host != "" {
+		return hostPort, nil
+	}
+
+	for _, ep := range cntr.NetworkSettings.Networks {
+		if ep.IPAddress != "" {
+			return net.JoinHostPort(ep.IPAddress, port), nil
+		}
+	}
+	return "", errors.Errorf("cannot resolve container address for healthcheck %q: container has no IP address", hostPort)
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -262,6 +426,38 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "TCP":
+		if len(config.Test) < 2 {
+			logrus.Warnf("Malformed TCP healthcheck in container %s: expected address argument", c.ID)
+			return nil
+		}
+		return &tcpProbe{addr: config.Test[1]}
+	case "HTTP":
+		if len(config.Test) < 3 {
+			logrus.Warnf("Malformed HTTP healthcheck in container %s: expected address and path arguments", c.ID)
+			return nil
+		}
+		method := "GET"
+		expectedStatus := http.StatusOK
+		if len(config.Test) >= 4 {
+			method = config.Test[3]
+		}
+		if len(config.Test) >= 5 {
+			if status, err := strconv.Atoi(config.Test[4]); err == nil {
+				expectedStatus = status
+			}
+		}
+		return &httpProbe{addr: config.Test[1], path: config.Test[2], method: method, expectedStatus: expectedStatus}
+	case "GRPC":
+		if len(config.Test) < 2 {
+			logrus.Warnf("Malformed GRPC healthcheck in container %s: expected address argument", c.ID)
+			return nil
+		}
+		var service string
+		if len(config.Test) >= 3 {
+			service = config.Test[2]
+		}
+		return &grpcProbe{addr: config.Test[1], service: service}
 	case "NONE":
 		return nil
 	default:
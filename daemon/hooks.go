@@ -0,0 +1,53 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/hooks"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// runContainerHooksOnStart calls each configured container-hooks plugin in
+// order, synchronously, just before containerd is asked to create c. A
+// plugin can deny the start outright (admission control) or contribute
+// extra devices to spec.Linux.Devices (custom device injection); the first
+// plugin to deny the start stops the remaining plugins from running.
+func (daemon *Daemon) runContainerHooksOnStart(c *container.Container, spec *specs.Spec) error {
+	if len(daemon.hooksPlugins) == 0 {
+		return nil
+	}
+
+	req := &hooks.StartRequest{
+		ContainerID: c.ID,
+		Config:      c.Config,
+		HostConfig:  c.HostConfig,
+		Spec:        spec,
+	}
+	for _, plugin := range daemon.hooksPlugins {
+		res, err := plugin.ContainerStart(req)
+		if err != nil {
+			return errors.Wrapf(err, "container-hooks plugin %s failed", plugin.Name())
+		}
+		if !res.Allow {
+			return errors.Errorf("container start denied by hooks plugin %s: %s", plugin.Name(), res.Msg)
+		}
+		if len(res.AddDevices) > 0 && spec.Linux != nil {
+			spec.Linux.Devices = append(spec.Linux.Devices, res.AddDevices...)
+		}
+	}
+	return nil
+}
+
+// runContainerHooksOnCleanup notifies each configured container-hooks
+// plugin that c is being cleaned up. Cleanup runs during teardown and must
+// proceed regardless of plugin failures, so errors are logged, not
+// returned.
+func (daemon *Daemon) runContainerHooksOnCleanup(c *container.Container) {
+	req := &hooks.StopRequest{ContainerID: c.ID}
+	for _, plugin := range daemon.hooksPlugins {
+		if err := plugin.ContainerStop(req); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Warnf("container-hooks plugin %s failed", plugin.Name())
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package imagepin tracks tags the user has pinned to a specific image, so
+// that prune and retag operations can refuse to move or remove them until
+// they're explicitly unpinned.
+//
+// The digest recorded for a pin is this daemon's own image ID - the digest
+// of the image's raw config JSON (see image.ID) - not the registry manifest
+// digest a client would get back from a pull or push. Those are different
+// digest spaces: the manifest digest also covers the layer list and media
+// type, which the image ID does not. RemoteDigest, set once reverification
+// has run at least once, is the registry manifest digest for the pin's tag,
+// and Moved compares successive RemoteDigest observations to each other,
+// not to Digest - this subsystem cannot tell you that a tag now points at
+// the exact image you pinned, only that the registry hasn't re-tagged it
+// out from under you since the last check.
+package imagepin // import "github.com/docker/docker/daemon/imagepin"
+
+import (
+	"sync"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Pin records that Reference must keep resolving to Digest until it's
+// explicitly unpinned.
+type Pin struct {
+	Reference        string
+	Digest           digest.Digest
+	PinnedAt         time.Time
+	ReverifyInterval time.Duration
+	RemoteDigest     digest.Digest
+	LastCheckedAt    time.Time
+	Moved            bool
+}
+
+// Engine holds the set of currently pinned references.
+type Engine struct {
+	mu   sync.Mutex
+	pins map[string]*Pin
+}
+
+// NewEngine returns an Engine with no pins.
+func NewEngine() *Engine {
+	return &Engine{pins: make(map[string]*Pin)}
+}
+
+// Pin records reference as pinned to dgst, replacing any existing pin for
+// the same reference. A zero reverifyInterval disables periodic
+// reverification for this pin.
+func (e *Engine) Pin(reference string, dgst digest.Digest, reverifyInterval time.Duration) Pin {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pin := &Pin{
+		Reference:        reference,
+		Digest:           dgst,
+		PinnedAt:         time.Now().UTC(),
+		ReverifyInterval: reverifyInterval,
+	}
+	e.pins[reference] = pin
+	return *pin
+}
+
+// Unpin removes the pin for reference, if any, and reports whether one
+// existed.
+func (e *Engine) Unpin(reference string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.pins[reference]; !ok {
+		return false
+	}
+	delete(e.pins, reference)
+	return true
+}
+
+// Get returns the pin recorded for reference, if any.
+func (e *Engine) Get(reference string) (Pin, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pin, ok := e.pins[reference]
+	if !ok {
+		return Pin{}, false
+	}
+	return *pin, true
+}
+
+// List returns every current pin, in no particular order.
+func (e *Engine) List() []Pin {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pins := make([]Pin, 0, len(e.pins))
+	for _, pin := range e.pins {
+		pins = append(pins, *pin)
+	}
+	return pins
+}
+
+// DueForReverify returns every pin whose ReverifyInterval has elapsed since
+// its LastCheckedAt (or PinnedAt, before the first check).
+func (e *Engine) DueForReverify(now time.Time) []Pin {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var due []Pin
+	for _, pin := range e.pins {
+		if pin.ReverifyInterval <= 0 {
+			continue
+		}
+		last := pin.LastCheckedAt
+		if last.IsZero() {
+			last = pin.PinnedAt
+		}
+		if now.Sub(last) >= pin.ReverifyInterval {
+			due = append(due, *pin)
+		}
+	}
+	return due
+}
+
+// RecordCheck stores the registry manifest digest observed for reference's
+// tag at checkedAt, and reports whether it differs from the last one
+// observed (false on the first check, since there's nothing yet to compare
+// against). It is a no-op if reference is no longer pinned.
+func (e *Engine) RecordCheck(reference string, remoteDigest digest.Digest, checkedAt time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pin, ok := e.pins[reference]
+	if !ok {
+		return false
+	}
+
+	moved := pin.RemoteDigest != "" && pin.RemoteDigest != remoteDigest
+	pin.RemoteDigest = remoteDigest
+	pin.LastCheckedAt = checkedAt
+	pin.Moved = moved
+	return moved
+}
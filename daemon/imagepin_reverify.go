@@ -0,0 +1,19 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"time"
+)
+
+// imagePinReverifyLoop periodically asks the image service to recheck any
+// pin with a reverify interval configured. It runs on a fixed tick
+// independent of any individual pin's interval; ImageService.ReverifyPins
+// decides which pins are actually due.
+func (daemon *Daemon) imagePinReverifyLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		daemon.imageService.ReverifyPins(context.Background())
+	}
+}
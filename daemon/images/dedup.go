@@ -0,0 +1,85 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/layer"
+)
+
+type dedupKey struct {
+	size   int64
+	digest string
+}
+
+// LayerDedupReport walks every layer in every layer store and reports
+// files whose content is duplicated across two or more layers, along with
+// the space that could be reclaimed by deduplicating them (e.g. via
+// reflinks on a filesystem that supports them).
+//
+// This is a read-only analysis: it does not rewrite any layer content.
+func (i *ImageService) LayerDedupReport(ctx context.Context) (*types.DedupReport, error) {
+	groups := map[dedupKey][]types.DuplicateFileLocation{}
+
+	for _, ls := range i.layerStores {
+		for chainID, l := range ls.Map() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := hashLayerFiles(l, chainID.String(), groups); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	report := &types.DedupReport{}
+	for key, locations := range groups {
+		if len(locations) < 2 {
+			continue
+		}
+		report.DuplicateFiles = append(report.DuplicateFiles, types.DuplicateFileGroup{
+			Digest:    key.digest,
+			Size:      key.size,
+			Locations: locations,
+		})
+		report.ReclaimableSize += key.size * int64(len(locations)-1)
+	}
+
+	return report, nil
+}
+
+// hashLayerFiles streams l's tar contents, hashing each regular file and
+// recording its location under the (size, digest) key shared by every
+// other file found with identical content.
+func hashLayerFiles(l layer.Layer, chainID string, groups map[dedupKey][]types.DuplicateFileLocation) error {
+	rc, err := l.TarStream()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size == 0 {
+			continue
+		}
+
+		h := sha256.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return err
+		}
+		key := dedupKey{size: hdr.Size, digest: hex.EncodeToString(h.Sum(nil))}
+		groups[key] = append(groups[key], types.DuplicateFileLocation{ChainID: chainID, Path: hdr.Name})
+	}
+}
@@ -97,3 +97,16 @@ func (i *ImageService) GetImage(refOrID string, platform *specs.Platform) (retIm
 
 	return nil, ErrImageDoesNotExist{ref}
 }
+
+// ImageHotFiles returns the page-cache warmup file list previously recorded
+// for id by SetImageHotFiles, or nil if none has been recorded.
+func (i *ImageService) ImageHotFiles(id image.ID) ([]string, error) {
+	return i.imageStore.GetHotFiles(id)
+}
+
+// SetImageHotFiles records the set of files, relative to a container's
+// rootfs, worth preloading into the page cache the next time a container of
+// image id is started.
+func (i *ImageService) SetImageHotFiles(id image.ID, paths []string) error {
+	return i.imageStore.SetHotFiles(id, paths)
+}
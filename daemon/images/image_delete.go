@@ -45,13 +45,13 @@ const (
 // are divided into two categories grouped by their severity:
 //
 // Hard Conflict:
-// 	- a pull or build using the image.
-// 	- any descendant image.
-// 	- any running container using the image.
+//   - a pull or build using the image.
+//   - any descendant image.
+//   - any running container using the image.
 //
 // Soft Conflict:
-// 	- any stopped container using the image.
-// 	- any repository tag or digest references to the image.
+//   - any stopped container using the image.
+//   - any repository tag or digest references to the image.
 //
 // The image cannot be removed if there are any hard conflicts and can be
 // removed if there are soft conflicts only if force is true.
@@ -59,7 +59,6 @@ const (
 // If prune is true, ancestor images will each attempt to be deleted quietly,
 // meaning any delete conflicts will cause the image to not be deleted and the
 // conflict will not be reported.
-//
 func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.ImageDeleteResponseItem, error) {
 	start := time.Now()
 	records := []types.ImageDeleteResponseItem{}
@@ -75,6 +74,14 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 	imgID := img.ID()
 	repoRefs := i.referenceStore.References(imgID.Digest())
 
+	for _, repoRef := range repoRefs {
+		if tagged, ok := repoRef.(reference.NamedTagged); ok {
+			if pin, pinned := i.imagePins.Get(reference.FamiliarString(tagged)); pinned {
+				return nil, errdefs.Conflict(errors.Errorf("conflict: unable to remove repository reference %q (must unpin first) - %s is pinned to %s", imageRef, pin.Reference, pin.Digest))
+			}
+		}
+	}
+
 	using := func(c *container.Container) bool {
 		return c.ImageID == imgID
 	}
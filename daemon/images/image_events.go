@@ -1,7 +1,10 @@
 package images // import "github.com/docker/docker/daemon/images"
 
 import (
+	"strconv"
+
 	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/pkg/progress"
 )
 
 // LogImageEvent generates an event related to an image with only the default attributes.
@@ -28,6 +31,26 @@ func (i *ImageService) LogImageEventWithAttributes(imageID, refName, action stri
 	i.eventsService.Log(action, events.ImageEventType, actor)
 }
 
+// LogImagePullProgressEvent generates a "pull" event for each layer
+// progress update received while pulling ref, so that dashboards can
+// observe node-wide pull activity without holding the original pull's
+// HTTP connection.
+func (i *ImageService) LogImagePullProgressEvent(ref string, p progress.Progress) {
+	if p.ID == "" {
+		return
+	}
+	actor := events.Actor{
+		ID: ref,
+		Attributes: map[string]string{
+			"layer":   p.ID,
+			"status":  p.Action,
+			"current": strconv.FormatInt(p.Current, 10),
+			"total":   strconv.FormatInt(p.Total, 10),
+		},
+	}
+	i.eventsService.Log("pull", events.ImageEventType, actor)
+}
+
 // copyAttributes guarantees that labels are not mutated by event triggers.
 func copyAttributes(attributes, labels map[string]string) {
 	if labels == nil {
@@ -18,8 +18,28 @@ func (i *ImageService) ExportImage(names []string, outStream io.Writer) error {
 
 // LoadImage uploads a set of images into the repository. This is the
 // complement of ImageExport.  The input stream is an uncompressed tar
-// ball containing images and metadata.
+// ball containing images and metadata, in either the legacy docker save
+// format or OCI image-layout format; the format is detected automatically.
 func (i *ImageService) LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
 	imageExporter := tarexport.NewTarExporter(i.imageStore, i.layerStores, i.referenceStore, i)
 	return imageExporter.Load(inTar, outStream, quiet)
 }
+
+// ExportImageOCI exports a list of images to the given output stream in OCI
+// image-layout format rather than the legacy docker save format used by
+// ExportImage. compression selects the layer blob compression: "" for
+// uncompressed tar layers, or "gzip"; any other value, including "zstd", is
+// rejected.
+func (i *ImageService) ExportImageOCI(names []string, outStream io.Writer, compression string) error {
+	imageExporter := tarexport.NewTarExporter(i.imageStore, i.layerStores, i.referenceStore, i)
+	return imageExporter.SaveOCI(names, outStream, tarexport.OCIOptions{Compression: compression})
+}
+
+// ExportImageDelta is like ExportImage, but omits the content of layers
+// already present in the local image named deltaFrom, for transfer to a
+// host that is already known to have deltaFrom. See tarexport.SaveDelta
+// for exactly what this does and does not cover.
+func (i *ImageService) ExportImageDelta(names []string, deltaFrom string, outStream io.Writer) error {
+	imageExporter := tarexport.NewTarExporter(i.imageStore, i.layerStores, i.referenceStore, i)
+	return imageExporter.SaveDelta(names, deltaFrom, outStream)
+}
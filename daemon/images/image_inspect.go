@@ -83,7 +83,9 @@ func (i *ImageService) LookupImage(name string) (*types.ImageInspect, error) {
 		VirtualSize:     size, // TODO: field unused, deprecate
 		RootFS:          rootFSToAPIType(img.RootFS),
 		Metadata: types.ImageMetadata{
-			LastTagTime: lastUpdated,
+			LastTagTime:       lastUpdated,
+			Verification:      i.lastVerification(repoTags),
+			VulnerabilityScan: i.vulnerabilityScanMetadata(img.ID().String()),
 		},
 	}
 
@@ -93,6 +95,59 @@ func (i *ImageService) LookupImage(name string) (*types.ImageInspect, error) {
 	return imageInspect, nil
 }
 
+// lastVerification returns the trust policy decision recorded for the first
+// of repoTags whose repository was evaluated at pull time, or nil if no
+// trust policy is configured or none of repoTags was ever pulled under one.
+func (i *ImageService) lastVerification(repoTags []string) *types.ImageVerification {
+	if i.trustPolicy == nil {
+		return nil
+	}
+	for _, tag := range repoTags {
+		named, err := reference.ParseNormalizedNamed(tag)
+		if err != nil {
+			continue
+		}
+		decision, ok := i.trustPolicy.LastDecision(reference.FamiliarName(named))
+		if !ok {
+			continue
+		}
+		return &types.ImageVerification{
+			Repository:        decision.Repository,
+			PolicyMatched:     decision.PolicyMatched,
+			RequireSignatures: decision.RequireSignatures,
+			Verified:          decision.Verified,
+			Reason:            decision.Reason,
+		}
+	}
+	return nil
+}
+
+// vulnerabilityScanMetadata returns the vulnerability scan result recorded
+// for imageID, if a scanner is configured and imageID has been scanned
+// since the daemon last started.
+func (i *ImageService) vulnerabilityScanMetadata(imageID string) *types.ImageVulnerabilityScan {
+	result, ok := i.VulnerabilityScanResult(imageID)
+	if !ok {
+		return nil
+	}
+	findings := make([]types.VulnerabilityFinding, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		findings = append(findings, types.VulnerabilityFinding{
+			ID:          f.ID,
+			Severity:    string(f.Severity),
+			Package:     f.Package,
+			Version:     f.Version,
+			Description: f.Description,
+		})
+	}
+	return &types.ImageVulnerabilityScan{
+		Findings:  findings,
+		Blocked:   result.Blocked,
+		Reason:    result.Reason,
+		ScannedAt: result.ScannedAt,
+	}
+}
+
 func rootFSToAPIType(rootfs *image.RootFS) types.RootFS {
 	var layers []string
 	for _, l := range rootfs.DiffIDs {
@@ -0,0 +1,136 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/daemon/imagepin"
+	"github.com/docker/docker/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// PinImage pins imageRef, which must resolve to a tag (not a digest
+// reference or a bare image ID), to the image it currently names. Until
+// UnpinImage is called for the same reference, ImageDelete and
+// TagImageWithReference refuse any operation that would remove or move it.
+// A reverifyInterval greater than zero additionally has ReverifyPins
+// periodically check whether the tag's upstream registry manifest digest
+// has changed, recording the result for inspection but never overriding
+// the pin itself.
+func (i *ImageService) PinImage(imageRef string, reverifyInterval time.Duration) (*types.ImagePin, error) {
+	tagged, err := parsePinnableReference(imageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := i.referenceStore.Get(tagged)
+	if err != nil {
+		return nil, errdefs.NotFound(errors.Wrapf(err, "pin: %s is not a known tag", imageRef))
+	}
+
+	pin := i.imagePins.Pin(reference.FamiliarString(tagged), id, reverifyInterval)
+	apiPin := toAPIImagePin(pin)
+	return &apiPin, nil
+}
+
+// UnpinImage removes the pin recorded for imageRef, if any.
+func (i *ImageService) UnpinImage(imageRef string) error {
+	tagged, err := parsePinnableReference(imageRef)
+	if err != nil {
+		return err
+	}
+
+	if !i.imagePins.Unpin(reference.FamiliarString(tagged)) {
+		return errdefs.NotFound(errors.Errorf("pin: %s is not pinned", imageRef))
+	}
+	return nil
+}
+
+// ImagePins returns every currently pinned reference.
+func (i *ImageService) ImagePins() []types.ImagePin {
+	pins := i.imagePins.List()
+	apiPins := make([]types.ImagePin, len(pins))
+	for idx, pin := range pins {
+		apiPins[idx] = toAPIImagePin(pin)
+	}
+	return apiPins
+}
+
+// ReverifyPins checks every pin due for reverification against its tag's
+// current upstream registry manifest digest, using no registry
+// credentials, and logs an event the first time a tag's manifest digest
+// changes since the last check.
+func (i *ImageService) ReverifyPins(ctx context.Context) {
+	for _, pin := range i.imagePins.DueForReverify(time.Now()) {
+		named, err := reference.ParseNormalizedNamed(pin.Reference)
+		if err != nil {
+			continue
+		}
+		tagged, ok := named.(reference.NamedTagged)
+		if !ok {
+			continue
+		}
+
+		remoteDigest, err := i.resolveRemoteTagDigest(ctx, tagged)
+		if err != nil {
+			logrus.WithError(err).WithField("image", pin.Reference).Warn("image pin: reverify failed to reach registry")
+			continue
+		}
+
+		if i.imagePins.RecordCheck(pin.Reference, remoteDigest, time.Now()) {
+			i.eventsService.Log("pin-moved", events.ImageEventType, events.Actor{
+				ID: pin.Digest.String(),
+				Attributes: map[string]string{
+					"name":          pin.Reference,
+					"remote-digest": remoteDigest.String(),
+				},
+			})
+		}
+	}
+}
+
+// resolveRemoteTagDigest looks up the manifest digest the registry
+// currently serves for tagged, without pulling it.
+func (i *ImageService) resolveRemoteTagDigest(ctx context.Context, tagged reference.NamedTagged) (digest.Digest, error) {
+	repo, _, err := i.GetRepository(ctx, reference.TrimNamed(tagged), nil)
+	if err != nil {
+		return "", err
+	}
+	desc, err := repo.Tags(ctx).Get(ctx, tagged.Tag())
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest, nil
+}
+
+// parsePinnableReference normalizes imageRef and requires it to be a tag
+// reference, since a digest reference is already immutable and an image ID
+// has no tag for a registry to move.
+func parsePinnableReference(imageRef string) (reference.NamedTagged, error) {
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !ok {
+		return nil, errdefs.InvalidParameter(errors.Errorf("pin: %s must be a tag reference", imageRef))
+	}
+	return tagged, nil
+}
+
+func toAPIImagePin(pin imagepin.Pin) types.ImagePin {
+	return types.ImagePin{
+		Reference:        pin.Reference,
+		Digest:           pin.Digest.String(),
+		PinnedAt:         pin.PinnedAt,
+		ReverifyInterval: pin.ReverifyInterval,
+		RemoteDigest:     pin.RemoteDigest.String(),
+		LastCheckedAt:    pin.LastCheckedAt,
+		Moved:            pin.Moved,
+	}
+}
@@ -0,0 +1,96 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/prepull"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// AddPrePullImage puts imageRef on the daemon's pre-pull list, to be
+// pulled (or re-pulled, to refresh a moving tag) every interval by
+// RunPrePull. imageRef is normalized but does not need to already exist
+// locally - the whole point is to fetch it.
+func (i *ImageService) AddPrePullImage(imageRef string, interval time.Duration) (*types.PrePullEntry, error) {
+	if interval <= 0 {
+		return nil, errdefs.InvalidParameter(errors.New("pre-pull interval must be greater than zero"))
+	}
+
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	named = reference.TagNameOnly(named)
+
+	entry := i.prePull.Add(reference.FamiliarString(named), interval)
+	apiEntry := toAPIPrePullEntry(entry)
+	return &apiEntry, nil
+}
+
+// RemovePrePullImage takes imageRef off the pre-pull list.
+func (i *ImageService) RemovePrePullImage(imageRef string) error {
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	named = reference.TagNameOnly(named)
+
+	if !i.prePull.Remove(reference.FamiliarString(named)) {
+		return errdefs.NotFound(errors.Errorf("pre-pull: %s is not on the pre-pull list", imageRef))
+	}
+	return nil
+}
+
+// PrePullImages returns the current pre-pull list.
+func (i *ImageService) PrePullImages() []types.PrePullEntry {
+	entries := i.prePull.List()
+	apiEntries := make([]types.PrePullEntry, len(entries))
+	for idx, entry := range entries {
+		apiEntries[idx] = toAPIPrePullEntry(entry)
+	}
+	return apiEntries
+}
+
+// RunPrePull pulls or re-pulls every pre-pull list entry due for a refresh,
+// passing no registry credentials of its own: like any pull without
+// credentials, it uses the credential helper configured for the registry,
+// if there is one, and otherwise pulls anonymously.
+func (i *ImageService) RunPrePull(ctx context.Context) {
+	for _, entry := range i.prePull.DueForPull(time.Now()) {
+		named, err := reference.ParseNormalizedNamed(entry.Reference)
+		if err != nil {
+			continue
+		}
+		tagged, ok := named.(reference.NamedTagged)
+		if !ok {
+			continue
+		}
+
+		err = i.PullImage(ctx, reference.FamiliarName(tagged), tagged.Tag(), nil, nil, nil, ioutil.Discard)
+		if err != nil {
+			logrus.WithError(err).WithField("image", entry.Reference).Warn("pre-pull failed")
+		}
+		i.prePull.RecordResult(entry.Reference, time.Now(), err)
+	}
+}
+
+// PrePulled reports whether imageRef's normalized tag reference is
+// currently on the pre-pull list, so prune can lease-protect it.
+func (i *ImageService) PrePulled(ref reference.Named) bool {
+	return i.prePull.Has(reference.FamiliarString(reference.TagNameOnly(ref)))
+}
+
+func toAPIPrePullEntry(entry prepull.Entry) types.PrePullEntry {
+	return types.PrePullEntry{
+		Reference:    entry.Reference,
+		Interval:     entry.Interval,
+		LastPulledAt: entry.LastPulledAt,
+		LastError:    entry.LastError,
+	}
+}
@@ -25,6 +25,7 @@ var imagesAcceptedFilters = map[string]bool{
 	"label":    true,
 	"label!":   true,
 	"until":    true,
+	"repair":   true,
 }
 
 // errPruneRunning is returned when a prune request is received while
@@ -46,6 +47,24 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 
 	rep := &types.ImagesPruneReport{}
 
+	// "repair" reconciles layer store mounts, rw-layers and metadata left
+	// inconsistent by a prior hard crash, ahead of (rather than instead
+	// of) the normal dangling-image prune below. It only applies to the
+	// classic layer store; "docker builder prune" uses BuildKit's own
+	// cache manager, which doesn't go through layer.Store, so there's
+	// nothing for this filter to repair there.
+	if pruneFilters.Contains("repair") {
+		if pruneFilters.ExactMatch("repair", "true") || pruneFilters.ExactMatch("repair", "1") {
+			for os, ls := range i.layerStores {
+				if err := ls.Repair(); err != nil {
+					return nil, errdefs.System(errors.Wrapf(err, "failed to repair %s layer store", os))
+				}
+			}
+		} else if !pruneFilters.ExactMatch("repair", "false") && !pruneFilters.ExactMatch("repair", "0") {
+			return nil, invalidFilter{"repair", pruneFilters.Get("repair")}
+		}
+	}
+
 	danglingOnly := true
 	if pruneFilters.Contains("dangling") {
 		if pruneFilters.ExactMatch("dangling", "false") || pruneFilters.ExactMatch("dangling", "0") {
@@ -84,6 +103,9 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 			if len(i.referenceStore.References(dgst)) == 0 && len(i.imageStore.Children(id)) != 0 {
 				continue
 			}
+			if prePullLeased(i, i.referenceStore.References(dgst)) {
+				continue
+			}
 			if !until.IsZero() && img.Created.After(until) {
 				continue
 			}
@@ -181,6 +203,18 @@ func imageDeleteFailed(ref string, err error) bool {
 	}
 }
 
+// prePullLeased reports whether any of refs is on i's pre-pull list, in
+// which case prune leaves the image alone so an autoscaling node doesn't
+// lose a cache entry it's about to need again.
+func prePullLeased(i *ImageService, refs []reference.Named) bool {
+	for _, ref := range refs {
+		if i.PrePulled(ref) {
+			return true
+		}
+	}
+	return false
+}
+
 func matchLabels(pruneFilters filters.Args, labels map[string]string) bool {
 	if !pruneFilters.MatchKVList("label", labels) {
 		return false
@@ -9,13 +9,18 @@ import (
 	dist "github.com/docker/distribution"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/imagescan"
+	"github.com/docker/docker/daemon/operations"
 	"github.com/docker/docker/distribution"
 	progressutils "github.com/docker/docker/distribution/utils"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/registry"
 	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // PullImage initiates a pull operation. image is the repository name to pull, and
@@ -52,6 +57,26 @@ func (i *ImageService) PullImage(ctx context.Context, image, tag string, platfor
 }
 
 func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference.Named, platform *specs.Platform, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+	if authConfig == nil {
+		// A pull with no caller-supplied credentials may still need them -
+		// most commonly a pull triggered by a container's restart policy or
+		// a swarm task, long after the client that originally authenticated
+		// is gone. If a credential helper is configured for this registry,
+		// use it instead of falling back to an anonymous pull.
+		if helperAuth, ok, err := registry.CredentialHelperAuthConfig(ctx, reference.Domain(ref)); err != nil {
+			logrus.WithError(err).WithField("registry", reference.Domain(ref)).Warn("image pull: credential helper lookup failed, pulling without credentials")
+		} else if ok {
+			authConfig = helperAuth
+		}
+	}
+
+	if i.trustPolicy != nil {
+		decision := i.trustPolicy.Evaluate(reference.FamiliarName(ref))
+		if decision.PolicyMatched && !decision.Verified {
+			return errdefs.Forbidden(errors.Errorf("image %q is rejected by the trust policy: %s", ref.String(), decision.Reason))
+		}
+	}
+
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -65,11 +90,19 @@ func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference
 		close(writesDone)
 	}()
 
+	op, ctx := operations.Start(ctx, "image-pull")
+	defer op.Finish()
+
+	progressOutput := progress.Tee(progress.ChanOutput(progressChan), func(p progress.Progress) {
+		i.LogImagePullProgressEvent(ref.String(), p)
+		op.SetProgress(p.Action, p.Current, p.Total, p.ID)
+	})
+
 	imagePullConfig := &distribution.ImagePullConfig{
 		Config: distribution.Config{
 			MetaHeaders:      metaHeaders,
 			AuthConfig:       authConfig,
-			ProgressOutput:   progress.ChanOutput(progressChan),
+			ProgressOutput:   progressOutput,
 			RegistryService:  i.registryService,
 			ImageEventLogger: i.LogImageEvent,
 			MetadataStore:    i.distributionMetadataStore,
@@ -79,14 +112,49 @@ func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference
 		DownloadManager: i.downloadManager,
 		Schema2Types:    distribution.ImageTypes,
 		Platform:        platform,
+		AllowLazyPull:   i.allowLazyPull,
 	}
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
 	close(progressChan)
 	<-writesDone
+	if err != nil {
+		return err
+	}
+
+	if i.vulnerabilityScan != nil {
+		if scanErr := i.scanPulledImage(ctx, ref, platform); scanErr != nil {
+			logrus.WithError(scanErr).WithField("image", ref.String()).Warn("vulnerability scan of pulled image failed")
+		}
+	}
+	return nil
+}
+
+// scanPulledImage runs the configured vulnerability scanner against the
+// image ref was just pulled as, recording the result for later lookup by
+// image ID (see ImageService.VulnerabilityScanResult).
+func (i *ImageService) scanPulledImage(ctx context.Context, ref reference.Named, platform *specs.Platform) error {
+	img, err := i.GetImage(ref.String(), platform)
+	if err != nil {
+		return err
+	}
+	req := imagescan.Request{
+		Ref:    ref.String(),
+		Layers: diffIDsToDigests(img.RootFS.DiffIDs),
+		Config: img.RawJSON(),
+	}
+	_, err = i.vulnerabilityScan.Scan(ctx, img.ID().String(), req)
 	return err
 }
 
+func diffIDsToDigests(diffIDs []layer.DiffID) []digest.Digest {
+	digests := make([]digest.Digest, len(diffIDs))
+	for i, d := range diffIDs {
+		digests[i] = digest.Digest(d)
+	}
+	return digests
+}
+
 // GetRepository returns a repository from the registry.
 func (i *ImageService) GetRepository(ctx context.Context, ref reference.Named, authConfig *types.AuthConfig) (dist.Repository, bool, error) {
 	// get repository info
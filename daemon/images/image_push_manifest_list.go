@@ -0,0 +1,50 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution"
+	"github.com/docker/docker/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// PushManifestList assembles an OCI image index out of sources, each of
+// which must already be present (by tag or digest) in the target
+// repository, and pushes it to target. It does not push the per-platform
+// images referenced by sources; use PushImage for that first.
+func (i *ImageService) PushManifestList(ctx context.Context, target string, sources []types.ManifestListSource, platformFilter []specs.Platform, metaHeaders map[string][]string, authConfig *types.AuthConfig) (digest.Digest, error) {
+	targetRef, err := reference.ParseNormalizedNamed(target)
+	if err != nil {
+		return "", errdefs.InvalidParameter(err)
+	}
+
+	distSources := make([]distribution.ManifestListSource, 0, len(sources))
+	for _, src := range sources {
+		ref, err := reference.ParseNormalizedNamed(src.Ref)
+		if err != nil {
+			return "", errdefs.InvalidParameter(err)
+		}
+		distSources = append(distSources, distribution.ManifestListSource{
+			Ref:         ref,
+			Annotations: src.Annotations,
+		})
+	}
+
+	imagePushConfig := &distribution.ImagePushConfig{
+		Config: distribution.Config{
+			MetaHeaders:     metaHeaders,
+			AuthConfig:      authConfig,
+			RegistryService: i.registryService,
+			ImageStore:      distribution.NewImageConfigStoreFromStore(i.imageStore),
+		},
+	}
+
+	dgst, err := distribution.CreateAndPushManifestList(ctx, targetRef, distSources, platformFilter, imagePushConfig)
+	if err != nil {
+		return "", errdefs.System(err)
+	}
+	return dgst, nil
+}
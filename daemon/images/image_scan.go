@@ -0,0 +1,41 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+
+	"github.com/docker/docker/daemon/imagescan"
+)
+
+// ScanImage runs the configured vulnerability scanner against imageID,
+// recording the result for later lookup by VulnerabilityScanResult. It is
+// a no-op, returning a zero-value Result, if no scanner is configured.
+//
+// Unlike the pull path, where the pulled platform is already known, the
+// caller (normally the build backend, right after committing the final
+// image) doesn't need to pass one: the image was just built for the
+// daemon's own platform.
+func (i *ImageService) ScanImage(ctx context.Context, imageID string) (imagescan.Result, error) {
+	if i.vulnerabilityScan == nil {
+		return imagescan.Result{}, nil
+	}
+	img, err := i.GetImage(imageID, nil)
+	if err != nil {
+		return imagescan.Result{}, err
+	}
+	req := imagescan.Request{
+		Ref:    imageID,
+		Layers: diffIDsToDigests(img.RootFS.DiffIDs),
+		Config: img.RawJSON(),
+	}
+	return i.vulnerabilityScan.Scan(ctx, img.ID().String(), req)
+}
+
+// VulnerabilityScanResult returns the most recent vulnerability scan
+// result recorded for imageID, if a scanner is configured and imageID has
+// been scanned.
+func (i *ImageService) VulnerabilityScanResult(imageID string) (imagescan.Result, bool) {
+	if i.vulnerabilityScan == nil {
+		return imagescan.Result{}, false
+	}
+	return i.vulnerabilityScan.Result(imageID)
+}
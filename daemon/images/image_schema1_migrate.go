@@ -0,0 +1,73 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution"
+	"github.com/sirupsen/logrus"
+)
+
+// MigrateSchema1Images re-pushes every locally known tag of a repository
+// that was last pulled from a schema1 manifest, upgrading the remote to
+// schema2.
+//
+// This tree has no local on-disk schema1 artifact to convert: a pulled
+// image is normalized into this daemon's own image.Image/layer.Layer model
+// at pull time regardless of the manifest format it came from, so there is
+// nothing left in local storage that still "is" schema1 by the time this
+// runs. The vendored containerd schema1 converter is not used here either,
+// since it's built around containerd's own content.Store/remotes.Fetcher
+// abstractions rather than this daemon's image.Store/layer.Store. What
+// actually changes the remote's format is a push: PushImage always builds
+// a schema2 manifest (see distribution.ImagePushConfig.ConfigMediaType in
+// PushImage), so re-pushing a repository's existing tags is the migration.
+//
+// Each tag is pushed independently, so the registry's own per-manifest PUT
+// atomicity is the only atomicity guarantee; there is no cross-tag
+// transaction. A repository is only dropped from the pending set once every
+// tag found for it pushed successfully.
+func (i *ImageService) MigrateSchema1Images(ctx context.Context) (*types.Schema1MigrationReport, error) {
+	report := &types.Schema1MigrationReport{}
+
+	for _, repo := range distribution.Schema1Repositories() {
+		select {
+		case <-ctx.Done():
+			logrus.Debugf("schema1 migration operation cancelled: %#v", *report)
+			return report, nil
+		default:
+		}
+
+		report.RepositoriesChecked++
+
+		named, err := reference.ParseNormalizedNamed(repo)
+		if err != nil {
+			report.Failed = append(report.Failed, types.Schema1MigrationFailure{Tag: repo, Reason: err.Error()})
+			continue
+		}
+
+		allMigrated := true
+		for _, ref := range i.referenceStore.ReferencesByName(named) {
+			tagged, ok := ref.Ref.(reference.NamedTagged)
+			if !ok {
+				continue
+			}
+
+			tag := reference.FamiliarString(tagged)
+			if err := i.PushImage(ctx, repo, tagged.Tag(), nil, nil, ioutil.Discard); err != nil {
+				allMigrated = false
+				report.Failed = append(report.Failed, types.Schema1MigrationFailure{Tag: tag, Reason: err.Error()})
+				continue
+			}
+			report.Migrated = append(report.Migrated, tag)
+		}
+
+		if allMigrated {
+			distribution.ForgetSchema1Repository(repo)
+		}
+	}
+
+	return report, nil
+}
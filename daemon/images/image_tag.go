@@ -2,7 +2,9 @@ package images // import "github.com/docker/docker/daemon/images"
 
 import (
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
+	"github.com/pkg/errors"
 )
 
 // TagImage creates the tag specified by newTag, pointing to the image named
@@ -29,6 +31,12 @@ func (i *ImageService) TagImage(imageName, repository, tag string) (string, erro
 
 // TagImageWithReference adds the given reference to the image ID provided.
 func (i *ImageService) TagImageWithReference(imageID image.ID, newTag reference.Named) error {
+	if tagged, ok := reference.TagNameOnly(newTag).(reference.NamedTagged); ok {
+		if pin, pinned := i.imagePins.Get(reference.FamiliarString(tagged)); pinned && pin.Digest != imageID.Digest() {
+			return errdefs.Conflict(errors.Errorf("conflict: unable to tag %q (must unpin first) - it is pinned to %s", reference.FamiliarString(tagged), pin.Digest))
+		}
+	}
+
 	if err := i.referenceStore.AddTag(newTag, imageID.Digest(), true); err != nil {
 		return err
 	}
@@ -0,0 +1,166 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// VerifyContentStore re-hashes every layer in the layer store and every
+// image config blob in the image store against the digest it was
+// registered under, reporting any that no longer match. For a corrupt
+// layer that's still part of a tagged image, it attempts a repair by
+// re-pulling that tag; a repair attempt that fails (for example because
+// the registry requires credentials and no credential helper supplies
+// them) is reported as unrepaired rather than retried.
+func (i *ImageService) VerifyContentStore(ctx context.Context) (*types.ContentVerifyReport, error) {
+	report := &types.ContentVerifyReport{}
+
+	corruptDiffIDs := make(map[layer.DiffID]struct{})
+	for _, lss := range i.layerStores {
+		for _, l := range lss.Map() {
+			select {
+			case <-ctx.Done():
+				logrus.Debugf("content verify operation cancelled: %#v", *report)
+				return report, nil
+			default:
+			}
+
+			report.LayersChecked++
+
+			actual, err := hashLayerContent(l)
+			if err != nil {
+				return nil, err
+			}
+			if actual == digest.Digest(l.DiffID()) {
+				continue
+			}
+
+			corruptDiffIDs[l.DiffID()] = struct{}{}
+			report.CorruptLayers = append(report.CorruptLayers, types.CorruptLayer{
+				DiffID:       l.DiffID().String(),
+				ActualDigest: actual.String(),
+				RepoTags:     i.repoTagsForDiffID(l.DiffID()),
+			})
+		}
+	}
+
+	repairedTags := make(map[string]struct{})
+	for id, img := range i.imageStore.Map() {
+		select {
+		case <-ctx.Done():
+			logrus.Debugf("content verify operation cancelled: %#v", *report)
+			return report, nil
+		default:
+		}
+
+		report.ImagesChecked++
+
+		actual := digest.FromBytes(img.RawJSON())
+		if actual == id.Digest() {
+			continue
+		}
+		report.CorruptImages = append(report.CorruptImages, types.CorruptImage{
+			ImageID:      id.String(),
+			ActualDigest: actual.String(),
+		})
+
+		for _, diffID := range img.RootFS.DiffIDs {
+			if _, ok := corruptDiffIDs[diffID]; !ok {
+				continue
+			}
+			for _, tag := range i.repoTagsForID(id) {
+				if _, ok := repairedTags[tag]; ok {
+					continue
+				}
+				if i.repairTag(ctx, tag) {
+					repairedTags[tag] = struct{}{}
+				}
+			}
+			break
+		}
+	}
+
+	// A corrupt layer may also belong to an otherwise-healthy image
+	// config; attempt those repairs too.
+	for _, corrupt := range report.CorruptLayers {
+		for _, tag := range corrupt.RepoTags {
+			if _, ok := repairedTags[tag]; ok {
+				continue
+			}
+			if i.repairTag(ctx, tag) {
+				repairedTags[tag] = struct{}{}
+			}
+		}
+	}
+
+	for tag := range repairedTags {
+		report.RepairedTags = append(report.RepairedTags, tag)
+	}
+
+	return report, nil
+}
+
+func hashLayerContent(l layer.Layer) (digest.Digest, error) {
+	arch, err := l.TarStream()
+	if err != nil {
+		return "", err
+	}
+	defer arch.Close()
+
+	digester := digest.Canonical.Digester()
+	if _, err := io.Copy(digester.Hash(), arch); err != nil {
+		return "", err
+	}
+	return digester.Digest(), nil
+}
+
+func (i *ImageService) repoTagsForDiffID(diffID layer.DiffID) []string {
+	var tags []string
+	for id, img := range i.imageStore.Map() {
+		for _, d := range img.RootFS.DiffIDs {
+			if d == diffID {
+				tags = append(tags, i.repoTagsForID(id)...)
+				break
+			}
+		}
+	}
+	return tags
+}
+
+func (i *ImageService) repoTagsForID(id image.ID) []string {
+	var tags []string
+	for _, ref := range i.referenceStore.References(id.Digest()) {
+		if _, ok := ref.(reference.NamedTagged); ok {
+			tags = append(tags, reference.FamiliarString(ref))
+		}
+	}
+	return tags
+}
+
+// repairTag attempts to re-pull tag to replace corrupt local content,
+// passing no registry credentials of its own; a credential helper
+// configured for the registry is still used. It returns whether the pull
+// succeeded.
+func (i *ImageService) repairTag(ctx context.Context, tag string) bool {
+	named, err := reference.ParseNormalizedNamed(tag)
+	if err != nil {
+		return false
+	}
+	tagged, ok := named.(reference.NamedTagged)
+	if !ok {
+		return false
+	}
+	if err := i.PullImage(ctx, reference.FamiliarName(tagged), tagged.Tag(), nil, nil, nil, ioutil.Discard); err != nil {
+		logrus.WithError(err).WithField("image", tag).Warn("content verify: repair pull failed")
+		return false
+	}
+	return true
+}
@@ -7,6 +7,11 @@ import (
 
 	"github.com/docker/docker/container"
 	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/imagepin"
+	"github.com/docker/docker/daemon/imagescan"
+	"github.com/docker/docker/daemon/prepull"
+	"github.com/docker/docker/daemon/trustpolicy"
 	"github.com/docker/docker/distribution"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
@@ -42,6 +47,9 @@ type ImageServiceConfig struct {
 	ReferenceStore            dockerreference.Store
 	RegistryService           registry.Service
 	TrustKey                  libtrust.PrivateKey
+	AllowLazyPull             bool
+	TrustPolicy               *trustpolicy.Engine
+	VulnerabilityScan         *imagescan.Engine
 }
 
 // NewImageService returns a new ImageService from a configuration
@@ -60,6 +68,11 @@ func NewImageService(config ImageServiceConfig) *ImageService {
 		registryService:           config.RegistryService,
 		trustKey:                  config.TrustKey,
 		uploadManager:             xfer.NewLayerUploadManager(config.MaxConcurrentUploads),
+		allowLazyPull:             config.AllowLazyPull,
+		trustPolicy:               config.TrustPolicy,
+		vulnerabilityScan:         config.VulnerabilityScan,
+		imagePins:                 imagepin.NewEngine(),
+		prePull:                   prepull.NewEngine(),
 	}
 }
 
@@ -76,6 +89,11 @@ type ImageService struct {
 	registryService           registry.Service
 	trustKey                  libtrust.PrivateKey
 	uploadManager             *xfer.LayerUploadManager
+	allowLazyPull             bool
+	trustPolicy               *trustpolicy.Engine
+	vulnerabilityScan         *imagescan.Engine
+	imagePins                 *imagepin.Engine
+	prePull                   *prepull.Engine
 }
 
 // DistributionServices provides daemon image storage services
@@ -135,6 +153,16 @@ func (i *ImageService) CreateLayer(container *container.Container, initFunc laye
 	return i.layerStores[container.OS].CreateRWLayer(container.ID, layerID, rwLayerOpts)
 }
 
+// CreateImageMountLayer mounts the root filesystem of img as a layer keyed
+// by mountID, for use as the source of a "type=image" container mount.
+// Nothing is ever written back through the returned layer; image content is
+// only ever read through it, and the layer is released via ReleaseLayer once
+// the mount is torn down.
+// called from daemon/volumes.go registerMountPoints()
+func (i *ImageService) CreateImageMountLayer(img *image.Image, os, mountID string) (layer.RWLayer, error) {
+	return i.layerStores[os].CreateRWLayer(mountID, img.RootFS.ChainID(), nil)
+}
+
 // GetLayerByID returns a layer by ID and operating system
 // called from daemon.go Daemon.restore(), and Daemon.containerExport()
 func (i *ImageService) GetLayerByID(cid string, os string) (layer.RWLayer, error) {
@@ -151,6 +179,20 @@ func (i *ImageService) LayerStoreStatus() map[string][][2]string {
 	return result
 }
 
+// LayerStoreHealthCheck returns the storage driver health-check results for
+// each layer store, keyed by operating system. An OS whose driver does not
+// implement graphdriver.HealthChecker is omitted.
+// called from info.go
+func (i *ImageService) LayerStoreHealthCheck() map[string][]graphdriver.HealthCheckResult {
+	result := make(map[string][]graphdriver.HealthCheckResult)
+	for os, store := range i.layerStores {
+		if res := store.DriverHealthCheck(); res != nil {
+			result[os] = res
+		}
+	}
+	return result
+}
+
 // GetLayerMountID returns the mount ID for a layer
 // called from daemon.go Daemon.Shutdown(), and Daemon.Cleanup() (cleanup is actually continerCleanup)
 // TODO: needs to be refactored to Unmount (see callers), or removed and replaced
@@ -249,3 +291,12 @@ func (i *ImageService) UpdateConfig(maxDownloads, maxUploads *int) {
 		i.uploadManager.SetConcurrency(*maxUploads)
 	}
 }
+
+// ReloadTrustPolicy re-reads the trust policy file configured for this
+// ImageService from disk, if one is configured.
+func (i *ImageService) ReloadTrustPolicy() error {
+	if i.trustPolicy == nil {
+		return nil
+	}
+	return i.trustPolicy.Reload()
+}
@@ -0,0 +1,178 @@
+// Package imagescan implements an optional hook that runs an external
+// vulnerability scanner plugin against an image's layer digests and config
+// after the image is pulled or built, and records what it found.
+//
+// The plugin itself is not part of this build: Engine only knows how to
+// invoke one (see NewExecScanner) and how to act on what it returns. No
+// scanner ships with this tree, so a daemon with no --vulnerability-scan-*
+// flags set never runs one and never blocks anything on its behalf.
+package imagescan // import "github.com/docker/docker/daemon/imagescan"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Severity is the severity of a Finding. Plugins report one of the values
+// below; an unrecognized value sorts as more severe than Critical so that
+// an unknown severity is never silently treated as safe to ignore.
+type Severity string
+
+// Recognized Severity values, from least to most severe.
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRank = map[Severity]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// rank returns s's position in severityRank, or one past the highest known
+// severity if s isn't recognized.
+func (s Severity) rank() int {
+	if r, ok := severityRank[s]; ok {
+		return r
+	}
+	return len(severityRank) + 1
+}
+
+// Finding is a single issue a scanner plugin reported against an image.
+type Finding struct {
+	ID          string
+	Severity    Severity
+	Package     string
+	Version     string
+	Description string
+}
+
+// Request is what Engine sends a Scanner to describe the image being
+// scanned.
+type Request struct {
+	// Ref is the reference the image was pulled or built as, for the
+	// plugin's own logging; it is not otherwise interpreted.
+	Ref string
+	// Layers are the image's layer content digests (its RootFS diff IDs),
+	// base layer first.
+	Layers []digest.Digest
+	// Config is the image's raw JSON config (its image.Image encoding).
+	Config []byte
+}
+
+// Scanner is the pluggable interface a vulnerability scanner implements.
+type Scanner interface {
+	Scan(ctx context.Context, req Request) ([]Finding, error)
+}
+
+// execScanner runs an external command as a Scanner: the request is
+// written to the command's stdin as JSON, and its stdout is parsed as a
+// JSON array of Finding.
+type execScanner struct {
+	command string
+}
+
+// NewExecScanner returns a Scanner that runs command, passing it the scan
+// Request as JSON on stdin and expecting a JSON array of Finding on
+// stdout.
+func NewExecScanner(command string) Scanner {
+	return &execScanner{command: command}
+}
+
+func (s *execScanner) Scan(ctx context.Context, req Request) ([]Finding, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, s.command)
+	cmd.Stdin = bytes.NewReader(input)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("vulnerability scan command failed: %w", err)
+	}
+
+	var findings []Finding
+	if err := json.Unmarshal(out, &findings); err != nil {
+		return nil, fmt.Errorf("vulnerability scan command returned invalid output: %w", err)
+	}
+	return findings, nil
+}
+
+// Result is the outcome of scanning one image, recorded by Engine so it
+// can be attached to that image's metadata and consulted before running
+// it.
+type Result struct {
+	Findings  []Finding
+	Blocked   bool
+	Reason    string
+	ScannedAt time.Time
+}
+
+// Engine runs a Scanner against pulled/built images and enforces a
+// severity policy against what it reports. Results are kept in memory
+// only, keyed by image ID; they do not survive a daemon restart, so an
+// image pulled or built before the daemon last started has no Result
+// until it is scanned again.
+type Engine struct {
+	mu            sync.RWMutex
+	scanner       Scanner
+	blockSeverity Severity
+	results       map[string]Result
+}
+
+// NewEngine returns an Engine that runs scanner and blocks on any Finding
+// at or above blockSeverity. blockSeverity == "" means findings are
+// recorded but never block anything.
+func NewEngine(scanner Scanner, blockSeverity Severity) *Engine {
+	return &Engine{
+		scanner:       scanner,
+		blockSeverity: blockSeverity,
+		results:       make(map[string]Result),
+	}
+}
+
+// Scan runs the configured Scanner against req, records and returns the
+// Result for imageID.
+func (e *Engine) Scan(ctx context.Context, imageID string, req Request) (Result, error) {
+	findings, err := e.scanner.Scan(ctx, req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Findings: findings, ScannedAt: time.Now().UTC()}
+	if e.blockSeverity != "" {
+		for _, f := range findings {
+			if f.Severity.rank() >= e.blockSeverity.rank() {
+				result.Blocked = true
+				result.Reason = fmt.Sprintf("finding %s (severity %s) is at or above the configured block severity %s", f.ID, f.Severity, e.blockSeverity)
+				break
+			}
+		}
+	}
+
+	e.mu.Lock()
+	e.results[imageID] = result
+	e.mu.Unlock()
+	return result, nil
+}
+
+// Result returns the most recent scan Result recorded for imageID, if
+// any.
+func (e *Engine) Result(imageID string) (Result, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	r, ok := e.results[imageID]
+	return r, ok
+}
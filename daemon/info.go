@@ -1,6 +1,7 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/docker/docker/cli/debug"
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/distribution"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/parsers/kernel"
@@ -84,6 +86,21 @@ func (daemon *Daemon) SystemInfo() *types.Info {
 		v.Warnings = append(v.Warnings, fmt.Sprintf("Configured default runtime %q is deprecated and will be removed in the next release.", config.LinuxV1RuntimeName))
 	}
 
+	v.Provisioning = daemon.provisioningStatus
+
+	if plugins, err := daemon.SystemContainerdInfo(context.Background()); err != nil {
+		logrus.WithError(err).Debug("Could not query containerd plugin list for system info")
+	} else {
+		v.ContainerdPlugins = plugins
+	}
+
+	v.RegistryRateLimits = distribution.RegistryRateLimits()
+	for host, rl := range v.RegistryRateLimits {
+		registryRateLimitRemaining.WithValues(host).Set(float64(rl.Remaining))
+	}
+
+	v.UserlandProxies = daemon.userlandProxyUsage()
+
 	return v
 }
 
@@ -143,10 +160,19 @@ func (daemon *Daemon) fillClusterInfo(v *types.Info) {
 
 func (daemon *Daemon) fillDriverInfo(v *types.Info) {
 	var ds [][2]string
+	var dh [][2]string
 	drivers := ""
 	statuses := daemon.imageService.LayerStoreStatus()
+	health := daemon.imageService.LayerStoreHealthCheck()
 	for os, gd := range daemon.graphDrivers {
 		ds = append(ds, statuses[os]...)
+		for _, res := range health[os] {
+			status := "OK"
+			if !res.OK {
+				status = "FAIL: " + res.Detail
+			}
+			dh = append(dh, [2]string{res.Name, status})
+		}
 		drivers += gd
 		if len(daemon.graphDrivers) > 1 {
 			drivers += fmt.Sprintf(" (%s) ", os)
@@ -160,10 +186,27 @@ func (daemon *Daemon) fillDriverInfo(v *types.Info) {
 
 	v.Driver = drivers
 	v.DriverStatus = ds
+	v.DriverHealth = dh
 
 	fillDriverWarnings(v)
 }
 
+// checkDriverHealth runs each layer store's storage driver self-test (for
+// drivers that implement one) and returns an error describing the first
+// failure found. It is used at startup to optionally hard-fail when
+// --storage-driver-health-check-fail-fast is set, so a misconfigured
+// backing filesystem is caught before any container is created.
+func (daemon *Daemon) checkDriverHealth() error {
+	for os, results := range daemon.imageService.LayerStoreHealthCheck() {
+		for _, res := range results {
+			if !res.OK {
+				return fmt.Errorf("storage driver health check failed for %s (%s): %s", os, res.Name, res.Detail)
+			}
+		}
+	}
+	return nil
+}
+
 func (daemon *Daemon) fillPluginsInfo(v *types.Info) {
 	v.Plugins = types.PluginsInfo{
 		Volume:  daemon.volumes.GetDriverList(),
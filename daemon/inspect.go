@@ -1,6 +1,7 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
+	"github.com/sirupsen/logrus"
 )
 
 // ContainerInspect returns low-level information about a
@@ -37,6 +39,58 @@ func (daemon *Daemon) ContainerInspectCurrent(name string, size bool) (*types.Co
 		return nil, err
 	}
 
+	return daemon.containerJSON(ctr, size)
+}
+
+// ContainerInspectAll returns low-level information about every container
+// named in names, or about every container if names is empty, all computed
+// from a single consistent snapshot of the container store rather than one
+// independent read per container. Containers removed between the snapshot
+// and the per-container read are silently omitted rather than causing the
+// whole call to fail.
+func (daemon *Daemon) ContainerInspectAll(names []string, size bool) ([]*types.ContainerJSON, error) {
+	view := daemon.containersReplica.Snapshot()
+
+	var snapshots []container.Snapshot
+	if len(names) == 0 {
+		all, err := view.All()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = all
+	} else {
+		snapshots = make([]container.Snapshot, 0, len(names))
+		for _, name := range names {
+			id, err := view.GetID(name)
+			if err != nil {
+				continue
+			}
+			snapshot, err := view.Get(id)
+			if err != nil {
+				continue
+			}
+			snapshots = append(snapshots, *snapshot)
+		}
+	}
+
+	results := make([]*types.ContainerJSON, 0, len(snapshots))
+	for i := range snapshots {
+		ctr := daemon.containers.Get(snapshots[i].ID)
+		if ctr == nil {
+			// removed since the snapshot was taken; skip it rather than error
+			continue
+		}
+		json, err := daemon.containerJSON(ctr, size)
+		if err != nil {
+			continue
+		}
+		results = append(results, json)
+	}
+	return results, nil
+}
+
+// containerJSON builds the full inspect payload for a single live container.
+func (daemon *Daemon) containerJSON(ctr *container.Container, size bool) (*types.ContainerJSON, error) {
 	ctr.Lock()
 
 	base, err := daemon.getInspectData(ctr)
@@ -203,6 +257,25 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 		contJSONBase.GraphDriver.Data = graphDriverData
 	}
 
+	if container.State.Running {
+		if info, err := daemon.containerd.RuntimeInfo(context.Background(), container.ID); err != nil {
+			logrus.WithError(err).WithField("container", container.ID).Debug("failed to retrieve runtime info for inspect")
+		} else {
+			events := make([]types.ContainerRuntimeEvent, 0, len(container.RuntimeEvents))
+			for _, e := range container.RuntimeEvents {
+				events = append(events, types.ContainerRuntimeEvent{Type: e.Type, Time: e.Time})
+			}
+			contJSONBase.Runtime = &types.ContainerRuntimeInfo{
+				BundlePath:  info.BundlePath,
+				Pid:         info.Pid,
+				Status:      string(info.Status),
+				CgroupsPath: info.CgroupsPath,
+				Annotations: info.Annotations,
+				Events:      events,
+			}
+		}
+	}
+
 	return contJSONBase, nil
 }
 
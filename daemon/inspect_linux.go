@@ -3,9 +3,12 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
+	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/versions/v1p19"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/oci/caps"
+	"github.com/sirupsen/logrus"
 )
 
 // This sets platform-specific fields
@@ -14,10 +17,25 @@ func setPlatformSpecificContainerFields(container *container.Container, contJSON
 	contJSONBase.ResolvConfPath = container.ResolvConfPath
 	contJSONBase.HostnamePath = container.HostnamePath
 	contJSONBase.HostsPath = container.HostsPath
+	contJSONBase.CapabilityAudit = capabilityAudit(container.HostConfig)
 
 	return contJSONBase
 }
 
+func capabilityAudit(hostConfig *containertypes.HostConfig) *types.CapabilityAudit {
+	granted, err := caps.TweakCapabilities(caps.DefaultCapabilities(), hostConfig.CapAdd, hostConfig.CapDrop, hostConfig.Privileged)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to compute effective capability set for inspect")
+		return nil
+	}
+	return &types.CapabilityAudit{
+		Granted: granted,
+		Note: "Granted is the configured capability set, not a usage-derived suggestion: telling which of " +
+			"these the container actually exercises needs kprobe/eBPF instrumentation this daemon doesn't " +
+			"have, so review and trim CapAdd/CapDrop manually.",
+	}
+}
+
 // containerInspectPre120 gets containers for pre 1.20 APIs.
 func (daemon *Daemon) containerInspectPre120(name string) (*v1p19.ContainerJSON, error) {
 	ctr, err := daemon.GetContainer(name)
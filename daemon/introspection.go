@@ -0,0 +1,38 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// SystemContainerdInfo queries containerd's introspection API for the list
+// of plugins (snapshotters, runtimes, content stores, ...) it has loaded,
+// including any that failed to initialize, so operators can diagnose a
+// missing overlay or btrfs snapshotter without having to shell into the
+// containerd socket directly.
+func (daemon *Daemon) SystemContainerdInfo(ctx context.Context) ([]types.ContainerdPlugin, error) {
+	if daemon.containerdCli == nil {
+		return nil, errors.New("containerd introspection is not available")
+	}
+
+	resp, err := daemon.containerdCli.IntrospectionService().Plugins(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying containerd plugins")
+	}
+
+	plugins := make([]types.ContainerdPlugin, 0, len(resp.Plugins))
+	for _, p := range resp.Plugins {
+		plugin := types.ContainerdPlugin{
+			Type:     p.Type,
+			ID:       p.ID,
+			Requires: p.Requires,
+		}
+		if p.InitErr != nil {
+			plugin.InitErr = p.InitErr.Message
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
@@ -0,0 +1,85 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLifecycleHookTimeout bounds how long a LifecycleHook is allowed to
+// run when its Timeout field is unset.
+const defaultLifecycleHookTimeout = 30 * time.Second
+
+// runLifecycleHook runs hook's command inside cntr, using the same exec
+// machinery as CMD healthchecks. event identifies the lifecycle transition
+// for logging ("post-start" or "pre-stop"). If hook is nil or has no
+// command, runLifecycleHook is a no-op. Failures are only returned as an
+// error when hook's FailurePolicy is LifecycleHookFailurePolicyFail;
+// otherwise they're logged and swallowed.
+func (daemon *Daemon) runLifecycleHook(cntr *container.Container, event string, hook *containertypes.LifecycleHook) error {
+	if hook == nil || len(hook.Exec) == 0 {
+		return nil
+	}
+
+	timeout := defaultLifecycleHookTimeout
+	if hook.Timeout > 0 {
+		timeout = time.Duration(hook.Timeout) * time.Second
+	}
+
+	execConfig := exec.NewConfig()
+	execConfig.OpenStdin = false
+	execConfig.OpenStdout = true
+	execConfig.OpenStderr = true
+	execConfig.ContainerID = cntr.ID
+	execConfig.DetachKeys = []byte{}
+	execConfig.Entrypoint = hook.Exec[0]
+	execConfig.Args = hook.Exec[1:]
+	execConfig.Tty = false
+	execConfig.Privileged = false
+	execConfig.User = cntr.Config.User
+	execConfig.WorkingDir = cntr.Config.WorkingDir
+
+	linkedEnv, err := daemon.setupLinkedContainers(cntr)
+	if err != nil {
+		return err
+	}
+	execConfig.Env = container.ReplaceOrAppendEnvValues(cntr.CreateDaemonEnvironment(execConfig.Tty, linkedEnv), execConfig.Env)
+
+	daemon.registerExecCommand(cntr, execConfig)
+	daemon.LogContainerEvent(cntr, event+"-hook: "+execConfig.Entrypoint+" "+strings.Join(execConfig.Args, " "))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	runErr := daemon.ContainerExecStart(ctx, execConfig.ID, nil, &output, &output)
+
+	var failure error
+	switch {
+	case runErr != nil:
+		failure = runErr
+	default:
+		if info, err := daemon.getExecConfig(execConfig.ID); err == nil && info.ExitCode != nil && *info.ExitCode != 0 {
+			failure = errors.Errorf("exited with code %d: %s", *info.ExitCode, output.String())
+		}
+	}
+
+	if failure == nil {
+		return nil
+	}
+
+	if hook.FailurePolicy != containertypes.LifecycleHookFailurePolicyFail {
+		logrus.WithError(failure).WithField("container", cntr.ID).Warnf("%s lifecycle hook failed, ignoring", event)
+		return nil
+	}
+
+	return errdefs.System(errors.Wrapf(failure, "%s lifecycle hook failed for container %s", event, cntr.ID))
+}
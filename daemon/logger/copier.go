@@ -25,11 +25,12 @@ const (
 // Writes are concurrent, so you need implement some sync in your logger.
 type Copier struct {
 	// srcs is map of name -> reader pairs, for example "stdout", "stderr"
-	srcs      map[string]io.Reader
-	dst       Logger
-	copyJobs  sync.WaitGroup
-	closeOnce sync.Once
-	closed    chan struct{}
+	srcs       map[string]io.Reader
+	dst        Logger
+	extraAttrs []LogAttr
+	copyJobs   sync.WaitGroup
+	closeOnce  sync.Once
+	closed     chan struct{}
 }
 
 // NewCopier creates a new Copier
@@ -41,6 +42,14 @@ func NewCopier(srcs map[string]io.Reader, dst Logger) *Copier {
 	}
 }
 
+// SetExtraAttrs sets a fixed set of attributes that the Copier merges into
+// every message's Attrs before handing it to dst. This runs ahead of, and
+// independent of, any attribute handling an individual log driver does for
+// itself (e.g. the json-file driver's own "labels"/"env" options).
+func (c *Copier) SetExtraAttrs(attrs []LogAttr) {
+	c.extraAttrs = attrs
+}
+
 // Run starts logs copying
 func (c *Copier) Run() {
 	for src, w := range c.srcs {
@@ -49,6 +58,17 @@ func (c *Copier) Run() {
 	}
 }
 
+// newMessage returns a Message from the pool, pre-populated with name as
+// its source and, if set, the Copier's extra attributes.
+func (c *Copier) newMessage(name string) *Message {
+	msg := NewMessage()
+	msg.Source = name
+	if len(c.extraAttrs) > 0 {
+		msg.Attrs = append(msg.Attrs, c.extraAttrs...)
+	}
+	return msg
+}
+
 func (c *Copier) copySrc(name string, src io.Reader) {
 	defer c.copyJobs.Done()
 
@@ -101,8 +121,7 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 				case <-c.closed:
 					return
 				default:
-					msg := NewMessage()
-					msg.Source = name
+					msg := c.newMessage(name)
 					msg.Line = append(msg.Line, buf[p:p+q]...)
 
 					if hasMorePartial {
@@ -132,8 +151,7 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 			// noting that it's a partial log line.
 			if eof || (p == 0 && n == len(buf)) {
 				if p < n {
-					msg := NewMessage()
-					msg.Source = name
+					msg := c.newMessage(name)
 					msg.Line = append(msg.Line, buf[p:n]...)
 
 					// Generate unique partialID for first partial. Use it across partials.
@@ -0,0 +1,55 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/docker/docker/daemon/logger/templates"
+)
+
+// attrsTemplateOpt is the log option, honored for every log driver, that
+// carries the attrs-template below. It is intentionally driver agnostic:
+// unlike "labels"/"env", which each driver reads and formats for itself,
+// the attributes it produces are attached to Message.Attrs by the Copier
+// before the message ever reaches a driver.
+const attrsTemplateOpt = "attrs-template"
+
+// ParseAttrsTemplate renders the attrs-template log option, if set, against
+// info and returns the resulting attributes to merge into every message
+// copied for the container. The template must produce a comma-separated
+// list of key=value pairs, for example:
+//
+//	node={{.NodeName}},project={{index .ContainerLabels "com.docker.compose.project"}}
+//
+// This gives daemon and container configuration a way to inject or
+// override structured metadata (node name, compose project, or anything
+// else derivable from Info) ahead of delivery to any log driver, including
+// ones that have no opinion of their own about extra attributes.
+func ParseAttrsTemplate(info Info) ([]LogAttr, error) {
+	tmplString := info.Config[attrsTemplateOpt]
+	if tmplString == "" {
+		return nil, nil
+	}
+
+	tmpl, err := templates.NewParse("log-attrs-template", tmplString)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, &info); err != nil {
+		return nil, err
+	}
+
+	var attrs []LogAttr
+	for _, kv := range strings.Split(buf.String(), ",") {
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		attrs = append(attrs, LogAttr{Key: parts[0], Value: parts[1]})
+	}
+	return attrs, nil
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/daemon/logger/templates"
 	"github.com/docker/docker/pkg/plugingetter"
 	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
@@ -127,6 +128,7 @@ func GetLogDriver(name string) (Creator, error) {
 var builtInLogOpts = map[string]bool{
 	"mode":            true,
 	"max-buffer-size": true,
+	"attrs-template":  true,
 }
 
 // ValidateLogOpts checks the options for the given log driver. The
@@ -151,6 +153,12 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		}
 	}
 
+	if s, ok := cfg["attrs-template"]; ok {
+		if _, err := templates.NewParse("log-attrs-template", s); err != nil {
+			return errors.Wrap(err, "error parsing option attrs-template")
+		}
+	}
+
 	if err := validateExternal(cfg); err != nil {
 		return err
 	}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog/jsonlog"
@@ -77,6 +78,34 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
+	var maxAge time.Duration
+	if maxAgeString, ok := info.Config["max-age"]; ok {
+		if capval == -1 {
+			return nil, fmt.Errorf("max-age cannot be set when max-size is not set")
+		}
+		var err error
+		maxAge, err = time.ParseDuration(maxAgeString)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max-age")
+		}
+		if maxAge <= 0 {
+			return nil, fmt.Errorf("max-age must be a positive duration")
+		}
+	}
+
+	if compressType, ok := info.Config["compress-type"]; ok {
+		if !compress {
+			return nil, fmt.Errorf("compress-type cannot be set unless compress is true")
+		}
+		switch compressType {
+		case "gzip":
+		case "zstd":
+			return nil, fmt.Errorf("compress-type=zstd is not supported by this build of the json-file log driver")
+		default:
+			return nil, fmt.Errorf("unknown compress-type %q, supported values are: gzip", compressType)
+		}
+	}
+
 	attrs, err := info.ExtraAttributes(nil)
 	if err != nil {
 		return nil, err
@@ -110,7 +139,7 @@ func New(info logger.Info) (logger.Logger, error) {
 		return b, nil
 	}
 
-	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, marshalFunc, decodeFunc, 0640, getTailReader)
+	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, maxAge, marshalFunc, decodeFunc, 0640, getTailReader)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +183,9 @@ func ValidateLogOpt(cfg map[string]string) error {
 		switch key {
 		case "max-file":
 		case "max-size":
+		case "max-age":
 		case "compress":
+		case "compress-type":
 		case "labels":
 		case "labels-regex":
 		case "env":
@@ -167,6 +198,14 @@ func ValidateLogOpt(cfg map[string]string) error {
 	return nil
 }
 
+// Rotate forces an immediate rotation of the log file, independent of the
+// configured max-size/max-age triggers.
+func (l *JSONFileLogger) Rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writer.Rotate()
+}
+
 // Close closes underlying file and signals all the readers
 // that the logs producer is gone.
 func (l *JSONFileLogger) Close() error {
@@ -46,6 +46,10 @@ func PutMessage(msg *Message) {
 // container.  The Line member is a slice of an array whose contents can be
 // changed after a log driver's Log() method returns.
 //
+// LogAttr is an alias of backend.LogAttr, kept here so callers that only
+// need the logger package don't also have to import api/types/backend.
+type LogAttr = backend.LogAttr
+
 // Message is subtyped from backend.LogMessage because there is a lot of
 // internal complexity around the Message type that should not be exposed
 // to any package not explicitly importing the logger type.
@@ -85,6 +89,14 @@ type SizedLogger interface {
 	BufSize() int
 }
 
+// LogRotater is the interface for logging drivers that support triggering a
+// log file rotation on demand, independent of whatever size/age based
+// rotation policy the driver is configured with.
+type LogRotater interface {
+	Logger
+	Rotate() error
+}
+
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
 	Since  time.Time
@@ -80,13 +80,15 @@ type LogFile struct {
 	mu              sync.RWMutex // protects the logfile access
 	f               *os.File     // store for closing
 	closed          bool
-	rotateMu        sync.Mutex // blocks the next rotation until the current rotation is completed
-	capacity        int64      // maximum size of each file
-	currentSize     int64      // current size of the latest file
-	maxFiles        int        // maximum number of files
-	compress        bool       // whether old versions of log files are compressed
-	lastTimestamp   time.Time  // timestamp of the last log
-	filesRefCounter refCounter // keep reference-counted of decompressed files
+	rotateMu        sync.Mutex    // blocks the next rotation until the current rotation is completed
+	capacity        int64         // maximum size of each file
+	currentSize     int64         // current size of the latest file
+	maxFiles        int           // maximum number of files
+	compress        bool          // whether old versions of log files are compressed
+	maxAge          time.Duration // maximum age of the latest file before it is rotated, 0 disables
+	lastRotation    time.Time     // time the current file was opened/rotated
+	lastTimestamp   time.Time     // timestamp of the last log
+	filesRefCounter refCounter    // keep reference-counted of decompressed files
 	notifyRotate    *pubsub.Publisher
 	marshal         logger.MarshalFunc
 	createDecoder   MakeDecoderFn
@@ -123,7 +125,11 @@ type SizeReaderAt interface {
 type GetTailReaderFunc func(ctx context.Context, f SizeReaderAt, nLogLines int) (rdr io.Reader, nLines int, err error)
 
 // NewLogFile creates new LogFile
-func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, marshaller logger.MarshalFunc, decodeFunc MakeDecoderFn, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
+//
+// maxAge, when non-zero, causes a rotation once the current file has been
+// open for longer than maxAge, independent of the size-based capacity
+// check.
+func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, maxAge time.Duration, marshaller logger.MarshalFunc, decodeFunc MakeDecoderFn, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
 	log, err := openFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perms)
 	if err != nil {
 		return nil, err
@@ -140,6 +146,8 @@ func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, mar
 		currentSize:     size,
 		maxFiles:        maxFiles,
 		compress:        compress,
+		maxAge:          maxAge,
+		lastRotation:    time.Now(),
 		filesRefCounter: refCounter{counter: make(map[string]int)},
 		notifyRotate:    pubsub.NewPublisher(0, 1),
 		marshal:         marshaller,
@@ -180,44 +188,66 @@ func (w *LogFile) WriteLogEntry(msg *logger.Message) error {
 }
 
 func (w *LogFile) checkCapacityAndRotate() error {
-	if w.capacity == -1 {
+	needsRotation := false
+	if w.capacity != -1 && w.currentSize >= w.capacity {
+		needsRotation = true
+	}
+	if w.maxAge != 0 && time.Since(w.lastRotation) >= w.maxAge {
+		needsRotation = true
+	}
+	if !needsRotation {
 		return nil
 	}
+	return w.doRotate()
+}
 
-	if w.currentSize >= w.capacity {
-		w.rotateMu.Lock()
-		fname := w.f.Name()
-		if err := w.f.Close(); err != nil {
-			// if there was an error during a prior rotate, the file could already be closed
-			if !errors.Is(err, os.ErrClosed) {
-				w.rotateMu.Unlock()
-				return errors.Wrap(err, "error closing file")
-			}
-		}
-		if err := rotate(fname, w.maxFiles, w.compress); err != nil {
-			w.rotateMu.Unlock()
-			return err
-		}
-		file, err := openFile(fname, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, w.perms)
-		if err != nil {
-			w.rotateMu.Unlock()
-			return err
-		}
-		w.f = file
-		w.currentSize = 0
-		w.notifyRotate.Publish(struct{}{})
+// Rotate forces an immediate rotation of the current log file, independent
+// of the configured size or age based rotation triggers. It is a no-op if
+// the current file is empty.
+func (w *LogFile) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.currentSize == 0 {
+		return nil
+	}
+	return w.doRotate()
+}
 
-		if w.maxFiles <= 1 || !w.compress {
+// doRotate performs the actual file rotation. The caller must hold w.mu.
+func (w *LogFile) doRotate() error {
+	w.rotateMu.Lock()
+	fname := w.f.Name()
+	if err := w.f.Close(); err != nil {
+		// if there was an error during a prior rotate, the file could already be closed
+		if !errors.Is(err, os.ErrClosed) {
 			w.rotateMu.Unlock()
-			return nil
+			return errors.Wrap(err, "error closing file")
 		}
+	}
+	if err := rotate(fname, w.maxFiles, w.compress); err != nil {
+		w.rotateMu.Unlock()
+		return err
+	}
+	file, err := openFile(fname, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, w.perms)
+	if err != nil {
+		w.rotateMu.Unlock()
+		return err
+	}
+	w.f = file
+	w.currentSize = 0
+	w.lastRotation = time.Now()
+	w.notifyRotate.Publish(struct{}{})
 
-		go func() {
-			compressFile(fname+".1", w.lastTimestamp)
-			w.rotateMu.Unlock()
-		}()
+	if w.maxFiles <= 1 || !w.compress {
+		w.rotateMu.Unlock()
+		return nil
 	}
 
+	go func() {
+		compressFile(fname+".1", w.lastTimestamp)
+		w.rotateMu.Unlock()
+	}()
+
 	return nil
 }
 
@@ -22,6 +22,7 @@ type Info struct {
 	ContainerLabels     map[string]string
 	LogPath             string
 	DaemonName          string
+	NodeName            string
 }
 
 // ExtraAttributes returns the user-defined extra attributes (labels,
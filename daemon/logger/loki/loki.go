@@ -0,0 +1,339 @@
+// Package loki provides the log driver for forwarding container logs to a
+// Grafana Loki push API endpoint.
+package loki // import "github.com/docker/docker/daemon/logger/loki"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
+	"github.com/docker/docker/pkg/urlutil"
+	units "github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	driverName = "loki"
+
+	urlKey         = "loki-url"
+	tenantIDKey    = "loki-tenant-id"
+	batchWaitKey   = "loki-batch-wait"
+	batchSizeKey   = "loki-batch-size"
+	retriesKey     = "loki-retries"
+	timeoutKey     = "loki-timeout"
+	envKey         = "env"
+	envRegexKey    = "env-regex"
+	labelsKey      = "labels"
+	labelsRegexKey = "labels-regex"
+	tagKey         = "tag"
+)
+
+const (
+	defaultBatchWait = 1 * time.Second
+	defaultBatchSize = 100 * 1024
+	defaultRetries   = 10
+	defaultTimeout   = 10 * time.Second
+
+	// maxResponseSize is the max amount that will be read from an error response.
+	maxResponseSize = 4 * 1024
+
+	// retryBaseDelay is the initial delay used for the exponential backoff
+	// between retries of a failed batch push.
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the exponential backoff delay.
+	retryMaxDelay = 30 * time.Second
+)
+
+func init() {
+	if err := logger.RegisterLogDriver(driverName, New); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(driverName, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+type entry struct {
+	ts   time.Time
+	line string
+}
+
+type lokiLogger struct {
+	client   *http.Client
+	url      string
+	tenantID string
+	labels   map[string]string
+	timeout  time.Duration
+	retries  int
+
+	batchWait time.Duration
+	batchSize int
+
+	stream     chan *entry
+	lock       sync.RWMutex
+	closed     bool
+	closedCond *sync.Cond
+}
+
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// New creates a loki logger that batches log lines and pushes them to a
+// Loki push API endpoint (POST /loki/api/v1/push).
+func New(info logger.Info) (logger.Logger, error) {
+	lokiURL, ok := info.Config[urlKey]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is required", driverName, urlKey)
+	}
+	if !urlutil.IsURL(lokiURL) {
+		return nil, fmt.Errorf("%s: %s must be a valid URL", driverName, urlKey)
+	}
+
+	batchWait := defaultBatchWait
+	if s, ok := info.Config[batchWaitKey]; ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s: %v", driverName, batchWaitKey, err)
+		}
+		batchWait = d
+	}
+
+	batchSize := defaultBatchSize
+	if s, ok := info.Config[batchSizeKey]; ok {
+		size, err := units.RAMInBytes(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s: %v", driverName, batchSizeKey, err)
+		}
+		batchSize = int(size)
+	}
+
+	retries := defaultRetries
+	if s, ok := info.Config[retriesKey]; ok {
+		r, err := strconv.Atoi(s)
+		if err != nil || r < 0 {
+			return nil, fmt.Errorf("%s: invalid %s: must be a non-negative integer", driverName, retriesKey)
+		}
+		retries = r
+	}
+
+	timeout := defaultTimeout
+	if s, ok := info.Config[timeoutKey]; ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s: %v", driverName, timeoutKey, err)
+		}
+		timeout = d
+	}
+
+	labels, err := info.ExtraAttributes(nil)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels["container_name"] = info.Name()
+	labels["container_id"] = info.ID()
+
+	// Allow users to remove the tag from stream labels by setting tag to an
+	// empty string.
+	if tagTemplate, ok := info.Config[tagKey]; !ok || tagTemplate != "" {
+		labels["tag"], err = loggerutils.ParseLogTag(info, loggerutils.DefaultTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l := &lokiLogger{
+		client:    &http.Client{Timeout: timeout},
+		url:       lokiURL,
+		tenantID:  info.Config[tenantIDKey],
+		labels:    labels,
+		timeout:   timeout,
+		retries:   retries,
+		batchWait: batchWait,
+		batchSize: batchSize,
+		stream:    make(chan *entry, 4*1024),
+	}
+
+	go l.worker()
+
+	return l, nil
+}
+
+func (l *lokiLogger) Log(msg *logger.Message) error {
+	e := &entry{ts: msg.Timestamp, line: string(msg.Line)}
+	logger.PutMessage(msg)
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.closedCond != nil {
+		return fmt.Errorf("%s: driver is closed", driverName)
+	}
+	l.stream <- e
+	return nil
+}
+
+func (l *lokiLogger) worker() {
+	timer := time.NewTicker(l.batchWait)
+	defer timer.Stop()
+
+	var batch []*entry
+	var batchBytes int
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		l.pushWithRetry(batch)
+		batch = nil
+		batchBytes = 0
+	}
+
+	for {
+		select {
+		case e, open := <-l.stream:
+			if !open {
+				flush()
+				l.lock.Lock()
+				l.closed = true
+				l.closedCond.Signal()
+				l.lock.Unlock()
+				return
+			}
+			batch = append(batch, e)
+			batchBytes += len(e.line)
+			if batchBytes >= l.batchSize {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		}
+	}
+}
+
+// pushWithRetry attempts to push a batch to Loki, retrying with exponential
+// backoff on failure. If every attempt fails, the batch is logged to the
+// daemon log and dropped.
+func (l *lokiLogger) pushWithRetry(batch []*entry) {
+	delay := retryBaseDelay
+	var lastErr error
+	for attempt := 0; attempt <= l.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay + time.Duration(rand.Int63n(int64(delay)/2+1)))
+			delay *= 2
+			if delay > retryMaxDelay {
+				delay = retryMaxDelay
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+		err := l.push(ctx, batch)
+		cancel()
+		if err == nil {
+			return
+		}
+		lastErr = err
+	}
+	logrus.WithError(lastErr).WithField("module", "logger/loki").
+		Errorf("failed to push %d log lines to loki after %d attempts, dropping them", len(batch), l.retries+1)
+}
+
+func (l *lokiLogger) push(ctx context.Context, batch []*entry) error {
+	values := make([][2]string, len(batch))
+	for i, e := range batch {
+		values[i] = [2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line}
+	}
+
+	body, err := json.Marshal(&pushRequest{
+		Streams: []stream{
+			{Stream: l.labels, Values: values},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, l.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if l.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", l.tenantID)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		rdr := io.LimitReader(resp.Body, maxResponseSize)
+		respBody, _ := ioutil.ReadAll(rdr)
+		return fmt.Errorf("%s: server returned HTTP status %s: %s", driverName, resp.Status, string(respBody))
+	}
+	return nil
+}
+
+func (l *lokiLogger) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closedCond == nil {
+		l.closedCond = sync.NewCond(&l.lock)
+		close(l.stream)
+		for !l.closed {
+			l.closedCond.Wait()
+		}
+	}
+	return nil
+}
+
+func (l *lokiLogger) Name() string {
+	return driverName
+}
+
+// ValidateLogOpt looks for all supported options for the loki driver.
+func ValidateLogOpt(cfg map[string]string) error {
+	for key := range cfg {
+		switch key {
+		case urlKey:
+		case tenantIDKey:
+		case batchWaitKey:
+		case batchSizeKey:
+		case retriesKey:
+		case timeoutKey:
+		case envKey:
+		case envRegexKey:
+		case labelsKey:
+		case labelsRegexKey:
+		case tagKey:
+		default:
+			return fmt.Errorf("unknown log opt '%s' for %s log driver", key, driverName)
+		}
+	}
+	if _, ok := cfg[urlKey]; !ok {
+		return fmt.Errorf("%s: %s is required", driverName, urlKey)
+	}
+	return nil
+}
@@ -55,6 +55,14 @@ func makePluginClient(p getter.CompatPlugin) (logPlugin, error) {
 		return nil, errdefs.System(errors.Errorf("got unknown plugin type %T", p))
 	}
 
+	if pa.Protocol() == plugins.ProtocolSchemeGRPCV2 {
+		// The v2 gRPC streaming protocol (reconnection, ack offsets, local
+		// ring buffer replay on plugin crash) is not implemented by this
+		// build of the daemon; only its protocol scheme is reserved here so
+		// plugins can already advertise intent to use it.
+		return nil, errdefs.NotImplemented(errors.Errorf("log plugin protocol %q is not supported by this build of the daemon", pa.Protocol()))
+	}
+
 	if pa.Protocol() != plugins.ProtocolSchemeHTTPV1 {
 		return nil, errors.Errorf("plugin protocol not supported: %s", p)
 	}
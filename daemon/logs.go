@@ -160,6 +160,35 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 	return messageChan, ctr.Config.Tty, nil
 }
 
+// ContainerLogsRotate triggers an immediate log file rotation for the given
+// container, independent of its configured size/age based rotation policy.
+// It returns an error if the container's log driver does not support
+// on-demand rotation.
+func (daemon *Daemon) ContainerLogsRotate(containerName string) error {
+	ctr, err := daemon.GetContainer(containerName)
+	if err != nil {
+		return err
+	}
+
+	cLog, cLogCreated, err := daemon.getLogger(ctr)
+	if err != nil {
+		return err
+	}
+	if cLogCreated {
+		defer func() {
+			if err := cLog.Close(); err != nil {
+				logrus.Errorf("Error closing logger: %v", err)
+			}
+		}()
+	}
+
+	rotater, ok := cLog.(logger.LogRotater)
+	if !ok {
+		return errdefs.InvalidParameter(errors.Errorf("the %q logging driver does not support on-demand log rotation", ctr.HostConfig.LogConfig.Type))
+	}
+	return rotater.Rotate()
+}
+
 func (daemon *Daemon) getLogger(container *container.Container) (l logger.Logger, created bool, err error) {
 	container.Lock()
 	if container.State.Running {
@@ -168,7 +197,7 @@ func (daemon *Daemon) getLogger(container *container.Container) (l logger.Logger
 	container.Unlock()
 	if l == nil {
 		created = true
-		l, err = container.StartLogger()
+		l, _, err = container.StartLogger()
 	}
 	return
 }
@@ -193,7 +222,23 @@ func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) err
 
 	logcache.MergeDefaultLogConfig(cfg.Config, daemon.defaultLogConfig.Config)
 
-	return logger.ValidateLogOpts(cfg.Type, cfg.Config)
+	if err := logger.ValidateLogOpts(cfg.Type, cfg.Config); err != nil {
+		return err
+	}
+
+	for _, override := range []*containertypes.LogStreamConfig{cfg.Stdout, cfg.Stderr} {
+		if override == nil {
+			continue
+		}
+		if override.Type == "" {
+			override.Type = cfg.Type
+		}
+		if err := logger.ValidateLogOpts(override.Type, override.Config); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (daemon *Daemon) setupDefaultLogConfig() error {
@@ -0,0 +1,111 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/docker/libnetwork"
+	"github.com/sirupsen/logrus"
+)
+
+// stickyAddressDrivers are the network driver types whose endpoints get
+// their IP/MAC addresses remembered across a connect/disconnect cycle. Only
+// macvlan and ipvlan lack libnetwork's own persistent IPAM reservation for
+// a removed endpoint (bridge networks release the address back to the pool
+// immediately), so a container recreated with the same name on the same
+// network would otherwise come back with a different address, which is
+// disruptive for these "looks like a physical NIC" drivers in particular.
+var stickyAddressDrivers = map[string]bool{
+	"macvlan": true,
+	"ipvlan":  true,
+}
+
+// stickyAddress is the address information remembered for one endpoint.
+type stickyAddress struct {
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+	MAC  string `json:"mac,omitempty"`
+}
+
+// stickyAddressStore persists addresses keyed by "<network id>/<endpoint
+// name>" in a single JSON file under the daemon root. It is intentionally
+// simple: a full read-modify-write under a mutex, matching the size and
+// update frequency of the data (one small record per container per
+// macvlan/ipvlan network).
+type stickyAddressStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (daemon *Daemon) stickyAddressStore() *stickyAddressStore {
+	return &stickyAddressStore{path: filepath.Join(daemon.configStore.Root, "network", "sticky-addresses.json")}
+}
+
+func (s *stickyAddressStore) load() map[string]stickyAddress {
+	addrs := map[string]stickyAddress{}
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return addrs
+	}
+	if err := json.Unmarshal(data, &addrs); err != nil {
+		logrus.Warnf("discarding unreadable sticky address store %s: %v", s.path, err)
+		return map[string]stickyAddress{}
+	}
+	return addrs
+}
+
+func (s *stickyAddressStore) save(addrs map[string]stickyAddress) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(addrs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path, data, 0600)
+}
+
+// get returns the address previously remembered for the given network and
+// endpoint, if any.
+func (s *stickyAddressStore) get(networkID, endpointName string) (stickyAddress, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addr, ok := s.load()[networkID+"/"+endpointName]
+	return addr, ok
+}
+
+// put remembers addr for the given network and endpoint, overwriting any
+// previous entry.
+func (s *stickyAddressStore) put(networkID, endpointName string, addr stickyAddress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addrs := s.load()
+	addrs[networkID+"/"+endpointName] = addr
+	if err := s.save(addrs); err != nil {
+		logrus.Warnf("failed to persist address for endpoint %s on network %s: %v", endpointName, networkID, err)
+	}
+}
+
+// rememberEndpointAddress records the address assigned to endpointName on
+// n for reuse the next time a container with the same name connects to the
+// same network, but only for drivers in stickyAddressDrivers.
+func (daemon *Daemon) rememberEndpointAddress(n libnetwork.Network, endpointName string, addr stickyAddress) {
+	if !stickyAddressDrivers[n.Type()] {
+		return
+	}
+	daemon.stickyAddressStore().put(n.ID(), endpointName, addr)
+}
+
+// stickyEndpointAddress looks up a previously remembered address for
+// endpointName on n. It only returns a value for drivers in
+// stickyAddressDrivers; other drivers manage their own address lifecycle
+// and should not have a stale address injected into their allocation.
+func (daemon *Daemon) stickyEndpointAddress(n libnetwork.Network, endpointName string) (stickyAddress, bool) {
+	if !stickyAddressDrivers[n.Type()] {
+		return stickyAddress{}, false
+	}
+	return daemon.stickyAddressStore().get(n.ID(), endpointName)
+}
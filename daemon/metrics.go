@@ -15,16 +15,19 @@ import (
 const metricsPluginType = "MetricsCollector"
 
 var (
-	containerActions          metrics.LabeledTimer
-	networkActions            metrics.LabeledTimer
-	hostInfoFunctions         metrics.LabeledTimer
-	engineInfo                metrics.LabeledGauge
-	engineCpus                metrics.Gauge
-	engineMemory              metrics.Gauge
-	healthChecksCounter       metrics.Counter
-	healthChecksFailedCounter metrics.Counter
+	containerActions           metrics.LabeledTimer
+	networkActions             metrics.LabeledTimer
+	hostInfoFunctions          metrics.LabeledTimer
+	engineInfo                 metrics.LabeledGauge
+	engineCpus                 metrics.Gauge
+	engineMemory               metrics.Gauge
+	healthChecksCounter        metrics.Counter
+	healthChecksFailedCounter  metrics.Counter
+	registryRateLimitRemaining metrics.LabeledGauge
 
 	stateCtr *stateCounter
+
+	networkTrafficCtr *networkTrafficCollector
 )
 
 func init() {
@@ -57,10 +60,14 @@ func init() {
 	engineMemory = ns.NewGauge("engine_memory", "The number of bytes of memory that the host system of the engine has", metrics.Bytes)
 	healthChecksCounter = ns.NewCounter("health_checks", "The total number of health checks")
 	healthChecksFailedCounter = ns.NewCounter("health_checks_failed", "The total number of failed health checks")
+	registryRateLimitRemaining = ns.NewLabeledGauge("registry_rate_limit_remaining", "The number of requests remaining in the current registry rate-limit window, as last reported by that registry", metrics.Unit("requests"), "registry_host")
 
 	stateCtr = newStateCounter(ns.NewDesc("container_states", "The count of containers in various states", metrics.Unit("containers"), "state"))
 	ns.Add(stateCtr)
 
+	networkTrafficCtr = newNetworkTrafficCollector()
+	ns.Add(networkTrafficCtr)
+
 	metrics.Register(ns)
 }
 
@@ -194,3 +201,70 @@ func (a *metricsPluginAdapter) StopMetrics() error {
 	}
 	return nil
 }
+
+// networkTrafficCollector exports per-container, per-network rx/tx byte and
+// packet counters, broken down by network name (see
+// daemon.(*Daemon).getNetworkStats). It is disabled unless the daemon is
+// configured with --container-network-metrics, since the container_id
+// label it adds can produce a large number of time series on a host that
+// churns through many short-lived containers.
+type networkTrafficCollector struct {
+	mu     sync.Mutex
+	daemon *Daemon
+
+	rxBytesDesc   *prometheus.Desc
+	rxPacketsDesc *prometheus.Desc
+	txBytesDesc   *prometheus.Desc
+	txPacketsDesc *prometheus.Desc
+}
+
+func newNetworkTrafficCollector() *networkTrafficCollector {
+	labels := []string{"container_id", "network"}
+	return &networkTrafficCollector{
+		rxBytesDesc:   prometheus.NewDesc("engine_daemon_container_network_received_bytes_total", "The number of bytes received by a container on a network.", labels, nil),
+		rxPacketsDesc: prometheus.NewDesc("engine_daemon_container_network_received_packets_total", "The number of packets received by a container on a network.", labels, nil),
+		txBytesDesc:   prometheus.NewDesc("engine_daemon_container_network_transmitted_bytes_total", "The number of bytes transmitted by a container on a network.", labels, nil),
+		txPacketsDesc: prometheus.NewDesc("engine_daemon_container_network_transmitted_packets_total", "The number of packets transmitted by a container on a network.", labels, nil),
+	}
+}
+
+// setDaemon wires the collector up to the running daemon. It is called once
+// the daemon is constructed, since the collector itself is registered at
+// package init time, before any *Daemon exists.
+func (c *networkTrafficCollector) setDaemon(d *Daemon) {
+	c.mu.Lock()
+	c.daemon = d
+	c.mu.Unlock()
+}
+
+func (c *networkTrafficCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rxBytesDesc
+	ch <- c.rxPacketsDesc
+	ch <- c.txBytesDesc
+	ch <- c.txPacketsDesc
+}
+
+func (c *networkTrafficCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	d := c.daemon
+	c.mu.Unlock()
+	if d == nil || !d.configStore.ContainerNetworkMetrics {
+		return
+	}
+
+	for _, ctr := range d.List() {
+		if !ctr.IsRunning() {
+			continue
+		}
+		stats, err := d.GetContainerStats(ctr)
+		if err != nil {
+			continue
+		}
+		for netName, netStats := range stats.Networks {
+			ch <- prometheus.MustNewConstMetric(c.rxBytesDesc, prometheus.CounterValue, float64(netStats.RxBytes), ctr.ID, netName)
+			ch <- prometheus.MustNewConstMetric(c.rxPacketsDesc, prometheus.CounterValue, float64(netStats.RxPackets), ctr.ID, netName)
+			ch <- prometheus.MustNewConstMetric(c.txBytesDesc, prometheus.CounterValue, float64(netStats.TxBytes), ctr.ID, netName)
+			ch <- prometheus.MustNewConstMetric(c.txPacketsDesc, prometheus.CounterValue, float64(netStats.TxPackets), ctr.ID, netName)
+		}
+	}
+}
@@ -15,6 +15,9 @@ func (daemon *Daemon) prepareMountPoints(container *container.Container) error {
 		if err := daemon.lazyInitializeVolume(container.ID, config); err != nil {
 			return err
 		}
+		if err := daemon.lazyInitializeImageMount(container.OS, config); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,154 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+	volumemounts "github.com/docker/docker/volume/mounts"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// ContainerUpdateMounts attaches or detaches bind mounts from a running
+// container's mount namespace using the same cgroup-freezer-based injection
+// `docker update` uses to add mounts, without requiring the container to be
+// recreated. It can also resize an existing tmpfs mount in place. The
+// resulting mount list is persisted to the container's host config so it
+// survives a restart.
+//
+// Only bind mounts are supported for Add/Remove: a named volume mount would
+// first need to be created and registered with the volume subsystem before
+// it could be bound in, which is out of scope for this live-update path -
+// attaching a new volume still requires recreating the container.
+func (daemon *Daemon) ContainerUpdateMounts(name string, req *container.MountsUpdateConfig) error {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if !ctr.IsRunning() || ctr.IsRestarting() {
+		return errdefs.Conflict(errors.New("container must be running to update its mounts"))
+	}
+
+	parser := volumemounts.NewParser(ctr.OS)
+
+	var toAdd []mounttypes.Mount
+	for _, m := range req.Add {
+		if m.Type != mounttypes.TypeBind {
+			return errdefs.InvalidParameter(errors.Errorf("mount %s: only bind mounts can be attached to a running container", m.Target))
+		}
+		if err := parser.ValidateMountConfig(&m); err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		if ctr.IsDestinationMounted(m.Target) {
+			return errdefs.InvalidParameter(errors.Errorf("destination %s is already mounted", m.Target))
+		}
+		toAdd = append(toAdd, m)
+	}
+
+	for _, target := range req.Remove {
+		if !ctr.IsDestinationMounted(target) {
+			return errdefs.InvalidParameter(errors.Errorf("destination %s is not mounted", target))
+		}
+	}
+
+	tmpfsSizes := make(map[string]uint64, len(req.TmpfsResize))
+	for target, sizeStr := range req.TmpfsResize {
+		if _, isClassicTmpfs := ctr.HostConfig.Tmpfs[target]; !isClassicTmpfs {
+			mp, isMountTmpfs := ctr.MountPoints[target]
+			if !isMountTmpfs || mp.Type != mounttypes.TypeTmpfs {
+				return errdefs.InvalidParameter(errors.Errorf("destination %s is not a tmpfs mount", target))
+			}
+		}
+		size, err := units.RAMInBytes(sizeStr)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrapf(err, "invalid size for tmpfs resize of %s", target))
+		}
+		tmpfsSizes[target] = uint64(size)
+	}
+
+	if len(toAdd) > 0 {
+		if err := daemon.freezeAndAddMounts(ctr, toAdd); err != nil {
+			return errdefs.System(err)
+		}
+	}
+	if len(req.Remove) > 0 {
+		if err := daemon.freezeAndRemoveMounts(ctr, req.Remove); err != nil {
+			return errdefs.System(err)
+		}
+	}
+	if len(tmpfsSizes) > 0 {
+		if err := daemon.freezeAndResizeTmpfs(ctr, tmpfsSizes); err != nil {
+			return errdefs.System(err)
+		}
+	}
+
+	ctr.Lock()
+	for _, m := range toAdd {
+		mp, err := parser.ParseMountSpec(m)
+		if err != nil {
+			// The mount is already injected; record what we have rather
+			// than lose track of it entirely.
+			mp = &volumemounts.MountPoint{Type: m.Type, Source: m.Source, Destination: m.Target, RW: !m.ReadOnly, Spec: m}
+		}
+		ctr.MountPoints[mp.Destination] = mp
+		ctr.HostConfig.Mounts = append(ctr.HostConfig.Mounts, m)
+	}
+	for _, target := range req.Remove {
+		delete(ctr.MountPoints, target)
+		var kept []mounttypes.Mount
+		for _, m := range ctr.HostConfig.Mounts {
+			if m.Target != target {
+				kept = append(kept, m)
+			}
+		}
+		ctr.HostConfig.Mounts = kept
+	}
+	for target, size := range tmpfsSizes {
+		if data, isClassicTmpfs := ctr.HostConfig.Tmpfs[target]; isClassicTmpfs {
+			ctr.HostConfig.Tmpfs[target] = setTmpfsDataSize(data, size)
+			continue
+		}
+		mp := ctr.MountPoints[target]
+		if mp.Spec.TmpfsOptions == nil {
+			mp.Spec.TmpfsOptions = &mounttypes.TmpfsOptions{}
+		}
+		mp.Spec.TmpfsOptions.SizeBytes = int64(size)
+	}
+	checkpointErr := ctr.CheckpointTo(daemon.containersReplica)
+	ctr.Unlock()
+	if checkpointErr != nil {
+		return checkpointErr
+	}
+
+	daemon.LogContainerEvent(ctr, "update-mounts")
+	return nil
+}
+
+// setTmpfsDataSize returns data, the comma-separated tmpfs mount-option
+// string used by the classic HostConfig.Tmpfs, with its "size" option
+// replaced by size (in bytes), or with a "size" option appended if it
+// didn't already have one.
+func setTmpfsDataSize(data string, size uint64) string {
+	sizeOpt := "size=" + strconv.FormatUint(size, 10)
+
+	var opts []string
+	found := false
+	if data != "" {
+		for _, opt := range strings.Split(data, ",") {
+			if strings.HasPrefix(opt, "size=") {
+				opts = append(opts, sizeOpt)
+				found = true
+				continue
+			}
+			opts = append(opts, opt)
+		}
+	}
+	if !found {
+		opts = append(opts, sizeOpt)
+	}
+	return strings.Join(opts, ",")
+}
@@ -0,0 +1,149 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// netPodRoot holds the bind-mounted network namespace files backing named
+// "pod:" network sharing groups.
+const netPodRoot = "/var/run/docker/netns/pod"
+
+var (
+	netPodMu sync.Mutex
+	netPods  = map[string]struct{}{}
+)
+
+// CreateNetworkPod creates a new network namespace that containers can
+// join with --network=pod:<name>, with a lifetime independent of any one
+// container: it's destroyed only by an explicit RemoveNetworkPod, not by
+// the start or stop of any container using it.
+//
+// The registry of known pod names is kept in memory only; a daemon
+// restart loses track of pods created before it; their netns files are
+// left behind on disk and any containers still using them keep working,
+// but RemoveNetworkPod won't find them again until CreateNetworkPod is
+// called again with the same name.
+func (daemon *Daemon) CreateNetworkPod(name string) error {
+	if name == "" {
+		return fmt.Errorf("network pod name must not be empty")
+	}
+
+	netPodMu.Lock()
+	defer netPodMu.Unlock()
+
+	if _, exists := netPods[name]; exists {
+		return fmt.Errorf("network pod %q already exists", name)
+	}
+
+	if err := os.MkdirAll(netPodRoot, 0700); err != nil {
+		return err
+	}
+
+	path := netPodPathFor(name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("creating network pod %q: %v", name, err)
+	}
+	f.Close()
+
+	if err := bindMountNewNetNS(path); err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	netPods[name] = struct{}{}
+	return nil
+}
+
+// RemoveNetworkPod destroys a network pod created by CreateNetworkPod. It
+// refuses if any running container is still using it.
+func (daemon *Daemon) RemoveNetworkPod(name string) error {
+	netPodMu.Lock()
+	if _, exists := netPods[name]; !exists {
+		netPodMu.Unlock()
+		return fmt.Errorf("network pod %q not found", name)
+	}
+	netPodMu.Unlock()
+
+	for _, ctr := range daemon.List() {
+		if ctr.IsRunning() && ctr.HostConfig.NetworkMode.IsPod() && ctr.HostConfig.NetworkMode.ConnectedPod() == name {
+			return fmt.Errorf("network pod %q is in use by container %s", name, ctr.ID)
+		}
+	}
+
+	netPodMu.Lock()
+	defer netPodMu.Unlock()
+
+	path := netPodPathFor(name)
+	if err := unix.Unmount(path, unix.MNT_DETACH); err != nil {
+		logrus.WithError(err).Warnf("failed to unmount network pod %q", name)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing network pod %q: %v", name, err)
+	}
+
+	delete(netPods, name)
+	return nil
+}
+
+// ListNetworkPods returns the names of all network pods known to this
+// daemon instance.
+func (daemon *Daemon) ListNetworkPods() []string {
+	netPodMu.Lock()
+	defer netPodMu.Unlock()
+
+	names := make([]string, 0, len(netPods))
+	for name := range netPods {
+		names = append(names, name)
+	}
+	return names
+}
+
+// netPodPath returns the filesystem path of the named pod's network
+// namespace, failing if no such pod has been created on this daemon
+// instance.
+func (daemon *Daemon) netPodPath(name string) (string, error) {
+	netPodMu.Lock()
+	defer netPodMu.Unlock()
+
+	if _, exists := netPods[name]; !exists {
+		return "", fmt.Errorf("network pod %q not found", name)
+	}
+	return netPodPathFor(name), nil
+}
+
+func netPodPathFor(name string) string {
+	return filepath.Join(netPodRoot, name)
+}
+
+// bindMountNewNetNS creates a new network namespace and bind-mounts it at
+// path so it persists independently of any process - the same technique
+// "ip netns add" uses.
+func bindMountNewNetNS(path string) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origNS, err := os.Open("/proc/self/ns/net")
+	if err != nil {
+		return err
+	}
+	defer origNS.Close()
+	defer unix.Setns(int(origNS.Fd()), unix.CLONE_NEWNET)
+
+	if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("creating network namespace: %v", err)
+	}
+
+	if err := unix.Mount("/proc/self/ns/net", path, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("binding network namespace to %s: %v", path, err)
+	}
+
+	return nil
+}
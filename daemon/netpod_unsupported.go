@@ -0,0 +1,26 @@
+//go:build !linux
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import "fmt"
+
+// CreateNetworkPod is unsupported outside Linux.
+func (daemon *Daemon) CreateNetworkPod(name string) error {
+	return fmt.Errorf("network pods are not supported on this platform")
+}
+
+// RemoveNetworkPod is unsupported outside Linux.
+func (daemon *Daemon) RemoveNetworkPod(name string) error {
+	return fmt.Errorf("network pods are not supported on this platform")
+}
+
+// ListNetworkPods is unsupported outside Linux.
+func (daemon *Daemon) ListNetworkPods() []string {
+	return nil
+}
+
+// netPodPath is unsupported outside Linux.
+func (daemon *Daemon) netPodPath(name string) (string, error) {
+	return "", fmt.Errorf("network pods are not supported on this platform")
+}
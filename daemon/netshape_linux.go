@@ -0,0 +1,122 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	containerpkg "github.com/docker/docker/container"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// shapedInterface is the container-side interface libnetwork's bridge
+// driver attaches as the endpoint's primary interface. Only this interface
+// is shaped; containers with additional networks joined after the first
+// one keep those endpoints unshaped.
+const shapedInterface = "eth0"
+
+// applyNetworkShaping enforces ctr's HostConfig egress/ingress rate limits
+// on its network sandbox using tc. It is called once after the sandbox is
+// created (on start) and again whenever the limits change via a live
+// "docker container update".
+//
+// This only covers network modes that give the container its own network
+// namespace (bridge and other libnetwork-managed modes). Host, none and
+// container-sharing network modes have no private namespace to shape and
+// are silently skipped.
+func (daemon *Daemon) applyNetworkShaping(ctr *containerpkg.Container) error {
+	if ctr.HostConfig.NetworkMode.IsHost() || ctr.HostConfig.NetworkMode.IsNone() || ctr.HostConfig.NetworkMode.IsContainer() {
+		return nil
+	}
+
+	sandboxKey := ctr.NetworkSettings.SandboxKey
+	if sandboxKey == "" {
+		return nil
+	}
+
+	egress := ctr.HostConfig.Resources.NetworkEgressRate
+	ingress := ctr.HostConfig.Resources.NetworkIngressRate
+	if egress < 0 || ingress < 0 {
+		return fmt.Errorf("network rate limits cannot be negative")
+	}
+
+	return shapeInterface(sandboxKey, shapedInterface, egress, ingress)
+}
+
+// shapeInterface enters the network namespace at nsPath and replaces (or,
+// if both rates are zero, clears) any tc qdiscs shaping iface.
+func shapeInterface(nsPath, iface string, egressRate, ingressRate int64) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/net")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(nsPath)
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("failed to enter network namespace %s: %v", nsPath, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNET)
+
+	// Clear any shaping left over from a previous update before
+	// (re)applying, since tc has no "replace rate only" operation for
+	// the egress/ingress qdisc pair used here.
+	runTC("qdisc", "del", "dev", iface, "root")
+	runTC("qdisc", "del", "dev", iface, "ingress")
+
+	if egressRate > 0 {
+		burst := egressBurst(egressRate)
+		if err := exec.Command("tc", "qdisc", "add", "dev", iface, "root", "tbf",
+			"rate", fmt.Sprintf("%dbps", egressRate),
+			"burst", fmt.Sprintf("%db", burst),
+			"latency", "50ms").Run(); err != nil {
+			return fmt.Errorf("failed to set egress rate on %s: %v", iface, err)
+		}
+	}
+
+	if ingressRate > 0 {
+		burst := egressBurst(ingressRate)
+		if err := exec.Command("tc", "qdisc", "add", "dev", iface, "handle", "ffff:", "ingress").Run(); err != nil {
+			return fmt.Errorf("failed to add ingress qdisc on %s: %v", iface, err)
+		}
+		if err := exec.Command("tc", "filter", "add", "dev", iface, "parent", "ffff:",
+			"protocol", "ip", "u32", "match", "u32", "0", "0",
+			"police", "rate", fmt.Sprintf("%dbps", ingressRate),
+			"burst", fmt.Sprintf("%db", burst), "drop", "flowid", ":1").Run(); err != nil {
+			return fmt.Errorf("failed to set ingress rate on %s: %v", iface, err)
+		}
+	}
+
+	return nil
+}
+
+// runTC runs a best-effort tc cleanup command, logging failures instead of
+// returning them since deleting a qdisc that isn't there is expected to
+// fail on a container's first shaping pass.
+func runTC(args ...string) {
+	if out, err := exec.Command("tc", args...).CombinedOutput(); err != nil {
+		logrus.Debugf("tc %v: %v: %s", args, err, out)
+	}
+}
+
+// egressBurst picks a token bucket burst size for the given rate. tc
+// requires a burst large enough for the kernel to service within its
+// timer resolution; 1/10th of a second worth of traffic, with a floor to
+// keep very low rates usable, works well in practice.
+func egressBurst(rate int64) int64 {
+	burst := rate / 10
+	if burst < 32*1024 {
+		burst = 32 * 1024
+	}
+	return burst
+}
@@ -0,0 +1,12 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	containerpkg "github.com/docker/docker/container"
+)
+
+// applyNetworkShaping is unsupported on Windows: there is no tc equivalent
+// wired up here, so egress/ingress rate limits on HostConfig are silently
+// ignored on this platform rather than erroring the whole container start.
+func (daemon *Daemon) applyNetworkShaping(ctr *containerpkg.Container) error {
+	return nil
+}
@@ -2,6 +2,7 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"net"
 	"sort"
@@ -281,6 +282,63 @@ func (daemon *Daemon) CreateManagedNetwork(create clustertypes.NetworkCreateRequ
 	return err
 }
 
+// NetworkOptDNSUpstream is a network driver option, set with `docker network
+// create --opt`, that gives a comma-separated list of upstream DNS servers
+// the embedded resolver should use for containers on this network that
+// don't set their own --dns, in place of the daemon-wide default.
+const NetworkOptDNSUpstream = "com.docker.network.dns.upstream"
+
+// NetworkOptDNSCacheDisable is a network driver option, set with `docker
+// network create --opt`, that would disable the embedded resolver's DNS
+// cache for containers on this network. It is recognized so that setting it
+// fails createNetwork with a clear, actionable error (see createNetwork)
+// instead of being silently accepted and ignored: the embedded resolver
+// (vendor/github.com/docker/libnetwork/resolver.go) answers every query
+// straight from its in-memory service table and keeps no cache at all, so
+// there is nothing to disable.
+const NetworkOptDNSCacheDisable = "com.docker.network.dns.cache_disable"
+
+// networkDNSServers returns the upstream DNS servers configured with
+// NetworkOptDNSUpstream on the first of ctr's networks that sets it, or nil
+// if none of them do.
+//
+// The embedded resolver forwards lookups sandbox-wide rather than
+// per-network - a container has a single resolv.conf no matter how many
+// networks it's attached to - so this can only offer one network's
+// upstream list as a fallback default. It can't give two networks on the
+// same container independently forwarded DNS policies, and it has no way
+// to express conditional (per-domain) forwarding rules or a DNSSEC
+// validation toggle: both would mean teaching the resolver itself about
+// per-network policy in vendor/github.com/docker/libnetwork/resolver.go,
+// which is out of scope for a change confined to this tree.
+func (daemon *Daemon) networkDNSServers(ctr *container.Container) []string {
+	for name, ep := range ctr.NetworkSettings.Networks {
+		n, err := daemon.FindNetwork(getNetworkID(name, ep.EndpointSettings))
+		if err != nil {
+			continue
+		}
+		if upstream := n.Info().DriverOptions()[NetworkOptDNSUpstream]; upstream != "" {
+			return strings.Split(upstream, ",")
+		}
+	}
+	return nil
+}
+
+// unimplementedNetworkDrivers names network drivers this fork recognizes by
+// name but does not implement, mapped to an explanation of why. Requesting
+// one of these fails createNetwork with a clear, actionable error instead
+// of the generic "driver not found" message libnetwork would otherwise
+// return.
+var unimplementedNetworkDrivers = map[string]string{
+	"wireguard": "a WireGuard-backed overlay driver (kernel WireGuard for node-to-node " +
+		"encryption instead of VXLAN+IPSec, with key rotation through the cluster key " +
+		"manager) is not implemented. Every built-in network driver is registered inside " +
+		"the vendored github.com/docker/libnetwork module; adding a new one means adding " +
+		"a new vendored driver package there, which is out of scope for a change confined " +
+		"to this tree. Use the built-in \"overlay\" driver, optionally with \"--opt encrypted\" " +
+		"for IPSec-encrypted traffic, instead.",
+}
+
 // CreateNetwork creates a network with the given name, driver and other optional parameters
 func (daemon *Daemon) CreateNetwork(create types.NetworkCreateRequest) (*types.NetworkCreateResponse, error) {
 	resp, err := daemon.createNetwork(create, "", false)
@@ -319,6 +377,40 @@ func (daemon *Daemon) createNetwork(create types.NetworkCreateRequest, id string
 		driver = c.Config().Daemon.DefaultDriver
 	}
 
+	if reason, ok := unimplementedNetworkDrivers[driver]; ok {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("network driver %q is not implemented: %s", driver, reason))
+	}
+
+	if _, ok := create.Options[NetworkOptDNSCacheDisable]; ok {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("network option %q is not implemented: the embedded DNS server keeps no cache to disable", NetworkOptDNSCacheDisable))
+	}
+
+	// Auto-assign a ULA pool for IPv6-enabled networks that don't specify
+	// one, so dual-stack and IPv6-only networks are usable out of the box.
+	// NAT66/ip6tables masquerading for that pool isn't set up here: the
+	// vendored bridge driver (vendor/github.com/docker/libnetwork/drivers/
+	// bridge) has no ip6tables masquerade rule installer at all, only the
+	// IPv4 one, so containers on an auto-assigned or user-supplied ULA
+	// pool are reachable from each other and the host but not NATed out to
+	// the internet. Adding that would mean extending the vendored driver,
+	// which is out of scope for a change confined to this tree.
+	if create.EnableIPv6 {
+		hasV6Config, err := ipamHasIPv6Config(create.IPAM)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		if !hasV6Config {
+			subnet, err := randomULASubnet()
+			if err != nil {
+				return nil, err
+			}
+			if create.IPAM == nil {
+				create.IPAM = &network.IPAM{}
+			}
+			create.IPAM.Config = append(create.IPAM.Config, network.IPAMConfig{Subnet: subnet})
+		}
+	}
+
 	nwOptions := []libnetwork.NetworkOption{
 		libnetwork.NetworkOptionEnableIPv6(create.EnableIPv6),
 		libnetwork.NetworkOptionDriverOpts(create.Options),
@@ -406,6 +498,39 @@ func (daemon *Daemon) pluginRefCount(driver, capability string, mode int) {
 	}
 }
 
+// ipamHasIPv6Config reports whether ipam already has a config entry whose
+// subnet is an IPv6 prefix.
+func ipamHasIPv6Config(ipam *network.IPAM) (bool, error) {
+	if ipam == nil {
+		return false, nil
+	}
+	for _, cfg := range ipam.Config {
+		ip, _, err := net.ParseCIDR(cfg.Subnet)
+		if err != nil {
+			return false, fmt.Errorf("invalid subnet %s: %v", cfg.Subnet, err)
+		}
+		if ip.To4() == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// randomULASubnet returns a randomly generated RFC 4193 unique local
+// address /64 prefix (fd00::/8 with a random 40-bit global ID), suitable as
+// a default IPv6 pool for a network created with --ipv6 but no explicit
+// IPv6 subnet. It lets dual-stack networks get a usable, collision-unlikely
+// IPv6 address space without requiring the user to plan one out, the same
+// way the default bridge gets an IPv4 pool without one being specified.
+func randomULASubnet() (string, error) {
+	addr := make(net.IP, net.IPv6len)
+	addr[0] = 0xfd
+	if _, err := rand.Read(addr[1:6]); err != nil {
+		return "", fmt.Errorf("failed to generate a random IPv6 ULA prefix: %v", err)
+	}
+	return (&net.IPNet{IP: addr, Mask: net.CIDRMask(64, 128)}).String(), nil
+}
+
 func getIpamConfig(data []network.IPAMConfig) ([]*libnetwork.IpamConf, []*libnetwork.IpamConf, error) {
 	ipamV4Cfg := []*libnetwork.IpamConf{}
 	ipamV6Cfg := []*libnetwork.IpamConf{}
@@ -1048,6 +1173,10 @@ func buildEndpointInfo(networkSettings *internalnetwork.Settings, n libnetwork.N
 		return nil
 	}
 
+	if iface.SrcName() != "" {
+		networkSettings.Networks[n.Name()].NetworkInterfaceName = iface.SrcName()
+	}
+
 	if iface.MacAddress() != nil {
 		networkSettings.Networks[n.Name()].MacAddress = iface.MacAddress().String()
 	}
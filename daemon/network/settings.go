@@ -34,6 +34,11 @@ type Settings struct {
 type EndpointSettings struct {
 	*networktypes.EndpointSettings
 	IPAMOperational bool
+	// NetworkInterfaceName is the name of the interface inside the
+	// container's network namespace backing this endpoint (e.g. "eth0").
+	// It is used to attribute per-interface network statistics back to the
+	// network they belong to.
+	NetworkInterfaceName string
 }
 
 // AttachmentStore stores the load balancer IP address for a network id.
@@ -0,0 +1,48 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"net"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestRandomULASubnet(t *testing.T) {
+	subnet, err := randomULASubnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		t.Fatalf("randomULASubnet returned an invalid CIDR %q: %v", subnet, err)
+	}
+	if ip.To4() != nil {
+		t.Fatalf("expected an IPv6 subnet, got %q", subnet)
+	}
+	if ones, bits := ipNet.Mask.Size(); ones != 64 || bits != 128 {
+		t.Fatalf("expected a /64, got /%d", ones)
+	}
+	if ip[0] != 0xfd {
+		t.Fatalf("expected an RFC 4193 fd00::/8 prefix, got %q", subnet)
+	}
+}
+
+func TestIpamHasIPv6Config(t *testing.T) {
+	has, err := ipamHasIPv6Config(nil)
+	if err != nil || has {
+		t.Fatalf("expected no IPv6 config for nil IPAM, got %v, %v", has, err)
+	}
+
+	has, err = ipamHasIPv6Config(&network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.17.0.0/16"}}})
+	if err != nil || has {
+		t.Fatalf("expected no IPv6 config among IPv4-only subnets, got %v, %v", has, err)
+	}
+
+	has, err = ipamHasIPv6Config(&network.IPAM{Config: []network.IPAMConfig{
+		{Subnet: "172.17.0.0/16"},
+		{Subnet: "fd00:1234::/64"},
+	}})
+	if err != nil || !has {
+		t.Fatalf("expected to find the IPv6 subnet, got %v, %v", has, err)
+	}
+}
@@ -231,6 +231,23 @@ func getUser(c *container.Container, username string) (uint32, uint32, []uint32,
 	return uid, gid, additionalGids, nil
 }
 
+// containerIDMapping returns the user namespace ID mapping to use for c: its own
+// explicit HostConfig.UIDMappings/GIDMappings if it was given one, otherwise
+// the daemon-wide userns-remap mapping.
+func (daemon *Daemon) containerIDMapping(c *container.Container) *idtools.IdentityMapping {
+	if len(c.HostConfig.UIDMappings) == 0 {
+		return daemon.idMapping
+	}
+	var uids, gids []idtools.IDMap
+	for _, m := range c.HostConfig.UIDMappings {
+		uids = append(uids, idtools.IDMap{ContainerID: m.ContainerID, HostID: m.HostID, Size: m.Size})
+	}
+	for _, m := range c.HostConfig.GIDMappings {
+		gids = append(gids, idtools.IDMap{ContainerID: m.ContainerID, HostID: m.HostID, Size: m.Size})
+	}
+	return idtools.NewIDMappingsFromMaps(uids, gids)
+}
+
 func setNamespace(s *specs.Spec, ns specs.LinuxNamespace) {
 	for i, n := range s.Linux.Namespaces {
 		if n.Type == ns.Type {
@@ -247,13 +264,14 @@ func WithNamespaces(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		userNS := false
 		// user
 		if c.HostConfig.UsernsMode.IsPrivate() {
-			uidMap := daemon.idMapping.UIDs()
+			idMapping := daemon.containerIDMapping(c)
+			uidMap := idMapping.UIDs()
 			if uidMap != nil {
 				userNS = true
 				ns := specs.LinuxNamespace{Type: "user"}
 				setNamespace(s, ns)
 				s.Linux.UIDMappings = specMapping(uidMap)
-				s.Linux.GIDMappings = specMapping(daemon.idMapping.GIDs())
+				s.Linux.GIDMappings = specMapping(idMapping.GIDs())
 			}
 		}
 		// network
@@ -274,6 +292,12 @@ func WithNamespaces(daemon *Daemon, c *container.Container) coci.SpecOpts {
 				}
 			} else if c.HostConfig.NetworkMode.IsHost() {
 				ns.Path = c.NetworkSettings.SandboxKey
+			} else if c.HostConfig.NetworkMode.IsPod() {
+				path, err := daemon.netPodPath(c.HostConfig.NetworkMode.ConnectedPod())
+				if err != nil {
+					return err
+				}
+				ns.Path = path
 			}
 			setNamespace(s, ns)
 		}
@@ -337,6 +361,25 @@ func WithNamespaces(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		if c.HostConfig.UTSMode.IsHost() {
 			oci.RemoveNamespace(s, "uts")
 			s.Hostname = ""
+		} else if c.HostConfig.UTSMode.IsContainer() {
+			uc, err := daemon.getUTSContainer(c)
+			if err != nil {
+				return err
+			}
+			ns := specs.LinuxNamespace{
+				Type: "uts",
+				Path: fmt.Sprintf("/proc/%d/ns/uts", uc.State.GetPID()),
+			}
+			setNamespace(s, ns)
+			s.Hostname = uc.Config.Hostname
+			if userNS {
+				// to share a UTS namespace, they must also share a user namespace
+				nsUser := specs.LinuxNamespace{
+					Type: "user",
+					Path: fmt.Sprintf("/proc/%d/ns/user", uc.State.GetPID()),
+				}
+				setNamespace(s, nsUser)
+			}
 		}
 
 		// cgroup
@@ -744,7 +787,7 @@ func WithCommonOptions(daemon *Daemon, c *container.Container) coci.SpecOpts {
 			Path:     c.BaseFS.Path(),
 			Readonly: c.HostConfig.ReadonlyRootfs,
 		}
-		if err := c.SetupWorkingDirectory(daemon.idMapping.RootPair()); err != nil {
+		if err := c.SetupWorkingDirectory(daemon.containerIDMapping(c).RootPair()); err != nil {
 			return err
 		}
 		cwd := c.Config.WorkingDir
@@ -806,6 +849,15 @@ func WithCommonOptions(daemon *Daemon, c *container.Container) coci.SpecOpts {
 // WithCgroups sets the container's cgroups
 func WithCgroups(daemon *Daemon, c *container.Container) coci.SpecOpts {
 	return func(ctx context.Context, _ coci.Client, _ *containers.Container, s *coci.Spec) error {
+		if daemon.isSandboxedRuntime(c.HostConfig.Runtime) {
+			// VM-isolated sandbox runtimes manage their own, guest-side
+			// cgroup hierarchy; wiring up a host cgroup path here is
+			// meaningless (and the systemd/CPU RT controller lookups
+			// below assume a host cgroup filesystem that may not even
+			// be mounted inside the sandbox).
+			return nil
+		}
+
 		var cgroupsPath string
 		scopePrefix := "docker"
 		parent := "/docker"
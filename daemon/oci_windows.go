@@ -14,6 +14,7 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/oci"
 	"github.com/docker/docker/oci/caps"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/docker/docker/pkg/system"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -27,6 +28,13 @@ const (
 	credentialSpecFileLocation     = "CredentialSpecs"
 )
 
+// containerIDMapping returns the user namespace ID mapping to use for c.
+// Windows has no equivalent of Linux's per-container userns-remap, so this
+// always returns the daemon-wide mapping.
+func (daemon *Daemon) containerIDMapping(c *container.Container) *idtools.IdentityMapping {
+	return daemon.idMapping
+}
+
 func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 
 	img, err := daemon.imageService.GetImage(string(c.ImageID), nil)
@@ -0,0 +1,18 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/operations"
+)
+
+// SystemOperations lists all in-flight long-running operations known to
+// the /operations API.
+func (daemon *Daemon) SystemOperations() []types.Operation {
+	return operations.List()
+}
+
+// SystemCancelOperation requests cancellation of the in-flight operation
+// with the given ID.
+func (daemon *Daemon) SystemCancelOperation(id string) error {
+	return operations.Cancel(id)
+}
@@ -0,0 +1,120 @@
+// Package operations tracks the daemon's in-flight long-running operations
+// (pulls, prunes, and the like) so they can be listed and cancelled through
+// the /operations API, regardless of which package actually does the work.
+// It has no dependency on the daemon package itself so that both it and the
+// packages it calls into (such as daemon/images) can use it without an
+// import cycle.
+package operations // import "github.com/docker/docker/daemon/operations"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// Operation is a handle on one tracked operation, returned by Start. It's
+// used to report progress and to mark the operation as finished; callers
+// that want the current state of all operations use List instead.
+type Operation struct {
+	mu     sync.Mutex
+	state  types.Operation
+	cancel context.CancelFunc
+}
+
+// SetProgress updates the operation's reported stage, progress, and
+// message. It's safe to call concurrently with List.
+func (op *Operation) SetProgress(stage string, current, total int64, message string) {
+	op.mu.Lock()
+	op.state.Stage = stage
+	op.state.Current = current
+	op.state.Total = total
+	op.state.Message = message
+	op.mu.Unlock()
+}
+
+// Finish removes the operation from the registry. Callers should defer
+// this right after Start, the same way they would release any other
+// resource.
+func (op *Operation) Finish() {
+	registry.forget(op)
+}
+
+func (op *Operation) snapshot() types.Operation {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.state
+}
+
+type operationRegistry struct {
+	mu  sync.Mutex
+	ops map[string]*Operation
+}
+
+var registry = &operationRegistry{
+	ops: make(map[string]*Operation),
+}
+
+func (r *operationRegistry) forget(op *Operation) {
+	r.mu.Lock()
+	delete(r.ops, op.state.ID)
+	r.mu.Unlock()
+}
+
+// Start registers a new operation of the given kind and returns a handle
+// for it, along with a context derived from ctx that's cancelled either
+// when ctx is, or when the operation is cancelled through Cancel.
+func Start(ctx context.Context, kind string) (*Operation, context.Context) {
+	opCtx, cancel := context.WithCancel(ctx)
+
+	op := &Operation{
+		state: types.Operation{
+			ID:        stringid.GenerateRandomID(),
+			Kind:      kind,
+			StartedAt: time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	registry.mu.Lock()
+	registry.ops[op.state.ID] = op
+	registry.mu.Unlock()
+
+	return op, opCtx
+}
+
+// List returns a snapshot of all in-flight operations.
+func List() []types.Operation {
+	registry.mu.Lock()
+	ops := make([]*Operation, 0, len(registry.ops))
+	for _, op := range registry.ops {
+		ops = append(ops, op)
+	}
+	registry.mu.Unlock()
+
+	out := make([]types.Operation, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, op.snapshot())
+	}
+	return out
+}
+
+// Cancel requests that the operation with the given ID stop early. It's
+// advisory: the operation notices via its context the same way it would
+// notice the caller's own request context being cancelled, and unwinds
+// however it already does for that case, releasing whatever it was
+// holding (leases, partial ingests, and so on) along the way.
+func Cancel(id string) error {
+	registry.mu.Lock()
+	op, ok := registry.ops[id]
+	registry.mu.Unlock()
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("operation %s not found", id))
+	}
+	op.cancel()
+	return nil
+}
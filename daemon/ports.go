@@ -0,0 +1,214 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// portReservationTTL is how long a port reservation is held before it
+// expires on its own, in case the caller never creates the container it
+// was reserving ports for and never releases it either.
+const portReservationTTL = 30 * time.Second
+
+// maxPortReservationCount caps how many ports a single reservation request
+// can ask for, so a malformed or malicious RangeStart/RangeEnd/Count can't
+// make the daemon scan or hold an unbounded number of ports.
+const maxPortReservationCount = 1024
+
+// reservedPort is a single host port held by a reservation until it
+// expires or is released.
+type reservedPort struct {
+	proto   string
+	hostIP  string
+	port    int
+	expires time.Time
+}
+
+// portReservationTracker is a short-lived, in-memory record of host ports
+// that have been claimed through SystemReservePorts but not yet bound to a
+// container. It exists to close the race where two callers both query for
+// a free port, get the same answer, and then both fail (or worse, both
+// succeed and collide) when they create and start their containers. It is
+// consulted in addition to, not instead of, the actual bind performed by
+// the network driver at container start, since ports can also be taken by
+// things docker doesn't know about at all.
+type portReservationTracker struct {
+	mu    sync.Mutex
+	byTok map[string][]reservedPort
+}
+
+var defaultPortReservations = &portReservationTracker{
+	byTok: make(map[string][]reservedPort),
+}
+
+// sweep removes expired reservations. Callers must hold t.mu.
+func (t *portReservationTracker) sweep(now time.Time) {
+	for tok, ports := range t.byTok {
+		live := ports[:0]
+		for _, p := range ports {
+			if p.expires.After(now) {
+				live = append(live, p)
+			}
+		}
+		if len(live) == 0 {
+			delete(t.byTok, tok)
+		} else {
+			t.byTok[tok] = live
+		}
+	}
+}
+
+// isReserved reports whether proto/hostIP/port is held by a live
+// reservation. Callers must hold t.mu and have already swept.
+func (t *portReservationTracker) isReserved(proto, hostIP string, port int) bool {
+	for _, ports := range t.byTok {
+		for _, p := range ports {
+			if p.proto == proto && p.port == port && (p.hostIP == "" || hostIP == "" || p.hostIP == hostIP) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reserve finds count free ports for proto/hostIP within [start, end] that
+// are bound by neither an existing container nor another live reservation,
+// and holds them under a new token until they expire or are released.
+func (t *portReservationTracker) reserve(daemon *Daemon, proto, hostIP string, start, end uint16, count int) (string, []int, error) {
+	if count <= 0 || count > maxPortReservationCount {
+		return "", nil, errdefs.InvalidParameter(fmt.Errorf("count must be between 1 and %d", maxPortReservationCount))
+	}
+	if end < start {
+		return "", nil, errdefs.InvalidParameter(fmt.Errorf("range end %d is before range start %d", end, start))
+	}
+
+	inUse := daemon.hostPortsInUse(proto)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.sweep(now)
+
+	var ports []int
+	for port := int(start); port <= int(end) && len(ports) < count; port++ {
+		key := hostPortKey(hostIP, port)
+		if _, taken := inUse[key]; taken {
+			continue
+		}
+		if t.isReserved(proto, hostIP, port) {
+			continue
+		}
+		ports = append(ports, port)
+	}
+
+	if len(ports) < count {
+		return "", nil, errdefs.Conflict(fmt.Errorf("only %d of %d requested ports are free in range %d-%d", len(ports), count, start, end))
+	}
+
+	token := stringid.GenerateRandomID()
+	reserved := make([]reservedPort, 0, len(ports))
+	for _, port := range ports {
+		reserved = append(reserved, reservedPort{proto: proto, hostIP: hostIP, port: port, expires: now.Add(portReservationTTL)})
+	}
+	t.byTok[token] = reserved
+
+	return token, ports, nil
+}
+
+// release drops a reservation early, freeing its ports for reuse without
+// waiting for them to expire. It is not an error to release an unknown or
+// already-expired token.
+func (t *portReservationTracker) release(token string) {
+	t.mu.Lock()
+	delete(t.byTok, token)
+	t.mu.Unlock()
+}
+
+// hostPortKey identifies a host port regardless of protocol, since a
+// reservation only needs to avoid colliding with what's already bound, not
+// distinguish between equally-unavailable matches.
+func hostPortKey(hostIP string, port int) string {
+	return hostIP + ":" + strconv.Itoa(port)
+}
+
+// hostPortsInUse returns the host ports already claimed for proto by
+// existing containers, as returned by hostPortKey, gathered from both the
+// port bindings a container was created with and the ports actually bound
+// once it's running (which also covers bindings that asked for "any" host
+// port and were assigned one at start time).
+func (daemon *Daemon) hostPortsInUse(proto string) map[string]struct{} {
+	inUse := make(map[string]struct{})
+
+	for _, ctr := range daemon.containers.List() {
+		if ctr.HostConfig != nil {
+			for port, bindings := range ctr.HostConfig.PortBindings {
+				if port.Proto() != proto {
+					continue
+				}
+				for _, b := range bindings {
+					if b.HostPort == "" {
+						continue
+					}
+					if p, err := strconv.Atoi(b.HostPort); err == nil {
+						inUse[hostPortKey(b.HostIP, p)] = struct{}{}
+					}
+				}
+			}
+		}
+
+		for port, bindings := range ctr.NetworkSettings.Ports {
+			if port.Proto() != proto {
+				continue
+			}
+			for _, b := range bindings {
+				if p, err := strconv.Atoi(b.HostPort); err == nil {
+					inUse[hostPortKey(b.HostIP, p)] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return inUse
+}
+
+// SystemReservePorts finds count free host ports for the given protocol and
+// address within [RangeStart, RangeEnd], holds them under a reservation
+// token so a concurrent caller won't be handed the same ones, and returns
+// them. The reservation expires on its own a short while later if it's
+// never released, so a caller that fails to create its container doesn't
+// permanently starve the range.
+//
+// This only protects against races between callers of this API; it cannot
+// prevent a port from being taken by something outside the daemon's
+// knowledge between the reservation and the container actually starting,
+// so callers must still handle a bind failure at container start.
+func (daemon *Daemon) SystemReservePorts(req types.PortReservationRequest) (*types.PortReservation, error) {
+	proto := req.Proto
+	if proto == "" {
+		proto = "tcp"
+	}
+	if proto != "tcp" && proto != "udp" {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("invalid proto %q: must be \"tcp\" or \"udp\"", req.Proto))
+	}
+
+	token, ports, err := defaultPortReservations.reserve(daemon, proto, req.HostIP, req.RangeStart, req.RangeEnd, req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PortReservation{Token: token, Ports: ports}, nil
+}
+
+// SystemReleasePortReservation releases a reservation made by
+// SystemReservePorts before it expires on its own.
+func (daemon *Daemon) SystemReleasePortReservation(token string) error {
+	defaultPortReservations.release(token)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package prepull tracks a daemon-managed list of image tags that should
+// be kept pulled and up to date on a schedule, so a node joining an
+// autoscaling group already has them cached instead of pulling on first
+// use.
+package prepull // import "github.com/docker/docker/daemon/prepull"
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is one tag on the pre-pull list.
+type Entry struct {
+	Reference    string
+	Interval     time.Duration
+	LastPulledAt time.Time
+	LastError    string
+}
+
+// Engine holds the current pre-pull list.
+type Engine struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+// NewEngine returns an Engine with an empty pre-pull list.
+func NewEngine() *Engine {
+	return &Engine{entries: make(map[string]*Entry)}
+}
+
+// Add puts reference on the pre-pull list, to be refreshed every interval.
+// Adding a reference already on the list replaces its interval but keeps
+// its last-pull history.
+func (e *Engine) Add(reference string, interval time.Duration) Entry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entry, ok := e.entries[reference]
+	if !ok {
+		entry = &Entry{Reference: reference}
+		e.entries[reference] = entry
+	}
+	entry.Interval = interval
+	return *entry
+}
+
+// Remove takes reference off the pre-pull list and reports whether it was
+// on it.
+func (e *Engine) Remove(reference string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.entries[reference]; !ok {
+		return false
+	}
+	delete(e.entries, reference)
+	return true
+}
+
+// Has reports whether reference is currently on the pre-pull list.
+func (e *Engine) Has(reference string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.entries[reference]
+	return ok
+}
+
+// List returns every entry on the pre-pull list, in no particular order.
+func (e *Engine) List() []Entry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entries := make([]Entry, 0, len(e.entries))
+	for _, entry := range e.entries {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+// DueForPull returns every entry whose Interval has elapsed since its
+// LastPulledAt (or that has never been pulled yet).
+func (e *Engine) DueForPull(now time.Time) []Entry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var due []Entry
+	for _, entry := range e.entries {
+		if entry.LastPulledAt.IsZero() || now.Sub(entry.LastPulledAt) >= entry.Interval {
+			due = append(due, *entry)
+		}
+	}
+	return due
+}
+
+// RecordResult stores the outcome of a pull attempt for reference at
+// pulledAt. A nil err clears any previously recorded error. It is a no-op
+// if reference is no longer on the list.
+func (e *Engine) RecordResult(reference string, pulledAt time.Time, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entry, ok := e.entries[reference]
+	if !ok {
+		return
+	}
+	entry.LastPulledAt = pulledAt
+	if err != nil {
+		entry.LastError = err.Error()
+	} else {
+		entry.LastError = ""
+	}
+}
@@ -0,0 +1,19 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"time"
+)
+
+// prePullLoop periodically asks the image service to refresh any pre-pull
+// list entry due for a pull. It runs on a fixed tick independent of any
+// individual entry's interval; ImageService.RunPrePull decides which
+// entries are actually due.
+func (daemon *Daemon) prePullLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		daemon.imageService.RunPrePull(context.Background())
+	}
+}
@@ -0,0 +1,30 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ContainerGeneratedProfile reports on a container's --security-opt
+// generate-profile learning session.
+func (daemon *Daemon) ContainerGeneratedProfile(name string) (*backend.GeneratedProfile, error) {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ctr.GenerateProfile {
+		return nil, errdefs.InvalidParameter(errors.New("container was not started with --security-opt generate-profile"))
+	}
+
+	return &backend.GeneratedProfile{
+		Enabled:       true,
+		SeccompAction: string(types.ActLog),
+		Note: "Syscalls outside the default seccomp profile's allow-list were logged rather than blocked; " +
+			"a profile is not synthesized automatically. Review the resulting kernel audit log entries " +
+			"(for example with `ausearch -m seccomp` or `dmesg | grep SECCOMP`) and add any syscalls the " +
+			"workload needs to a custom seccomp profile. AppArmor and SELinux profile generation are not " +
+			"implemented: both would need an audit/eBPF capture pipeline this daemon doesn't have.",
+	}, nil
+}
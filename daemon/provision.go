@@ -0,0 +1,129 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	volumeopts "github.com/docker/docker/volume/service/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// provisionMarkerFile records that the first-boot provisioning manifest
+// has already been applied, so that subsequent daemon starts skip it.
+const provisionMarkerFile = "provisioned"
+
+// ProvisionManifest describes the objects a daemon should create on its
+// first boot, for immutable-infrastructure style host bring-up.
+type ProvisionManifest struct {
+	Images   []string                     `json:"images,omitempty"`
+	Networks []types.NetworkCreateRequest `json:"networks,omitempty"`
+	Volumes  []ProvisionVolume            `json:"volumes,omitempty"`
+}
+
+// ProvisionVolume describes a volume to create during provisioning.
+type ProvisionVolume struct {
+	Name   string            `json:"name"`
+	Driver string            `json:"driver,omitempty"`
+	Opts   map[string]string `json:"opts,omitempty"`
+}
+
+// loadProvisionManifest reads a ProvisionManifest from a local file path
+// or, if source looks like a URL, fetches it over HTTP(S).
+func loadProvisionManifest(source string) (*ProvisionManifest, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching provisioning manifest")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("fetching provisioning manifest: unexpected status %s", resp.Status)
+		}
+		data, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading provisioning manifest")
+		}
+	} else {
+		data, err = ioutil.ReadFile(source)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading provisioning manifest")
+		}
+	}
+
+	var manifest ProvisionManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "parsing provisioning manifest")
+	}
+	return &manifest, nil
+}
+
+// runProvisioning applies the daemon's configured provisioning manifest,
+// if any, and records the outcome for SystemInfo. It is a no-op if the
+// manifest was already applied on a previous start.
+func (daemon *Daemon) runProvisioning() {
+	source := daemon.configStore.ProvisioningManifest
+	if source == "" {
+		return
+	}
+
+	markerPath := filepath.Join(daemon.root, provisionMarkerFile)
+	if _, err := os.Stat(markerPath); err == nil {
+		daemon.provisioningStatus = &types.ProvisioningStatus{Source: source, Applied: true}
+		return
+	}
+
+	status := &types.ProvisioningStatus{Source: source}
+	if err := daemon.applyProvisioning(source); err != nil {
+		logrus.WithError(err).Error("first-boot provisioning failed")
+		status.Error = err.Error()
+	} else {
+		status.Applied = true
+		if err := ioutil.WriteFile(markerPath, []byte(source+"\n"), 0644); err != nil {
+			logrus.WithError(err).Warn("failed to record provisioning marker")
+		}
+	}
+	daemon.provisioningStatus = status
+}
+
+func (daemon *Daemon) applyProvisioning(source string) error {
+	manifest, err := loadProvisionManifest(source)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, image := range manifest.Images {
+		if err := daemon.imageService.PullImage(ctx, image, "", nil, nil, nil, ioutil.Discard); err != nil {
+			return errors.Wrapf(err, "provisioning image %s", image)
+		}
+	}
+
+	for _, v := range manifest.Volumes {
+		if _, err := daemon.volumes.Get(ctx, v.Name); err == nil {
+			continue
+		}
+		if _, err := daemon.volumes.Create(ctx, v.Name, v.Driver, volumeopts.WithCreateOptions(v.Opts)); err != nil {
+			return errors.Wrapf(err, "provisioning volume %s", v.Name)
+		}
+	}
+
+	for _, n := range manifest.Networks {
+		if _, err := daemon.GetNetworkByName(n.Name); err == nil {
+			continue
+		}
+		if _, err := daemon.CreateNetwork(n); err != nil {
+			return errors.Wrapf(err, "provisioning network %s", n.Name)
+		}
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/daemon/operations"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/runconfig"
 	"github.com/docker/libnetwork"
@@ -44,6 +45,9 @@ func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.
 	}
 	defer atomic.StoreInt32(&daemon.pruneRunning, 0)
 
+	op, ctx := operations.Start(ctx, "container-prune")
+	defer op.Finish()
+
 	rep := &types.ContainersPruneReport{}
 
 	// make sure that only accepted filters have been received
@@ -58,7 +62,7 @@ func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.
 	}
 
 	allContainers := daemon.List()
-	for _, c := range allContainers {
+	for i, c := range allContainers {
 		select {
 		case <-ctx.Done():
 			logrus.Debugf("ContainersPrune operation cancelled: %#v", *rep)
@@ -66,6 +70,8 @@ func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.
 		default:
 		}
 
+		op.SetProgress("removing unused containers", int64(i), int64(len(allContainers)), c.ID)
+
 		if !c.IsRunning() {
 			if !until.IsZero() && c.Created.After(until) {
 				continue
@@ -124,3 +124,31 @@ func (daemon *Daemon) rewriteRuntimePath(name, p string, args []string) (string,
 
 	return filepath.Join(daemon.configStore.Root, "runtimes", name), nil
 }
+
+// rewriteRuntimePathForContainer is like rewriteRuntimePath, but for
+// per-container HostConfig.RuntimeArgs overrides rather than arguments
+// configured on a named daemon runtime. The wrapper script is keyed by
+// container ID so it doesn't collide with (or get cleaned up by) the
+// scripts rewriteRuntimePath generates for named runtimes.
+func (daemon *Daemon) rewriteRuntimePathForContainer(containerID, p string, args []string) (string, error) {
+	if len(args) == 0 {
+		return p, nil
+	}
+
+	if _, err := exec.LookPath(p); err != nil {
+		return "", errors.Wrap(err, "error while looking up the specified runtime path")
+	}
+
+	runtimeDir := filepath.Join(daemon.configStore.Root, "runtimes")
+	if err := os.MkdirAll(runtimeDir, 0700); err != nil {
+		return "", errors.Wrap(err, "failed to create runtimes dir")
+	}
+
+	script := filepath.Join(runtimeDir, "container-"+containerID)
+	content := fmt.Sprintf("#!/bin/sh\n%s %s $@\n", p, strings.Join(args, " "))
+	if err := ioutil.WriteFile(script, []byte(content), 0700); err != nil {
+		return "", errors.Wrap(err, "failed to write per-container runtime script")
+	}
+
+	return script, nil
+}
@@ -0,0 +1,21 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/operations"
+)
+
+// SystemMigrateSchema1 re-pushes the locally known tags of every repository
+// a pull has fetched a schema1 manifest for, upgrading the remote to
+// schema2, for the `docker system schema1-migrate` API. See
+// images.ImageService.MigrateSchema1Images for what this does and does not
+// cover.
+func (daemon *Daemon) SystemMigrateSchema1(ctx context.Context) (*types.Schema1MigrationReport, error) {
+	op, ctx := operations.Start(ctx, "schema1-migration")
+	defer op.Finish()
+
+	op.SetProgress("re-pushing repositories pulled as schema1", 0, 0, "")
+	return daemon.imageService.MigrateSchema1Images(ctx)
+}
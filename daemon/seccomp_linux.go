@@ -41,6 +41,11 @@ func WithSeccomp(daemon *Daemon, c *container.Container) coci.SpecOpts {
 			if err != nil {
 				return err
 			}
+		} else if c.GenerateProfile {
+			profile, err = seccomp.GenerateProfile(s)
+			if err != nil {
+				return err
+			}
 		} else {
 			if daemon.seccompProfile != nil {
 				profile, err = seccomp.LoadProfile(string(daemon.seccompProfile), s)
@@ -0,0 +1,146 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/daemon/names"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+)
+
+var validSnapshotNamePattern = names.RestrictedNamePattern
+
+// ContainerSnapshot creates a named, point-in-time copy of a container's
+// root filesystem on disk, which ContainerRollback can later restore without
+// recreating the container. The container may be running: the snapshot is
+// taken directly from the graphdriver mount, the same way ContainerExport
+// reads it, so it holds the whole merged filesystem (image content as well
+// as the writable layer's changes), not the writable layer alone. Volumes
+// and bind mounts are not included.
+func (daemon *Daemon) ContainerSnapshot(name, snapshotName string) error {
+	if !validSnapshotNamePattern.MatchString(snapshotName) {
+		return errdefs.InvalidParameter(errors.Errorf("invalid snapshot name (%s), only %s are allowed", snapshotName, names.RestrictedNameChars))
+	}
+
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if ctr.IsDead() {
+		return errdefs.Conflict(errors.Errorf("cannot snapshot container %s which is Dead", ctr.ID))
+	}
+	if ctr.IsRemovalInProgress() {
+		return errdefs.Conflict(errors.Errorf("cannot snapshot container %s which is being removed", ctr.ID))
+	}
+
+	snapshotPath := filepath.Join(ctr.SnapshotDir(), snapshotName+".tar")
+	if _, err := os.Stat(snapshotPath); err == nil {
+		return errdefs.Conflict(errors.Errorf("snapshot %s already exists for container %s", snapshotName, ctr.ID))
+	}
+
+	if err := os.MkdirAll(ctr.SnapshotDir(), 0700); err != nil {
+		return errors.Wrapf(err, "error creating snapshot directory for container %s", ctr.ID)
+	}
+
+	if err := daemon.Mount(ctr); err != nil {
+		return errors.Wrapf(err, "error snapshotting container %s", ctr.ID)
+	}
+	defer daemon.Unmount(ctr)
+
+	archv, err := archivePath(ctr.BaseFS, ctr.BaseFS.Path(), &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     daemon.idMapping.UIDs(),
+		GIDMaps:     daemon.idMapping.GIDs(),
+	}, ctr.BaseFS.Path())
+	if err != nil {
+		return errors.Wrapf(err, "error snapshotting container %s", ctr.ID)
+	}
+	defer archv.Close()
+
+	f, err := ioutils.NewAtomicFileWriter(snapshotPath, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating snapshot file for container %s", ctr.ID)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, archv); err != nil {
+		return errors.Wrapf(err, "error writing snapshot for container %s", ctr.ID)
+	}
+
+	daemon.LogContainerEvent(ctr, "snapshot")
+	return nil
+}
+
+// ContainerRollback restores a container's root filesystem to the state
+// captured by a previous ContainerSnapshot call, clearing the mounted
+// filesystem and extracting the snapshot into it in place. The container is not recreated or restarted; processes
+// already running inside it keep running against the rolled-back
+// filesystem, the same way files written through `docker cp` into a
+// running container are immediately visible to it.
+func (daemon *Daemon) ContainerRollback(name, snapshotName string) error {
+	if !validSnapshotNamePattern.MatchString(snapshotName) {
+		return errdefs.InvalidParameter(errors.Errorf("invalid snapshot name (%s), only %s are allowed", snapshotName, names.RestrictedNameChars))
+	}
+
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if ctr.IsDead() {
+		return errdefs.Conflict(errors.Errorf("cannot roll back container %s which is Dead", ctr.ID))
+	}
+	if ctr.IsRemovalInProgress() {
+		return errdefs.Conflict(errors.Errorf("cannot roll back container %s which is being removed", ctr.ID))
+	}
+
+	snapshotPath := filepath.Join(ctr.SnapshotDir(), snapshotName+".tar")
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errdefs.NotFound(errors.Errorf("snapshot %s not found for container %s", snapshotName, ctr.ID))
+		}
+		return errors.Wrapf(err, "error opening snapshot %s for container %s", snapshotName, ctr.ID)
+	}
+	defer f.Close()
+
+	if err := daemon.Mount(ctr); err != nil {
+		return errors.Wrapf(err, "error rolling back container %s", ctr.ID)
+	}
+	defer daemon.Unmount(ctr)
+
+	if err := clearDir(ctr.BaseFS.Path()); err != nil {
+		return errors.Wrapf(err, "error clearing filesystem of container %s for rollback", ctr.ID)
+	}
+
+	if err := extractArchive(ctr.BaseFS, f, ctr.BaseFS.Path(), &archive.TarOptions{
+		UIDMaps: daemon.idMapping.UIDs(),
+		GIDMaps: daemon.idMapping.GIDs(),
+	}, ctr.BaseFS.Path()); err != nil {
+		return errors.Wrapf(err, "error restoring snapshot %s for container %s", snapshotName, ctr.ID)
+	}
+
+	daemon.LogContainerEvent(ctr, "rollback")
+	return nil
+}
+
+// clearDir removes every entry directly under dir, without removing dir
+// itself, so a fresh tar can be extracted into it as though it were empty.
+func clearDir(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
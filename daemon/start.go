@@ -18,7 +18,7 @@ import (
 )
 
 // ContainerStart starts a container.
-func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.HostConfig, checkpoint string, checkpointDir string) error {
+func (daemon *Daemon) ContainerStart(ctx context.Context, name string, hostConfig *containertypes.HostConfig, checkpoint string, checkpointDir string) error {
 	if checkpoint != "" && !daemon.HasExperimental() {
 		return errdefs.InvalidParameter(errors.New("checkpoint is only supported in experimental mode"))
 	}
@@ -95,14 +95,20 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 			return errdefs.InvalidParameter(err)
 		}
 	}
-	return daemon.containerStart(ctr, checkpoint, checkpointDir, true)
+	return daemon.containerStart(ctx, ctr, checkpoint, checkpointDir, true)
 }
 
 // containerStart prepares the container to run by setting up everything the
 // container needs, such as storage and networking, as well as links
 // between containers. The container is left waiting for a signal to
 // begin running.
-func (daemon *Daemon) containerStart(container *container.Container, checkpoint string, checkpointDir string, resetRestartManager bool) (err error) {
+//
+// ctx is used to cancel the (potentially slow) call into containerd to
+// create the container if the caller goes away, e.g. an HTTP client
+// disconnecting from a `docker start` request; it is not used once the
+// container has actually been created, since tearing down a container that
+// containerd already knows about needs to happen regardless.
+func (daemon *Daemon) containerStart(ctx context.Context, container *container.Container, checkpoint string, checkpointDir string, resetRestartManager bool) (err error) {
 	start := time.Now()
 	container.Lock()
 	defer container.Unlock()
@@ -146,19 +152,43 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		}
 	}()
 
+	if err := daemon.waitForDependencies(container); err != nil {
+		return errdefs.System(err)
+	}
+
+	if err := daemon.waitForHostServices(container); err != nil {
+		return errdefs.System(err)
+	}
+
 	if err := daemon.conditionalMountOnStart(container); err != nil {
 		return err
 	}
 
+	daemon.warmImageCache(container)
+
 	if err := daemon.initializeNetworking(container); err != nil {
 		return err
 	}
 
+	if err := daemon.applyNetworkShaping(container); err != nil {
+		return errdefs.System(err)
+	}
+
+	if container.HostConfig.ClockSyncStatusFile {
+		if err := daemon.writeClockSyncFile(container); err != nil {
+			return err
+		}
+	}
+
 	spec, err := daemon.createSpec(container)
 	if err != nil {
 		return errdefs.System(err)
 	}
 
+	if err := daemon.runContainerHooksOnStart(container, spec); err != nil {
+		return errdefs.System(err)
+	}
+
 	if resetRestartManager {
 		container.ResetRestartManager(true)
 		container.HasBeenManuallyStopped = false
@@ -180,8 +210,6 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		return err
 	}
 
-	ctx := context.TODO()
-
 	imageRef, err := reference.ParseNormalizedNamed(container.Config.Image)
 	if err != nil {
 		return err
@@ -191,9 +219,11 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 	if err != nil {
 		if errdefs.IsConflict(err) {
 			logrus.WithError(err).WithField("container", container.ID).Error("Container not cleaned up from containerd from previous run")
-			// best effort to clean up old container object
-			daemon.containerd.DeleteTask(ctx, container.ID)
-			if err := daemon.containerd.Delete(ctx, container.ID); err != nil && !errdefs.IsNotFound(err) {
+			// best effort to clean up old container object; always run this to
+			// completion even if ctx was canceled, since leaving a stale
+			// containerd container object behind is worse than finishing late
+			daemon.containerd.DeleteTask(context.Background(), container.ID)
+			if err := daemon.containerd.Delete(context.Background(), container.ID); err != nil && !errdefs.IsNotFound(err) {
 				logrus.WithError(err).WithField("container", container.ID).Error("Error cleaning up stale containerd container object")
 			}
 			err = daemon.containerd.Create(ctx, container.ID, spec, shim, createOptions, withImageName(imageRef.String()))
@@ -208,6 +238,7 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		container.StreamConfig.Stdin() != nil || container.Config.Tty,
 		container.InitializeStdio)
 	if err != nil {
+		daemon.retainFailedBundle(container)
 		if err := daemon.containerd.Delete(context.Background(), container.ID); err != nil {
 			logrus.WithError(err).WithField("container", container.ID).
 				Error("failed to delete failed start container")
@@ -221,6 +252,21 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 
 	daemon.initHealthMonitor(container)
 
+	if err := daemon.publishNamedPipes(container); err != nil {
+		logrus.WithError(err).WithField("container", container.ID).Error("failed to publish named pipes")
+	}
+
+	if container.HostConfig.PostStartHook != nil {
+		go func() {
+			if err := daemon.runLifecycleHook(container, "post-start", container.HostConfig.PostStartHook); err != nil {
+				logrus.WithError(err).WithField("container", container.ID).Error("post-start lifecycle hook failed, killing container")
+				if err := daemon.Kill(container); err != nil {
+					logrus.WithError(err).WithField("container", container.ID).Error("failed to kill container after post-start lifecycle hook failure")
+				}
+			}
+		}()
+	}
+
 	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
 		logrus.WithError(err).WithField("container", container.ID).
 			Errorf("failed to store container")
@@ -235,6 +281,10 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 // Cleanup releases any network resources allocated to the container along with any rules
 // around how containers are linked together.  It also unmounts the container's root filesystem.
 func (daemon *Daemon) Cleanup(container *container.Container) {
+	daemon.runContainerHooksOnCleanup(container)
+
+	daemon.unpublishNamedPipes(container)
+
 	daemon.releaseNetwork(container)
 
 	if err := container.UnmountIpcMount(); err != nil {
@@ -19,7 +19,21 @@ func (daemon *Daemon) getLibcontainerdCreateOptions(container *container.Contain
 	}
 
 	rt := daemon.configStore.GetRuntime(container.HostConfig.Runtime)
-	if rt.Shim == nil {
+	if rt == nil {
+		return "", nil, errdefs.InvalidParameter(errors.Errorf("runtime %q is not configured", container.HostConfig.Runtime))
+	}
+
+	if len(container.HostConfig.RuntimeArgs) > 0 {
+		// A per-container runtime args override always takes precedence
+		// over whatever arguments (if any) are configured for this
+		// runtime in the daemon, and always needs its own wrapper script
+		// since it can't be shared across containers.
+		p, err := daemon.rewriteRuntimePathForContainer(container.ID, rt.Path, container.HostConfig.RuntimeArgs)
+		if err != nil {
+			return "", nil, translateContainerdStartErr(container.Path, container.SetExitCode, err)
+		}
+		rt.Shim = defaultV2ShimConfig(daemon.configStore, p)
+	} else if rt.Shim == nil {
 		p, err := daemon.rewriteRuntimePath(container.HostConfig.Runtime, rt.Path, rt.Args)
 		if err != nil {
 			return "", nil, translateContainerdStartErr(container.Path, container.SetExitCode, err)
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/api/types/versions/v1p20"
 	"github.com/docker/docker/container"
+	daemonstats "github.com/docker/docker/daemon/stats"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/ioutils"
 )
@@ -160,3 +161,28 @@ func (daemon *Daemon) GetContainerStats(container *container.Container) (*types.
 
 	return stats, nil
 }
+
+// GetContainerStatsBatch collects stats for every given container with a
+// single underlying read where the platform allows it (one containerd call
+// instead of one per container), for use by the stats collector's periodic
+// tick. A container absent from the returned map should be retried
+// individually through GetContainerStats by the caller.
+func (daemon *Daemon) GetContainerStatsBatch(containers []*container.Container) (map[string]*types.StatsJSON, error) {
+	return daemon.statsBatch(containers)
+}
+
+// ContainerStatsHistory returns the stats history samples recorded for the
+// container at or after since, without requiring a live stats stream.
+func (daemon *Daemon) ContainerStatsHistory(prefixOrName string, since time.Time) ([]*daemonstats.HistorySample, error) {
+	ctr, err := daemon.GetContainer(prefixOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := daemon.statsHistory.Since(ctr.ID, since)
+	out := make([]*daemonstats.HistorySample, len(samples))
+	for i := range samples {
+		out[i] = &samples[i]
+	}
+	return out, nil
+}
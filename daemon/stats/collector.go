@@ -36,6 +36,12 @@ func NewCollector(supervisor supervisor, interval time.Duration) *Collector {
 type supervisor interface {
 	// GetContainerStats collects all the stats related to a container
 	GetContainerStats(container *container.Container) (*types.StatsJSON, error)
+
+	// GetContainerStatsBatch collects stats for every given container in a
+	// single pass rather than one call per container. Containers with no
+	// entry in the returned map are retried individually through
+	// GetContainerStats.
+	GetContainerStatsBatch(containers []*container.Container) (map[string]*types.StatsJSON, error)
 }
 
 // Collect registers the container with the collector and adds it to
@@ -112,8 +118,22 @@ func (s *Collector) Run() {
 			continue
 		}
 
+		batchContainers := make([]*container.Container, len(pairs))
+		for i, pair := range pairs {
+			batchContainers[i] = pair.container
+		}
+		batch, err := s.supervisor.GetContainerStatsBatch(batchContainers)
+		if err != nil {
+			logrus.Errorf("collecting batched container stats: %v", err)
+			batch = nil
+		}
+
 		for _, pair := range pairs {
-			stats, err := s.supervisor.GetContainerStats(pair.container)
+			stats, ok := batch[pair.container.ID]
+			var err error
+			if !ok {
+				stats, err = s.supervisor.GetContainerStats(pair.container)
+			}
 
 			switch err.(type) {
 			case nil:
@@ -0,0 +1,126 @@
+package stats // import "github.com/docker/docker/daemon/stats"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// HistorySample is a distilled, point-in-time snapshot of a container's
+// resource usage, cheap enough to retain many of per container without the
+// overhead of storing a full types.StatsJSON for each one.
+type HistorySample struct {
+	Read        time.Time `json:"read"`
+	CPUPercent  float64   `json:"cpu_percent"`
+	MemoryUsage uint64    `json:"memory_usage"`
+	MemoryLimit uint64    `json:"memory_limit"`
+	BlockRead   uint64    `json:"block_read"`
+	BlockWrite  uint64    `json:"block_write"`
+}
+
+// History is a bounded, in-memory time series of HistorySamples per
+// container. Unlike the live stats Collector, it records independently of
+// whether anything is subscribed to a container's stats stream.
+type History struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	maxSamples int
+	samples    map[string][]HistorySample
+}
+
+// NewHistory creates a History that samples on the given interval and
+// retains at most maxSamples per container, discarding the oldest once
+// that's exceeded.
+func NewHistory(interval time.Duration, maxSamples int) *History {
+	return &History{
+		interval:   interval,
+		maxSamples: maxSamples,
+		samples:    make(map[string][]HistorySample),
+	}
+}
+
+// Interval returns the configured sampling interval.
+func (h *History) Interval() time.Duration {
+	return h.interval
+}
+
+// Record appends sample for containerID, dropping the oldest retained
+// sample for that container if it's already at capacity.
+func (h *History) Record(containerID string, sample HistorySample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := append(h.samples[containerID], sample)
+	if len(samples) > h.maxSamples {
+		samples = samples[len(samples)-h.maxSamples:]
+	}
+	h.samples[containerID] = samples
+}
+
+// Since returns the samples recorded for containerID at or after since,
+// oldest first.
+func (h *History) Since(containerID string, since time.Time) []HistorySample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	all := h.samples[containerID]
+	for i, s := range all {
+		if !s.Read.Before(since) {
+			out := make([]HistorySample, len(all)-i)
+			copy(out, all[i:])
+			return out
+		}
+	}
+	return nil
+}
+
+// Remove discards any history retained for containerID.
+func (h *History) Remove(containerID string) {
+	h.mu.Lock()
+	delete(h.samples, containerID)
+	h.mu.Unlock()
+}
+
+// NewHistorySample distills curr down to the fields History retains,
+// using prev (which may be nil, e.g. for the first sample of a container)
+// to compute a CPU usage percentage the same way the live stats stream
+// does.
+func NewHistorySample(curr, prev *types.StatsJSON) HistorySample {
+	var blkRead, blkWrite uint64
+	for _, e := range curr.BlkioStats.IoServiceBytesRecursive {
+		switch e.Op {
+		case "Read", "read":
+			blkRead += e.Value
+		case "Write", "write":
+			blkWrite += e.Value
+		}
+	}
+
+	return HistorySample{
+		Read:        curr.Read,
+		CPUPercent:  cpuPercent(curr, prev),
+		MemoryUsage: curr.MemoryStats.Usage,
+		MemoryLimit: curr.MemoryStats.Limit,
+		BlockRead:   blkRead,
+		BlockWrite:  blkWrite,
+	}
+}
+
+func cpuPercent(curr, prev *types.StatsJSON) float64 {
+	if prev == nil {
+		return 0
+	}
+
+	cpuDelta := float64(curr.CPUStats.CPUUsage.TotalUsage) - float64(prev.CPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(curr.CPUStats.SystemUsage) - float64(prev.CPUStats.SystemUsage)
+	if systemDelta <= 0 || cpuDelta <= 0 {
+		return 0
+	}
+
+	onlineCPUs := float64(curr.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(curr.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return (cpuDelta / systemDelta) * onlineCPUs * 100.0
+}
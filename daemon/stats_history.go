@@ -0,0 +1,38 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/stats"
+	"github.com/sirupsen/logrus"
+)
+
+// statsHistoryCollector periodically samples resource usage for all running
+// containers into daemon.statsHistory, independent of whether anything is
+// subscribed to a live stats stream.
+func (daemon *Daemon) statsHistoryCollector() {
+	prev := make(map[string]*types.StatsJSON)
+
+	ticker := time.NewTicker(daemon.statsHistory.Interval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, c := range daemon.List() {
+			if !c.IsRunning() {
+				delete(prev, c.ID)
+				continue
+			}
+
+			curr, err := daemon.GetContainerStats(c)
+			if err != nil {
+				logrus.WithError(err).WithField("container", c.ID).
+					Debug("failed to sample container stats for history")
+				continue
+			}
+
+			daemon.statsHistory.Record(c.ID, stats.NewHistorySample(curr, prev[c.ID]))
+			prev[c.ID] = curr
+		}
+	}
+}
@@ -1,3 +1,4 @@
+//go:build !windows
 // +build !windows
 
 package daemon // import "github.com/docker/docker/daemon"
@@ -38,10 +39,29 @@ func (daemon *Daemon) getNetworkStats(c *container.Container) (map[string]types.
 		return nil, err
 	}
 
+	// Interface names reported by libnetwork are the names inside the
+	// container's network namespace (e.g. "eth0"), not the network name.
+	// Resolve the reverse mapping here so callers get stats keyed by
+	// network name, consistent with c.NetworkSettings.Networks.
+	ifaceToNetwork := make(map[string]string, len(c.NetworkSettings.Networks))
+	for netName, epSettings := range c.NetworkSettings.Networks {
+		if epSettings.NetworkInterfaceName != "" {
+			ifaceToNetwork[epSettings.NetworkInterfaceName] = netName
+		}
+	}
+
 	stats := make(map[string]types.NetworkStats)
 	// Convert libnetwork nw stats into api stats
 	for ifName, ifStats := range lnstats {
-		stats[ifName] = types.NetworkStats{
+		name, ok := ifaceToNetwork[ifName]
+		if !ok {
+			// No known network maps to this interface (e.g. an older
+			// container started before NetworkInterfaceName was
+			// recorded); fall back to the raw interface name rather
+			// than dropping the stats.
+			name = ifName
+		}
+		stats[name] = types.NetworkStats{
 			RxBytes:   ifStats.RxBytes,
 			RxPackets: ifStats.RxPackets,
 			RxErrors:  ifStats.RxErrors,
@@ -42,6 +42,10 @@ func (daemon *Daemon) containerStop(container *containerpkg.Container, seconds i
 		return nil
 	}
 
+	if err := daemon.runLifecycleHook(container, "pre-stop", container.HostConfig.PreStopHook); err != nil {
+		return err
+	}
+
 	stopSignal := container.StopSignal()
 	// 1. Send a stop signal
 	if err := daemon.killPossiblyDeadProcess(container, stopSignal); err != nil {
@@ -0,0 +1,9 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import "context"
+
+// SystemReloadTrustPolicy reloads the image trust policy file configured via
+// --trust-policy-file without restarting the daemon.
+func (daemon *Daemon) SystemReloadTrustPolicy(ctx context.Context) error {
+	return daemon.imageService.ReloadTrustPolicy()
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
 )
 
 // ContainerUpdate updates configuration of the container
-func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostConfig) (container.ContainerUpdateOKBody, error) {
+func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostConfig, restartInPlace bool) (container.ContainerUpdateOKBody, error) {
 	var warnings []string
 
 	c, err := daemon.GetContainer(name)
@@ -23,14 +24,14 @@ func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostCon
 		return container.ContainerUpdateOKBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
 
-	if err := daemon.update(name, hostConfig); err != nil {
+	if err := daemon.update(name, hostConfig, restartInPlace); err != nil {
 		return container.ContainerUpdateOKBody{Warnings: warnings}, err
 	}
 
 	return container.ContainerUpdateOKBody{Warnings: warnings}, nil
 }
 
-func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) error {
+func (daemon *Daemon) update(name string, hostConfig *container.HostConfig, restartInPlace bool) error {
 	if hostConfig == nil {
 		return nil
 	}
@@ -56,7 +57,8 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	}
 
 	ctr.Lock()
-	if err := ctr.UpdateContainer(hostConfig); err != nil {
+	addedMounts, err := ctr.UpdateContainer(hostConfig)
+	if err != nil {
 		restoreConfig = true
 		ctr.Unlock()
 		return errCannotUpdate(ctr.ID, err)
@@ -78,10 +80,27 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	// If container is running (including paused), we need to update configs
 	// to the real world.
 	if ctr.IsRunning() && !ctr.IsRestarting() {
-		if err := daemon.containerd.UpdateResources(context.Background(), ctr.ID, toContainerdResources(hostConfig.Resources)); err != nil {
-			restoreConfig = true
-			// TODO: it would be nice if containerd responded with better errors here so we can classify this better.
-			return errCannotUpdate(ctr.ID, errdefs.System(err))
+		if restartInPlace {
+			if err := daemon.restartContainerInPlace(ctr); err != nil {
+				restoreConfig = true
+				return errCannotUpdate(ctr.ID, errdefs.System(err))
+			}
+		} else {
+			if len(addedMounts) > 0 {
+				if err := daemon.freezeAndAddMounts(ctr, addedMounts); err != nil {
+					restoreConfig = true
+					return errCannotUpdate(ctr.ID, errdefs.System(err))
+				}
+			}
+			if err := daemon.containerd.UpdateResources(context.Background(), ctr.ID, toContainerdResources(hostConfig.Resources)); err != nil {
+				restoreConfig = true
+				// TODO: it would be nice if containerd responded with better errors here so we can classify this better.
+				return errCannotUpdate(ctr.ID, errdefs.System(err))
+			}
+			if err := daemon.applyNetworkShaping(ctr); err != nil {
+				restoreConfig = true
+				return errCannotUpdate(ctr.ID, errdefs.System(err))
+			}
 		}
 	}
 
@@ -90,6 +109,23 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	return nil
 }
 
+// restartContainerInPlace gracefully stops and restarts ctr so that a
+// freshly generated OCI spec - reflecting host config fields (such as
+// ulimits) that cannot be pushed to a running task via UpdateResources -
+// takes effect, without recreating the container. The container keeps its
+// ID, its read-write layer, and reconnects to the same networks.
+func (daemon *Daemon) restartContainerInPlace(ctr *containerpkg.Container) error {
+	stopTimeout := ctr.StopTimeout()
+	if err := daemon.containerStop(ctr, stopTimeout); err != nil {
+		return errors.Wrap(err, "stopping container for in-place restart")
+	}
+	if err := daemon.containerStart(context.Background(), ctr, "", "", false); err != nil {
+		return errors.Wrap(err, "starting container for in-place restart")
+	}
+	daemon.LogContainerEvent(ctr, "restart-inplace")
+	return nil
+}
+
 func errCannotUpdate(containerID string, err error) error {
 	return errors.Wrap(err, "Cannot update container "+containerID)
 }
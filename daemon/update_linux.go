@@ -1,11 +1,17 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"fmt"
+	"os"
+	"runtime"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
+	containerpkg "github.com/docker/docker/container"
 	libcontainerdtypes "github.com/docker/docker/libcontainerd/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
 )
 
 func toContainerdResources(resources container.Resources) *libcontainerdtypes.Resources {
@@ -53,3 +59,200 @@ func toContainerdResources(resources container.Resources) *libcontainerdtypes.Re
 	r.Pids = getPidsLimit(resources)
 	return &r
 }
+
+// freezeAndAddMounts injects newMounts into ctr's running mount namespace so
+// that an update can add bind mounts without recreating the container. It
+// freezes the container with the cgroup freezer (the same primitive
+// containerPause/containerUnpause use) so the processes inside can't race
+// with the namespace switch, bind-mounts each new mount in, and thaws the
+// container again.
+//
+// Through `docker update`, only additions are applied live: changing or
+// removing an existing mount that way still requires a full recreate.
+// ContainerUpdateMounts detaches bind mounts separately, through
+// freezeAndRemoveMounts. Moving a running container
+// to a different cgroup parent isn't handled here - cgroup v1 and v2 differ
+// enough in how a live migration must be done that it's left as a
+// recreate-only change for now.
+func (daemon *Daemon) freezeAndAddMounts(ctr *containerpkg.Container, newMounts []mounttypes.Mount) error {
+	if err := daemon.containerPause(ctr); err != nil {
+		return fmt.Errorf("failed to freeze container for live mount update: %v", err)
+	}
+	defer func() {
+		if err := daemon.containerUnpause(ctr); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to unpause container %s after live mount update: %v\n", ctr.ID, err)
+		}
+	}()
+
+	pid := ctr.State.Pid
+	if pid <= 0 {
+		return fmt.Errorf("container %s has no running process to enter", ctr.ID)
+	}
+
+	for _, m := range newMounts {
+		if err := bindMountIntoNamespace(pid, m.Source, m.Target, m.ReadOnly); err != nil {
+			return fmt.Errorf("failed to add mount %s: %v", m.Target, err)
+		}
+	}
+
+	return nil
+}
+
+// freezeAndRemoveMounts detaches the mounts at the given in-container
+// destination paths from ctr's running mount namespace, freezing the
+// container the same way freezeAndAddMounts does so the unmounts can't race
+// with the processes inside.
+func (daemon *Daemon) freezeAndRemoveMounts(ctr *containerpkg.Container, targets []string) error {
+	if err := daemon.containerPause(ctr); err != nil {
+		return fmt.Errorf("failed to freeze container for live mount update: %v", err)
+	}
+	defer func() {
+		if err := daemon.containerUnpause(ctr); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to unpause container %s after live mount update: %v\n", ctr.ID, err)
+		}
+	}()
+
+	pid := ctr.State.Pid
+	if pid <= 0 {
+		return fmt.Errorf("container %s has no running process to enter", ctr.ID)
+	}
+
+	for _, target := range targets {
+		if err := bindUnmountFromNamespace(pid, target); err != nil {
+			return fmt.Errorf("failed to remove mount %s: %v", target, err)
+		}
+	}
+
+	return nil
+}
+
+// bindMountIntoNamespace enters the mount namespace of pid and bind-mounts
+// source onto target inside it, then returns to the caller's own mount
+// namespace. It must run on a locked OS thread, since mount namespace
+// membership is per-thread.
+func bindMountIntoNamespace(pid int, source, target string, readOnly bool) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("failed to enter mount namespace of pid %d: %v", pid, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNS)
+
+	if err := unix.Mount(source, target, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("failed to bind mount %s onto %s: %v", source, target, err)
+	}
+
+	if readOnly {
+		if err := unix.Mount("", target, "", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_RDONLY, ""); err != nil {
+			return fmt.Errorf("failed to remount %s read-only: %v", target, err)
+		}
+	}
+
+	return nil
+}
+
+// freezeAndResizeTmpfs remounts the tmpfs mounted at each destination in
+// sizes (in bytes) inside ctr's running mount namespace, freezing the
+// container the same way freezeAndAddMounts does so the remount can't race
+// with the processes inside.
+func (daemon *Daemon) freezeAndResizeTmpfs(ctr *containerpkg.Container, sizes map[string]uint64) error {
+	if err := daemon.containerPause(ctr); err != nil {
+		return fmt.Errorf("failed to freeze container for live tmpfs resize: %v", err)
+	}
+	defer func() {
+		if err := daemon.containerUnpause(ctr); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to unpause container %s after live tmpfs resize: %v\n", ctr.ID, err)
+		}
+	}()
+
+	pid := ctr.State.Pid
+	if pid <= 0 {
+		return fmt.Errorf("container %s has no running process to enter", ctr.ID)
+	}
+
+	for target, size := range sizes {
+		if err := resizeTmpfsInNamespace(pid, target, size); err != nil {
+			return fmt.Errorf("failed to resize tmpfs %s: %v", target, err)
+		}
+	}
+
+	return nil
+}
+
+// resizeTmpfsInNamespace enters the mount namespace of pid and remounts the
+// tmpfs at target with a new size, then returns to the caller's own mount
+// namespace. It must run on a locked OS thread, since mount namespace
+// membership is per-thread.
+func resizeTmpfsInNamespace(pid int, target string, size uint64) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("failed to enter mount namespace of pid %d: %v", pid, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNS)
+
+	opts := fmt.Sprintf("size=%d", size)
+	if err := unix.Mount("", target, "tmpfs", unix.MS_REMOUNT, opts); err != nil {
+		return fmt.Errorf("failed to remount tmpfs at %s with %s: %v", target, opts, err)
+	}
+
+	return nil
+}
+
+// bindUnmountFromNamespace enters the mount namespace of pid and detaches
+// whatever is mounted at target inside it, then returns to the caller's own
+// mount namespace. It must run on a locked OS thread, since mount namespace
+// membership is per-thread.
+func bindUnmountFromNamespace(pid int, target string) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	self, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return err
+	}
+	defer self.Close()
+
+	targetNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return err
+	}
+	defer targetNS.Close()
+
+	if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("failed to enter mount namespace of pid %d: %v", pid, err)
+	}
+	defer unix.Setns(int(self.Fd()), unix.CLONE_NEWNS)
+
+	if err := unix.Unmount(target, unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("failed to unmount %s: %v", target, err)
+	}
+
+	return nil
+}
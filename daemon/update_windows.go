@@ -1,7 +1,11 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"errors"
+
 	"github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
+	containerpkg "github.com/docker/docker/container"
 	libcontainerdtypes "github.com/docker/docker/libcontainerd/types"
 )
 
@@ -9,3 +13,22 @@ func toContainerdResources(resources container.Resources) *libcontainerdtypes.Re
 	// We don't support update, so do nothing
 	return nil
 }
+
+// freezeAndAddMounts is unsupported on Windows: UpdateContainer never
+// returns added mounts on this platform, so this should never be called,
+// but is kept in sync with the unix implementation's signature.
+func (daemon *Daemon) freezeAndAddMounts(ctr *containerpkg.Container, newMounts []mounttypes.Mount) error {
+	return errors.New("adding mounts to a running container isn't supported on Windows")
+}
+
+// freezeAndRemoveMounts is unsupported on Windows for the same reason as
+// freezeAndAddMounts.
+func (daemon *Daemon) freezeAndRemoveMounts(ctr *containerpkg.Container, targets []string) error {
+	return errors.New("removing mounts from a running container isn't supported on Windows")
+}
+
+// freezeAndResizeTmpfs is unsupported on Windows: there is no tmpfs mount
+// type on this platform to begin with.
+func (daemon *Daemon) freezeAndResizeTmpfs(ctr *containerpkg.Container, sizes map[string]uint64) error {
+	return errors.New("resizing tmpfs mounts isn't supported on Windows")
+}
@@ -0,0 +1,113 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// clockTicksPerSecond is the kernel's USER_HZ value used to scale the
+// utime/stime fields of /proc/[pid]/stat into seconds. This is the
+// overwhelmingly common value on Linux; there is no portable way to read
+// sysconf(_SC_CLK_TCK) from Go without cgo.
+const clockTicksPerSecond = 100
+
+// userlandProxyUsage scans /proc for running docker-proxy processes and
+// reports their resource usage. docker-proxy's command line always encodes
+// the published port it is forwarding (see the newProxyCommand helper in
+// the vendored libnetwork portmapper package), so no additional daemon-side
+// bookkeeping is needed to attribute a process to a port.
+func (daemon *Daemon) userlandProxyUsage() []types.UserlandProxyUsage {
+	if !daemon.configStore.BridgeConfig.EnableUserlandProxy {
+		return nil
+	}
+
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+
+	var usage []types.UserlandProxyUsage
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		cmdline, err := ioutil.ReadFile(filepath.Join("/proc", entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		if len(args) == 0 || filepath.Base(args[0]) != "docker-proxy" {
+			continue
+		}
+
+		u := types.UserlandProxyUsage{PID: pid}
+		for i := 0; i+1 < len(args); i++ {
+			switch args[i] {
+			case "-proto":
+				u.Proto = args[i+1]
+			case "-host-ip":
+				u.HostIP = args[i+1]
+			case "-host-port":
+				if p, err := strconv.Atoi(args[i+1]); err == nil {
+					u.HostPort = p
+				}
+			}
+		}
+
+		if rss, cpu, err := readProcUsage(pid); err == nil {
+			u.RSSBytes = rss
+			u.CPUSeconds = cpu
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// readProcUsage reads the resident set size and accumulated CPU time of pid
+// from procfs.
+func readProcUsage(pid int) (rssBytes uint64, cpuSeconds float64, err error) {
+	statData, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, 0, err
+	}
+	// The second field (comm) is parenthesized and may itself contain
+	// spaces or closing parens, so locate the fields that follow it by the
+	// last ')' rather than splitting naively on whitespace.
+	s := string(statData)
+	idx := strings.LastIndex(s, ")")
+	if idx < 0 {
+		return 0, 0, fmt.Errorf("unexpected format in /proc/%d/stat", pid)
+	}
+	fields := strings.Fields(s[idx+1:])
+	// Field 3 (state) is fields[0] here; utime is field 14 and stime is
+	// field 15 overall, i.e. fields[11] and fields[12].
+	if len(fields) < 13 {
+		return 0, 0, fmt.Errorf("unexpected format in /proc/%d/stat", pid)
+	}
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	cpuSeconds = float64(utime+stime) / clockTicksPerSecond
+
+	statusData, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "status"))
+	if err != nil {
+		return 0, cpuSeconds, err
+	}
+	for _, line := range strings.Split(string(statusData), "\n") {
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			if kb, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+				rssBytes = kb * 1024
+			}
+		}
+		break
+	}
+	return rssBytes, cpuSeconds, nil
+}
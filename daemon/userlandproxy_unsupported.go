@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import "github.com/docker/docker/api/types"
+
+// userlandProxyUsage is unsupported outside Linux: there is no procfs to
+// read resource usage from, so userland proxy usage is omitted from
+// /info on this platform rather than reported inaccurately.
+func (daemon *Daemon) userlandProxyUsage() []types.UserlandProxyUsage { return nil }
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package daemon
@@ -51,12 +52,18 @@ func (c *MockContainerdClient) Resume(ctx context.Context, containerID string) e
 func (c *MockContainerdClient) Stats(ctx context.Context, containerID string) (*libcontainerdtypes.Stats, error) {
 	return nil, nil
 }
+func (c *MockContainerdClient) AllStats(ctx context.Context) (map[string]*libcontainerdtypes.Stats, error) {
+	return nil, nil
+}
 func (c *MockContainerdClient) ListPids(ctx context.Context, containerID string) ([]uint32, error) {
 	return nil, nil
 }
 func (c *MockContainerdClient) Summary(ctx context.Context, containerID string) ([]libcontainerdtypes.Summary, error) {
 	return nil, nil
 }
+func (c *MockContainerdClient) RuntimeInfo(ctx context.Context, containerID string) (*libcontainerdtypes.RuntimeInfo, error) {
+	return nil, nil
+}
 func (c *MockContainerdClient) DeleteTask(ctx context.Context, containerID string) (uint32, time.Time, error) {
 	return 0, time.Time{}, nil
 }
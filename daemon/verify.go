@@ -0,0 +1,20 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/daemon/operations"
+)
+
+// SystemVerify re-hashes all blobs and layers in the content and layer
+// stores against the digests they were registered under, for the
+// `docker system verify` API. See images.ImageService.VerifyContentStore
+// for what's actually checked and repaired.
+func (daemon *Daemon) SystemVerify(ctx context.Context) (*types.ContentVerifyReport, error) {
+	op, ctx := operations.Start(ctx, "content-verify")
+	defer op.Finish()
+
+	op.SetProgress("re-hashing layers and image config blobs", 0, 0, "")
+	return daemon.imageService.VerifyContentStore(ctx)
+}
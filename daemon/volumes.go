@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -14,6 +15,8 @@ import (
 	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
 	"github.com/docker/docker/volume"
 	volumemounts "github.com/docker/docker/volume/mounts"
 	"github.com/docker/docker/volume/service"
@@ -221,6 +224,12 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 			mp.SkipMountpointCreation = true
 		}
 
+		if mp.Type == mounttypes.TypeImage {
+			if err := daemon.lazyInitializeImageMount(container.OS, mp); err != nil {
+				return err
+			}
+		}
+
 		binds[mp.Destination] = true
 		dereferenceIfExists(mp.Destination)
 		mountPoints[mp.Destination] = mp
@@ -256,6 +265,31 @@ func (daemon *Daemon) lazyInitializeVolume(containerID string, m *volumemounts.M
 	return nil
 }
 
+// lazyInitializeImageMount resolves the image backing a "type=image" mount
+// point if needed. Like lazyInitializeVolume, this happens after a daemon
+// restart, since MountPoint.Volume isn't persisted to disk.
+func (daemon *Daemon) lazyInitializeImageMount(containerOS string, m *volumemounts.MountPoint) error {
+	if m.Type != mounttypes.TypeImage || m.Volume != nil {
+		return nil
+	}
+	var subpath string
+	if m.Spec.ImageOptions != nil {
+		subpath = m.Spec.ImageOptions.Subpath
+	}
+	img, err := daemon.imageService.GetImage(m.Name, nil)
+	if err != nil {
+		return err
+	}
+	m.Volume = &imageMount{
+		ref:     m.Name,
+		subpath: subpath,
+		os:      containerOS,
+		img:     img,
+		images:  daemon.imageService,
+	}
+	return nil
+}
+
 // backportMountSpec resolves mount specs (introduced in 1.13) from pre-1.13
 // mount configurations
 // The container lock should not be held when calling this function.
@@ -421,3 +455,95 @@ func (v *volumeWrapper) CreatedAt() (time.Time, error) {
 func (v *volumeWrapper) Status() map[string]interface{} {
 	return v.v.Status
 }
+
+// imageLayerMounter is the subset of ImageService used by imageMount to
+// create and release the scratch layer an image mount is backed by.
+type imageLayerMounter interface {
+	CreateImageMountLayer(img *image.Image, os, mountID string) (layer.RWLayer, error)
+	ReleaseLayer(rwlayer layer.RWLayer, os string) error
+}
+
+// imageMount adapts a "type=image" mount so it can be plugged into
+// volumemounts.MountPoint the same way a volume.Volume is: the read-only
+// view onto the image's filesystem is backed by a throwaway layer.RWLayer
+// (the same mechanism used to give a container its own writable layer),
+// which is never written to and is created lazily on first Mount.
+type imageMount struct {
+	ref     string // the image reference or ID as given by the user
+	subpath string
+	os      string
+	img     *image.Image
+	images  imageLayerMounter
+
+	mu    sync.Mutex
+	layer layer.RWLayer
+	count int
+}
+
+func (m *imageMount) Name() string {
+	return m.ref
+}
+
+func (m *imageMount) DriverName() string {
+	return "image"
+}
+
+func (m *imageMount) Path() string {
+	if m.layer == nil {
+		return ""
+	}
+	return m.layer.Name()
+}
+
+func (m *imageMount) Mount(id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.layer == nil {
+		l, err := m.images.CreateImageMountLayer(m.img, m.os, id)
+		if err != nil {
+			return "", errors.Wrapf(err, "error creating image mount layer for %s", m.ref)
+		}
+		m.layer = l
+	}
+
+	root, err := m.layer.Mount("")
+	if err != nil {
+		return "", errors.Wrapf(err, "error mounting image %s", m.ref)
+	}
+
+	m.count++
+	p := root.Path()
+	if m.subpath != "" {
+		p = filepath.Join(p, m.subpath)
+	}
+	return p, nil
+}
+
+func (m *imageMount) Unmount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.layer == nil {
+		return nil
+	}
+	if err := m.layer.Unmount(); err != nil {
+		return errors.Wrapf(err, "error unmounting image %s", m.ref)
+	}
+
+	m.count--
+	if m.count <= 0 {
+		err := m.images.ReleaseLayer(m.layer, m.os)
+		m.layer = nil
+		return err
+	}
+	return nil
+}
+
+func (m *imageMount) CreatedAt() (time.Time, error) {
+	return time.Time{}, errors.New("not implemented")
+}
+
+func (m *imageMount) Status() map[string]interface{} {
+	return map[string]interface{}{"Image": m.ref}
+}
@@ -24,6 +24,9 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 		if err := daemon.lazyInitializeVolume(c.ID, mount); err != nil {
 			return nil, err
 		}
+		if err := daemon.lazyInitializeImageMount(c.OS, mount); err != nil {
+			return nil, err
+		}
 		s, err := mount.Setup(c.MountLabel, idtools.Identity{}, nil)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,89 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/docker/docker/container"
+	"github.com/pkg/errors"
+)
+
+// waitForHostTimeout bounds how long containerStart waits for a
+// container's HostConfig.WaitFor entries to become ready.
+const waitForHostTimeout = 60 * time.Second
+
+// waitForHostServices blocks until every entry in ctr.HostConfig.WaitFor
+// reports ready, or until waitForHostTimeout elapses. It exists to close
+// the race between docker.service and the host network/storage services
+// a container may depend on when the daemon restarts containers at boot.
+func (daemon *Daemon) waitForHostServices(ctr *container.Container) error {
+	if len(ctr.HostConfig.WaitFor) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitForHostTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for _, spec := range ctr.HostConfig.WaitFor {
+		for {
+			ready, err := hostDependencyReady(spec)
+			if err != nil {
+				return errors.Wrapf(err, "waiting for host dependency %q of container %s", spec, ctr.ID)
+			}
+			if ready {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return errors.Errorf("timed out waiting for host dependency %q of container %s", spec, ctr.ID)
+			case <-ticker.C:
+			}
+		}
+	}
+	return nil
+}
+
+// hostDependencyReady reports whether the host-level dependency described
+// by spec is currently ready. See HostConfig.WaitFor for the supported
+// spec formats.
+func hostDependencyReady(spec string) (bool, error) {
+	switch {
+	case strings.HasPrefix(spec, "unit:"):
+		return systemdUnitActive(strings.TrimPrefix(spec, "unit:"))
+	case strings.HasPrefix(spec, "tcp:"):
+		return tcpEndpointReachable(strings.TrimPrefix(spec, "tcp:"))
+	default:
+		return false, errors.Errorf("unrecognized wait-for spec %q", spec)
+	}
+}
+
+func tcpEndpointReachable(addr string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		return false, nil
+	}
+	conn.Close()
+	return true, nil
+}
+
+func systemdUnitActive(unit string) (bool, error) {
+	conn, err := dbus.NewSystemConnection()
+	if err != nil {
+		return false, errors.Wrap(err, "connecting to systemd")
+	}
+	defer conn.Close()
+
+	prop, err := conn.GetUnitProperty(unit, "ActiveState")
+	if err != nil {
+		return false, errors.Wrapf(err, "querying systemd unit %q", unit)
+	}
+	state, _ := prop.Value.Value().(string)
+	return state == "active", nil
+}
@@ -0,0 +1,102 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/container"
+	"github.com/sirupsen/logrus"
+)
+
+// warmImageCacheLabel names the container.Config.Labels key (inherited from
+// the image) that lists files, relative to the container's rootfs, whose
+// content is worth preloading into the page cache before the container's
+// process starts. Multiple files are newline-separated.
+const warmImageCacheLabel = "com.docker.image.warm-cache-files"
+
+// warmImageCache preloads the hot files of a container's image into the
+// host's page cache before the container's process starts, to cut cold
+// start latency for large runtime images. The file list comes from the
+// image's warmImageCacheLabel annotation, if set, merged with whatever list
+// was recorded from a previous start of this same image. Best effort: any
+// failure here must not prevent the container from starting.
+func (daemon *Daemon) warmImageCache(ctr *container.Container) {
+	if ctr.BaseFS == nil {
+		return
+	}
+
+	paths := daemon.hotFilesForContainer(ctr)
+	if len(paths) == 0 {
+		return
+	}
+
+	warmed := make([]string, 0, len(paths))
+	for _, path := range paths {
+		resolved, err := ctr.GetResourcePath(path)
+		if err != nil {
+			continue
+		}
+		if warmFile(resolved) {
+			warmed = append(warmed, path)
+		}
+	}
+
+	if len(warmed) > 0 {
+		if err := daemon.imageService.SetImageHotFiles(ctr.ImageID, warmed); err != nil {
+			logrus.WithError(err).WithField("container", ctr.ID).Warn("recording warmed image hot files")
+		}
+	}
+}
+
+// hotFilesForContainer collects the candidate hot file paths for ctr's
+// image: the ones declared on the image's warmImageCacheLabel, plus any
+// recorded from a previous run, deduplicated.
+func (daemon *Daemon) hotFilesForContainer(ctr *container.Container) []string {
+	seen := make(map[string]struct{})
+	var paths []string
+
+	add := func(path string) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return
+		}
+		if _, ok := seen[path]; ok {
+			return
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	if label := ctr.Config.Labels[warmImageCacheLabel]; label != "" {
+		for _, path := range strings.Split(label, "\n") {
+			add(path)
+		}
+	}
+
+	if recorded, err := daemon.imageService.ImageHotFiles(ctr.ImageID); err == nil {
+		for _, path := range recorded {
+			add(path)
+		}
+	}
+
+	return paths
+}
+
+// warmFile reads path in full, discarding its content, so the kernel pulls
+// it into the page cache. It reports whether the read succeeded.
+func warmFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	if fi, err := f.Stat(); err != nil || fi.IsDir() {
+		return false
+	}
+
+	_, err = io.Copy(ioutil.Discard, f)
+	return err == nil
+}
@@ -62,6 +62,17 @@ type ImagePullConfig struct {
 	Schema2Types []string
 	// Platform is the requested platform of the image being pulled
 	Platform *specs.Platform
+	// AllowLazyPull makes the puller recognise layers that are indexed as
+	// seekable eStargz. No remote snapshotter is wired into the pull path,
+	// so such layers are still downloaded and unpacked in full, like any
+	// other layer.
+	AllowLazyPull bool
+	// LowPriority marks this pull as deferrable: if the registry we'd pull
+	// from is already known to be low on rate-limit quota, Pull returns
+	// ErrPullDeferred immediately instead of spending part of that quota.
+	// It has no effect against a registry that hasn't sent rate-limit
+	// headers yet, since there's no observed quota to be low on.
+	LowPriority bool
 }
 
 // ImagePushConfig stores push configuration.
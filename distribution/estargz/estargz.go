@@ -0,0 +1,17 @@
+// Package estargz provides helpers for detecting seekable eStargz layers
+// during a pull, so that the puller can skip unpacking the full layer
+// blob in favor of a lazy-pulling remote snapshotter when one is
+// available, falling back to a normal pull otherwise.
+package estargz // import "github.com/docker/docker/distribution/estargz"
+
+// TOCDigestAnnotation is the descriptor annotation eStargz-indexed layers
+// set to point at their table-of-contents digest, as defined by the
+// stargz-snapshotter project.
+const TOCDigestAnnotation = "containerd.io/snapshot/stargz/toc.digest"
+
+// IsLayer reports whether the given descriptor annotations mark the layer
+// as a seekable eStargz layer.
+func IsLayer(annotations map[string]string) bool {
+	_, ok := annotations[TOCDigestAnnotation]
+	return ok
+}
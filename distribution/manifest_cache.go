@@ -0,0 +1,140 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// manifestCacheTTL bounds how long a resolved manifest response is served
+// straight out of the cache before being revalidated against the registry.
+// Keeping this short bounds staleness while still absorbing the repeated
+// resolves (e.g. swarm's periodic reconciliation, or several concurrent
+// pulls of the same tag) that would otherwise each cost a full manifest
+// fetch against the registry's rate limit.
+const manifestCacheTTL = 30 * time.Second
+
+// defaultManifestCache is shared by every repository opened during the
+// daemon's lifetime, so that resolving the same tag or digest again within
+// the TTL doesn't cost a registry round trip at all, and resolving it again
+// after the TTL costs a conditional request rather than a full fetch.
+var defaultManifestCache = newManifestCache(manifestCacheTTL)
+
+// manifestCache caches registry responses to GET .../manifests/<ref>
+// requests, keyed by request URL and Accept header. Entries younger than
+// the TTL are served without talking to the registry at all; older entries
+// are revalidated with a conditional (If-None-Match) request, which the
+// registry answers with a cheap 304 Not Modified when the tag hasn't moved.
+type manifestCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*manifestCacheEntry
+}
+
+type manifestCacheEntry struct {
+	etag      string
+	status    int
+	header    http.Header
+	body      []byte
+	fetchedAt time.Time
+}
+
+func newManifestCache(ttl time.Duration) *manifestCache {
+	return &manifestCache{ttl: ttl, entries: make(map[string]*manifestCacheEntry)}
+}
+
+func isManifestRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/manifests/")
+}
+
+func (c *manifestCache) key(req *http.Request) string {
+	return req.URL.String() + "|" + req.Header.Get("Accept")
+}
+
+func (c *manifestCache) roundTrip(base http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if !isManifestRequest(req) {
+		return base.RoundTrip(req)
+	}
+
+	key := c.key(req)
+
+	c.mu.Lock()
+	entry := c.entries[key]
+	c.mu.Unlock()
+
+	if entry != nil && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.response(req), nil
+	}
+
+	if entry != nil && entry.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		entry.fetchedAt = time.Now()
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	newEntry := &manifestCacheEntry{
+		etag:      resp.Header.Get("Etag"),
+		status:    resp.StatusCode,
+		header:    resp.Header.Clone(),
+		body:      body,
+		fetchedAt: time.Now(),
+	}
+	if newEntry.etag != "" {
+		c.mu.Lock()
+		c.entries[key] = newEntry
+		c.mu.Unlock()
+	}
+
+	return newEntry.response(req), nil
+}
+
+func (e *manifestCacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// manifestCacheTransport wraps an http.RoundTripper, transparently caching
+// and revalidating manifest GETs through a manifestCache so repeated
+// resolves of the same tag or digest don't each cost a full fetch against
+// the registry.
+type manifestCacheTransport struct {
+	base  http.RoundTripper
+	cache *manifestCache
+}
+
+func (t *manifestCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.cache.roundTrip(t.base, req)
+}
@@ -0,0 +1,119 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type stubManifestTransport struct {
+	requests int
+	handler  func(req *http.Request) *http.Response
+}
+
+func (t *stubManifestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return t.handler(req), nil
+}
+
+func newManifestResponse(status int, etag, body string) *http.Response {
+	header := make(http.Header)
+	if etag != "" {
+		header.Set("Etag", etag)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func newManifestRequest(t *testing.T) *http.Request {
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/foo/manifests/latest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestManifestCacheServesWithinTTLWithoutRoundTrip(t *testing.T) {
+	stub := &stubManifestTransport{handler: func(req *http.Request) *http.Response {
+		return newManifestResponse(http.StatusOK, `"abc"`, "manifest-body")
+	}}
+	cache := newManifestCache(manifestCacheTTL)
+
+	for i := 0; i < 3; i++ {
+		resp, err := cache.roundTrip(stub, newManifestRequest(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != "manifest-body" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	}
+
+	if stub.requests != 1 {
+		t.Fatalf("expected 1 registry request, got %d", stub.requests)
+	}
+}
+
+func TestManifestCacheRevalidatesAfterTTLAndServesOn304(t *testing.T) {
+	first := true
+	stub := &stubManifestTransport{handler: func(req *http.Request) *http.Response {
+		if first {
+			first = false
+			return newManifestResponse(http.StatusOK, `"abc"`, "manifest-body")
+		}
+		if req.Header.Get("If-None-Match") != `"abc"` {
+			t.Fatalf("expected conditional request with If-None-Match, got %q", req.Header.Get("If-None-Match"))
+		}
+		return newManifestResponse(http.StatusNotModified, "", "")
+	}}
+	// An already-expired TTL forces every call after the first to revalidate.
+	cache := newManifestCache(0)
+
+	resp, err := cache.roundTrip(stub, newManifestRequest(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(resp.Body)
+
+	resp, err = cache.roundTrip(stub, newManifestRequest(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "manifest-body" {
+		t.Fatalf("expected cached body to be served on 304, got %q", body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected caller to see 200 on revalidated cache hit, got %d", resp.StatusCode)
+	}
+	if stub.requests != 2 {
+		t.Fatalf("expected 2 registry requests, got %d", stub.requests)
+	}
+}
+
+func TestManifestCacheIgnoresNonManifestRequests(t *testing.T) {
+	stub := &stubManifestTransport{handler: func(req *http.Request) *http.Response {
+		return newManifestResponse(http.StatusOK, `"abc"`, "blob-body")
+	}}
+	cache := newManifestCache(manifestCacheTTL)
+
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/foo/blobs/sha256:abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.roundTrip(stub, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if stub.requests != 2 {
+		t.Fatalf("expected every non-manifest request to pass through, got %d requests", stub.requests)
+	}
+}
@@ -0,0 +1,173 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ManifestListSource names one existing, already-pushed image to include in
+// a manifest list assembled by CreateAndPushManifestList. Ref must resolve
+// (by tag or digest) to a manifest already present in the target
+// repository; this mirrors the constraint the `docker manifest create`
+// client tool has, since assembling a list only references existing
+// manifests rather than pushing new image content.
+type ManifestListSource struct {
+	Ref reference.Named
+	// Annotations are merged onto this entry's descriptor in the
+	// resulting list.
+	Annotations map[string]string
+}
+
+// CreateAndPushManifestList fetches the manifest descriptor for each of
+// sources from the registry, assembles them into a single OCI image index,
+// and pushes that index to target. A source whose platform does not match
+// platformFilter (when platformFilter is non-empty) is skipped, so a caller
+// can build a list from a superset of available per-arch images.
+//
+// This only assembles and pushes a list over manifests that already exist
+// server-side; it does not push the per-platform images themselves.
+func CreateAndPushManifestList(ctx context.Context, target reference.Named, sources []ManifestListSource, platformFilter []specs.Platform, pushConfig *ImagePushConfig) (digest.Digest, error) {
+	repoInfo, err := pushConfig.RegistryService.ResolveRepository(target)
+	if err != nil {
+		return "", err
+	}
+	endpoints, err := pushConfig.RegistryService.LookupPushEndpoints(reference.Domain(repoInfo.Name))
+	if err != nil {
+		return "", err
+	}
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("no push endpoints found for %s", reference.Domain(repoInfo.Name))
+	}
+	endpoint := endpoints[0]
+
+	repo, _, err := NewV2Repository(ctx, repoInfo, endpoint, pushConfig.MetaHeaders, pushConfig.AuthConfig, "pull", "push")
+	if err != nil {
+		return "", err
+	}
+
+	var descriptors []manifestlist.ManifestDescriptor
+	for _, src := range sources {
+		desc, platform, err := resolveManifestPlatform(ctx, repo, src.Ref, pushConfig.ImageStore)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", reference.FamiliarString(src.Ref), err)
+		}
+		if len(platformFilter) > 0 && !platformMatchesAny(platform, platformFilter) {
+			continue
+		}
+		if len(src.Annotations) > 0 {
+			if desc.Annotations == nil {
+				desc.Annotations = make(map[string]string, len(src.Annotations))
+			}
+			for k, v := range src.Annotations {
+				desc.Annotations[k] = v
+			}
+		}
+		descriptors = append(descriptors, manifestlist.ManifestDescriptor{
+			Descriptor: desc,
+			Platform: manifestlist.PlatformSpec{
+				Architecture: platform.Architecture,
+				OS:           platform.OS,
+				OSVersion:    platform.OSVersion,
+				Variant:      platform.Variant,
+			},
+		})
+	}
+	if len(descriptors) == 0 {
+		return "", fmt.Errorf("no source manifests matched; nothing to push for %s", reference.FamiliarString(target))
+	}
+
+	index, err := manifestlist.FromDescriptorsWithMediaType(descriptors, specs.MediaTypeImageIndex)
+	if err != nil {
+		return "", err
+	}
+
+	var putOptions []distribution.ManifestServiceOption
+	if tagged, ok := target.(reference.NamedTagged); ok {
+		putOptions = append(putOptions, distribution.WithTag(tagged.Tag()))
+	}
+
+	manifestService, err := repo.Manifests(ctx)
+	if err != nil {
+		return "", err
+	}
+	return manifestService.Put(ctx, index, putOptions...)
+}
+
+// resolveManifestPlatform fetches ref's manifest descriptor and platform
+// from repo. If ref's manifest is itself a single-platform schema2/OCI
+// manifest, the platform is read from its image config blob, the same way
+// the registry client resolves platform for a plain (non-list) image.
+func resolveManifestPlatform(ctx context.Context, repo distribution.Repository, ref reference.Named, imageStore ImageConfigStore) (distribution.Descriptor, specs.Platform, error) {
+	manifestService, err := repo.Manifests(ctx)
+	if err != nil {
+		return distribution.Descriptor{}, specs.Platform{}, err
+	}
+
+	var getOptions []distribution.ManifestServiceOption
+	var dgst digest.Digest
+	if tagged, ok := ref.(reference.NamedTagged); ok {
+		getOptions = append(getOptions, distribution.WithTag(tagged.Tag()))
+	} else if canonical, ok := ref.(reference.Canonical); ok {
+		dgst = canonical.Digest()
+	} else {
+		return distribution.Descriptor{}, specs.Platform{}, fmt.Errorf("reference %q has neither a tag nor a digest", ref.String())
+	}
+
+	manifest, err := manifestService.Get(ctx, dgst, getOptions...)
+	if err != nil {
+		return distribution.Descriptor{}, specs.Platform{}, err
+	}
+
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		return distribution.Descriptor{}, specs.Platform{}, err
+	}
+	if dgst == "" {
+		dgst = digest.FromBytes(payload)
+	}
+	desc := distribution.Descriptor{
+		MediaType: mediaType,
+		Size:      int64(len(payload)),
+		Digest:    dgst,
+	}
+
+	var configDigest digest.Digest
+	switch m := manifest.(type) {
+	case *schema2.DeserializedManifest:
+		configDigest = m.Config.Digest
+	case *ocischema.DeserializedManifest:
+		configDigest = m.Config.Digest
+	default:
+		return distribution.Descriptor{}, specs.Platform{}, fmt.Errorf("%q is not a single-platform image manifest, cannot determine its platform", ref.String())
+	}
+
+	blobs := repo.Blobs(ctx)
+	configBlob, err := blobs.Get(ctx, configDigest)
+	if err != nil {
+		return distribution.Descriptor{}, specs.Platform{}, fmt.Errorf("failed to fetch image config for %s: %w", ref.String(), err)
+	}
+	platform, err := imageStore.PlatformFromConfig(configBlob)
+	if err != nil {
+		return distribution.Descriptor{}, specs.Platform{}, err
+	}
+	return desc, *platform, nil
+}
+
+func platformMatchesAny(p specs.Platform, filters []specs.Platform) bool {
+	for _, f := range filters {
+		if (f.OS == "" || f.OS == p.OS) &&
+			(f.Architecture == "" || f.Architecture == p.Architecture) &&
+			(f.Variant == "" || f.Variant == p.Variant) {
+			return true
+		}
+	}
+	return false
+}
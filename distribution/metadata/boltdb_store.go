@@ -0,0 +1,73 @@
+package metadata // import "github.com/docker/docker/distribution/metadata"
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltMetadataStore is a Store implementation backed by a single bbolt
+// database file, using one bucket per namespace. Unlike FSMetadataStore,
+// which lays the store out as one file per key, it keeps all distribution
+// metadata in a single file, which is easier to back up, inspect, and
+// ship between daemons.
+type BoltMetadataStore struct {
+	db *bolt.DB
+}
+
+// NewBoltMetadataStore creates a new bbolt-backed metadata store at path.
+func NewBoltMetadataStore(path string) (*BoltMetadataStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltMetadataStore{db: db}, nil
+}
+
+// Get retrieves data by namespace and key.
+func (s *BoltMetadataStore) Get(namespace string, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(namespace))
+		if b == nil {
+			return errNotFound{}
+		}
+		v := b.Get([]byte(key))
+		if v == nil {
+			return errNotFound{}
+		}
+		value = make([]byte, len(v))
+		copy(value, v)
+		return nil
+	})
+	return value, err
+}
+
+// Set writes data indexed by namespace and key.
+func (s *BoltMetadataStore) Set(namespace, key string, value []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(namespace))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), value)
+	})
+}
+
+// Delete removes data indexed by namespace and key.
+func (s *BoltMetadataStore) Delete(namespace, key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(namespace))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+// Close releases the underlying bbolt database file.
+func (s *BoltMetadataStore) Close() error {
+	return s.db.Close()
+}
+
+type errNotFound struct{}
+
+func (errNotFound) Error() string { return "metadata: key not found" }
@@ -0,0 +1,99 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/docker/distribution"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// P2PFetcher fetches a layer blob from a peer-to-peer distributor, such as
+// Dragonfly or a BitTorrent-style client, in place of the registry.
+type P2PFetcher interface {
+	// Fetch returns the blob identified by dgst. The caller verifies the
+	// returned content against dgst itself, the same way it verifies a
+	// registry response, so Fetch does not need to check the digest.
+	Fetch(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error)
+}
+
+// p2pFetcher is the process-wide P2PFetcher configured by SetP2PFetcher, or
+// nil if none is configured. Layer downloads try it before falling back to
+// the registry; see v2LayerDescriptor.open in pull_v2_unix.go/
+// pull_v2_windows.go.
+var p2pFetcher P2PFetcher
+
+// SetP2PFetcher installs f as the peer-to-peer blob fetcher layer downloads
+// try before falling back to the registry. Passing nil (the default)
+// disables it, so every layer is fetched from the registry as before.
+func SetP2PFetcher(f P2PFetcher) {
+	p2pFetcher = f
+}
+
+// execP2PFetcher is a P2PFetcher that runs an external command as
+// "<command> <digest>" and reads the blob from its stdout.
+type execP2PFetcher struct {
+	command string
+}
+
+// NewExecP2PFetcher returns a P2PFetcher that shells out to command,
+// suitable for SetP2PFetcher. See the doc comment on
+// config.CommonConfig.P2PDistributionCommand for the command's contract.
+func NewExecP2PFetcher(command string) P2PFetcher {
+	return &execP2PFetcher{command: command}
+}
+
+// spooledBlob adapts a blob spooled to a temp file (which is what an
+// execP2PFetcher produces, since a subprocess's stdout isn't seekable) to
+// distribution.ReadSeekCloser, which the rest of the download path needs to
+// report progress and retry partial reads. Closing it removes the temp file.
+type spooledBlob struct {
+	*os.File
+}
+
+func (b spooledBlob) Close() error {
+	err := b.File.Close()
+	if rmErr := os.Remove(b.File.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func (f *execP2PFetcher) Fetch(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	cmd := exec.CommandContext(ctx, f.command, dgst.String())
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	tmpFile, err := ioutil.TempFile("", "p2p-fetch")
+	if err != nil {
+		cmd.Wait()
+		return nil, err
+	}
+	n, copyErr := io.Copy(tmpFile, stdout)
+	waitErr := cmd.Wait()
+	if copyErr != nil || waitErr != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		if copyErr != nil {
+			return nil, copyErr
+		}
+		return nil, waitErr
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return nil, err
+	}
+
+	logrus.Debugf("p2p distribution: fetched %s (%d bytes) via %q", dgst, n, f.command)
+	return spooledBlob{tmpFile}, nil
+}
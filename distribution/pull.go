@@ -24,6 +24,19 @@ type Puller interface {
 	Pull(ctx context.Context, ref reference.Named, platform *specs.Platform) error
 }
 
+// allEndpointsLowOnQuota reports whether every endpoint has an observed
+// rate-limit quota and that quota is low. A registry that hasn't sent
+// rate-limit headers yet (no observed quota) never counts as low, so an
+// unfamiliar registry is never deferred.
+func allEndpointsLowOnQuota(endpoints []registry.APIEndpoint) bool {
+	for _, endpoint := range endpoints {
+		if !LowOnRegistryQuota(endpoint.URL.Host, lowPriorityQuotaThreshold) {
+			return false
+		}
+	}
+	return len(endpoints) > 0
+}
+
 // newPuller returns a Puller interface that will pull from either a v1 or v2
 // registry. The endpoint argument contains a Version field that determines
 // whether a v1 or v2 puller will be created. The other parameters are passed
@@ -44,6 +57,17 @@ func newPuller(endpoint registry.APIEndpoint, repoInfo *registry.RepositoryInfo,
 	return nil, fmt.Errorf("unknown version %d for registry %s", endpoint.Version, endpoint.URL)
 }
 
+// lowPriorityQuotaThreshold is the fraction of a registry's rate-limit
+// quota remaining below which a LowPriority pull is deferred rather than
+// attempted.
+const lowPriorityQuotaThreshold = 0.1
+
+// ErrPullDeferred is returned by Pull when imagePullConfig.LowPriority is
+// set and every endpoint for the repository's registry is low on
+// rate-limit quota. Callers should retry the pull later rather than
+// treating this as a pull failure.
+var ErrPullDeferred = errors.New("deferred: registry rate-limit quota is low, retry this low-priority pull later")
+
 // Pull initiates a pull operation. image is the repository name to pull, and
 // tag may be either empty, or indicate a specific tag to pull.
 func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullConfig) error {
@@ -63,6 +87,10 @@ func Pull(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullCo
 		return err
 	}
 
+	if imagePullConfig.LowPriority && allEndpointsLowOnQuota(endpoints) {
+		return ErrPullDeferred
+	}
+
 	var (
 		lastErr error
 
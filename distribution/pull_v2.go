@@ -21,6 +21,7 @@ import (
 	"github.com/docker/distribution/registry/api/errcode"
 	"github.com/docker/distribution/registry/client/auth"
 	"github.com/docker/distribution/registry/client/transport"
+	"github.com/docker/docker/distribution/estargz"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
@@ -401,6 +402,7 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named, platform
 			logrus.Warn(msg)
 			progress.Message(p.config.ProgressOutput, "", msg)
 		}
+		recordSchema1Pull(reference.FamiliarName(ref))
 
 		id, manifestDigest, err = p.pullSchema1(ctx, ref, v, platform)
 		if err != nil {
@@ -575,6 +577,13 @@ func (p *v2Puller) pullSchema2Layers(ctx context.Context, target distribution.De
 	// Note that the order of this loop is in the direction of bottom-most
 	// to top-most, so that the downloads slice gets ordered correctly.
 	for _, d := range layers {
+		if p.config.AllowLazyPull && estargz.IsLayer(d.Annotations) {
+			// TODO: hand this layer to a remote snapshotter instead of
+			// downloading and unpacking it eagerly. Until that
+			// integration lands, fall through to a normal pull.
+			logrus.WithField("layer", d.Digest).Debug("lazy-pullable eStargz layer detected, falling back to normal pull")
+		}
+
 		layerDescriptor := &v2LayerDescriptor{
 			digest:            d.Digest,
 			repo:              p.repo,
@@ -817,6 +826,7 @@ func (p *v2Puller) pullManifestList(ctx context.Context, ref reference.Named, mf
 		msg := fmt.Sprintf("[DEPRECATION NOTICE] v2 schema1 manifests in manifest lists are not supported and will break in a future release. Suggest author of %s to upgrade to v2 schema2. More information at https://docs.docker.com/registry/spec/deprecated-schema-v1/", ref)
 		logrus.Warn(msg)
 		progress.Message(p.config.ProgressOutput, "", msg)
+		recordSchema1Pull(reference.FamiliarName(ref))
 
 		platform := toOCIPlatform(manifestMatches[0].Platform)
 		id, _, err = p.pullSchema1(ctx, manifestRef, v, &platform)
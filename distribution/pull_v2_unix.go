@@ -1,3 +1,4 @@
+//go:build !windows
 // +build !windows
 
 package distribution // import "github.com/docker/docker/distribution"
@@ -13,6 +14,14 @@ import (
 )
 
 func (ld *v2LayerDescriptor) open(ctx context.Context) (distribution.ReadSeekCloser, error) {
+	if p2pFetcher != nil {
+		if rsc, err := p2pFetcher.Fetch(ctx, ld.digest); err == nil {
+			return rsc, nil
+		} else {
+			logrus.Debugf("p2p distribution: fetch of %s failed, falling back to registry: %v", ld.digest, err)
+		}
+	}
+
 	blobs := ld.repo.Blobs(ctx)
 	return blobs.Open(ctx, ld.digest)
 }
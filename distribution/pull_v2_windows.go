@@ -32,6 +32,14 @@ func (ld *v2LayerDescriptor) Descriptor() distribution.Descriptor {
 }
 
 func (ld *v2LayerDescriptor) open(ctx context.Context) (distribution.ReadSeekCloser, error) {
+	if p2pFetcher != nil {
+		if rsc, err := p2pFetcher.Fetch(ctx, ld.digest); err == nil {
+			return rsc, nil
+		} else {
+			logrus.Debugf("p2p distribution: fetch of %s failed, falling back to registry: %v", ld.digest, err)
+		}
+	}
+
 	blobs := ld.repo.Blobs(ctx)
 	rsc, err := blobs.Open(ctx, ld.digest)
 
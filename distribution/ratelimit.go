@@ -0,0 +1,140 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+// RateLimit is a snapshot of a registry's rate-limit headers, as sent by
+// registries that implement Docker Hub's `ratelimit-limit` /
+// `ratelimit-remaining` convention (e.g. "100;w=21600" for a limit of 100
+// requests per 21600-second window).
+type RateLimit struct {
+	// Limit is the number of requests allowed per quota window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Window is the length of the quota window, in seconds, as reported by
+	// the registry's "w=" parameter. It is 0 if the registry didn't send one.
+	Window int
+}
+
+// rateLimitTracker records the most recently observed RateLimit per
+// registry host, so it can be consulted between pulls rather than only at
+// the moment a response comes back.
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	limits map[string]RateLimit
+}
+
+var defaultRateLimitTracker = &rateLimitTracker{limits: make(map[string]RateLimit)}
+
+func (t *rateLimitTracker) observe(host string, resp *http.Response) {
+	rl, ok := parseRateLimitHeaders(resp.Header)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	t.limits[host] = rl
+	t.mu.Unlock()
+}
+
+func (t *rateLimitTracker) get(host string) (RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rl, ok := t.limits[host]
+	return rl, ok
+}
+
+// RegistryRateLimit returns the most recently observed rate-limit quota for
+// the given registry host, and whether that registry has sent rate-limit
+// headers at all. Hosts that don't advertise a quota (most self-hosted
+// registries) never populate this.
+func RegistryRateLimit(host string) (RateLimit, bool) {
+	return defaultRateLimitTracker.get(host)
+}
+
+// RegistryRateLimits returns the most recently observed rate-limit quota
+// for every registry host seen so far, keyed by host, for reporting via
+// SystemInfo.
+func RegistryRateLimits() map[string]types.RegistryRateLimit {
+	defaultRateLimitTracker.mu.Lock()
+	defer defaultRateLimitTracker.mu.Unlock()
+
+	if len(defaultRateLimitTracker.limits) == 0 {
+		return nil
+	}
+	out := make(map[string]types.RegistryRateLimit, len(defaultRateLimitTracker.limits))
+	for host, rl := range defaultRateLimitTracker.limits {
+		out[host] = types.RegistryRateLimit{Limit: rl.Limit, Remaining: rl.Remaining, WindowSeconds: rl.Window}
+	}
+	return out
+}
+
+// LowOnRegistryQuota reports whether the most recently observed rate-limit
+// quota for host has fewer than the given fraction of its requests
+// remaining. It returns false for a host with no observed quota, since
+// there's nothing to defer for.
+func LowOnRegistryQuota(host string, fraction float64) bool {
+	rl, ok := RegistryRateLimit(host)
+	if !ok || rl.Limit <= 0 {
+		return false
+	}
+	return float64(rl.Remaining)/float64(rl.Limit) < fraction
+}
+
+// parseRateLimitHeaders extracts a RateLimit from a response's
+// "ratelimit-limit" and "ratelimit-remaining" headers. Both must be present
+// and parse as integers for ok to be true.
+func parseRateLimitHeaders(h http.Header) (RateLimit, bool) {
+	limit, limitWindow, ok := parseRateLimitHeader(h.Get("ratelimit-limit"))
+	if !ok {
+		return RateLimit{}, false
+	}
+	remaining, _, ok := parseRateLimitHeader(h.Get("ratelimit-remaining"))
+	if !ok {
+		return RateLimit{}, false
+	}
+	return RateLimit{Limit: limit, Remaining: remaining, Window: limitWindow}, true
+}
+
+// parseRateLimitHeader parses a single header value of the form "100" or
+// "100;w=21600".
+func parseRateLimitHeader(v string) (count int, window int, ok bool) {
+	if v == "" {
+		return 0, 0, false
+	}
+	parts := strings.Split(v, ";")
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	for _, p := range parts[1:] {
+		p = strings.TrimSpace(p)
+		if strings.HasPrefix(p, "w=") {
+			window, _ = strconv.Atoi(strings.TrimPrefix(p, "w="))
+		}
+	}
+	return count, window, true
+}
+
+// rateLimitTransport is an http.RoundTripper that records any rate-limit
+// headers a registry sends, so the daemon can expose current quota (via
+// /info and metrics) and let callers defer non-urgent pulls when it's low,
+// without every caller having to parse response headers itself.
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	defaultRateLimitTracker.observe(req.URL.Host, resp)
+	return resp, nil
+}
@@ -0,0 +1,39 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	h := make(http.Header)
+	h.Set("ratelimit-limit", "100;w=21600")
+	h.Set("ratelimit-remaining", "42;w=21600")
+
+	rl, ok := parseRateLimitHeaders(h)
+	if !ok {
+		t.Fatal("expected headers to parse")
+	}
+	if rl.Limit != 100 || rl.Remaining != 42 || rl.Window != 21600 {
+		t.Fatalf("unexpected result: %+v", rl)
+	}
+}
+
+func TestParseRateLimitHeadersMissing(t *testing.T) {
+	if _, ok := parseRateLimitHeaders(make(http.Header)); ok {
+		t.Fatal("expected no rate limit to be parsed from empty headers")
+	}
+}
+
+func TestLowOnRegistryQuota(t *testing.T) {
+	defaultRateLimitTracker.mu.Lock()
+	defaultRateLimitTracker.limits["registry.example.com"] = RateLimit{Limit: 100, Remaining: 5}
+	defaultRateLimitTracker.mu.Unlock()
+
+	if !LowOnRegistryQuota("registry.example.com", 0.1) {
+		t.Fatal("expected registry with 5% quota remaining to be considered low")
+	}
+	if LowOnRegistryQuota("unknown.example.com", 0.1) {
+		t.Fatal("a registry with no observed quota should never be considered low")
+	}
+}
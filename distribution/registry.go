@@ -130,7 +130,7 @@ func NewV2Repository(
 		}
 	}
 
-	repo, err = client.NewRepository(repoNameRef, endpoint.URL.String(), tr)
+	repo, err = client.NewRepository(repoNameRef, endpoint.URL.String(), &manifestCacheTransport{base: &rateLimitTransport{base: tr}, cache: defaultManifestCache})
 	if err != nil {
 		err = fallbackError{
 			err:         err,
@@ -0,0 +1,42 @@
+package distribution // import "github.com/docker/docker/distribution"
+
+import "sync"
+
+// schema1Repos tracks, by repository name (as returned by
+// reference.FamiliarName), every repository a pull has fetched a schema1
+// manifest for. It exists so a later, explicit migration pass can find
+// repositories worth re-pushing without re-contacting every configured
+// registry; see recordSchema1Pull in pull_v2.go.
+var (
+	schema1ReposMu sync.Mutex
+	schema1Repos   = make(map[string]struct{})
+)
+
+// recordSchema1Pull notes that repository was just pulled from a schema1
+// manifest.
+func recordSchema1Pull(repository string) {
+	schema1ReposMu.Lock()
+	schema1Repos[repository] = struct{}{}
+	schema1ReposMu.Unlock()
+}
+
+// Schema1Repositories returns every repository recordSchema1Pull has been
+// called with since the daemon started, in no particular order.
+func Schema1Repositories() []string {
+	schema1ReposMu.Lock()
+	defer schema1ReposMu.Unlock()
+
+	repos := make([]string, 0, len(schema1Repos))
+	for r := range schema1Repos {
+		repos = append(repos, r)
+	}
+	return repos
+}
+
+// ForgetSchema1Repository removes repository from the tracked set, once
+// its tags have been migrated off schema1.
+func ForgetSchema1Repository(repository string) {
+	schema1ReposMu.Lock()
+	delete(schema1Repos, repository)
+	schema1ReposMu.Unlock()
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/docker/distribution"
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/progress"
@@ -146,6 +147,10 @@ func (ls *mockLayerStore) Cleanup() error {
 	return nil
 }
 
+func (ls *mockLayerStore) Repair() error {
+	return nil
+}
+
 func (ls *mockLayerStore) DriverStatus() [][2]string {
 	return [][2]string{}
 }
@@ -154,6 +159,10 @@ func (ls *mockLayerStore) DriverName() string {
 	return "mock"
 }
 
+func (ls *mockLayerStore) DriverHealthCheck() []graphdriver.HealthCheckResult {
+	return nil
+}
+
 type mockDownloadDescriptor struct {
 	currentDownloads *int32
 	id               string
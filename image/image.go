@@ -98,6 +98,24 @@ func (img *Image) RunConfig() *container.Config {
 	return img.Config
 }
 
+// RuntimeLabel is the well-known image config label an image can set to
+// request a specific low-level runtime (for example "io.containerd.runc.v2",
+// or a third-party runtime such as "wasm" or "kata") at container create
+// time. The daemon only honors this when the requested runtime is both
+// configured and explicitly trusted by the daemon administrator; see
+// Config.IsImageRuntimeTrusted. It has no effect if the caller explicitly
+// sets HostConfig.Runtime.
+const RuntimeLabel = "io.docker.runtime"
+
+// RequestedRuntime returns the low-level runtime requested by the image via
+// the RuntimeLabel, or the empty string if the image does not request one.
+func (img *Image) RequestedRuntime() string {
+	if img == nil || img.Config == nil {
+		return ""
+	}
+	return img.Config.Labels[RuntimeLabel]
+}
+
 // BaseImgArch returns the image's architecture. If not populated, defaults to the host runtime arch.
 func (img *Image) BaseImgArch() string {
 	arch := img.Architecture
@@ -24,6 +24,8 @@ type Store interface {
 	GetParent(id ID) (ID, error)
 	SetLastUpdated(id ID) error
 	GetLastUpdated(id ID) (time.Time, error)
+	SetHotFiles(id ID, paths []string) error
+	GetHotFiles(id ID) ([]string, error)
 	Children(id ID) []ID
 	Map() map[ID]*Image
 	Heads() map[ID]*Image
@@ -294,6 +296,33 @@ func (is *store) GetLastUpdated(id ID) (time.Time, error) {
 	return time.Parse(time.RFC3339Nano, string(bytes))
 }
 
+// SetHotFiles records the set of files (paths relative to the container
+// rootfs) that were found worth warming into the page cache the last time a
+// container of this image was started, so a future start can warm the same
+// files before the container's process runs, without first having to
+// rediscover them.
+func (is *store) SetHotFiles(id ID, paths []string) error {
+	data, err := json.Marshal(paths)
+	if err != nil {
+		return err
+	}
+	return is.fs.SetMetadata(id.Digest(), "hotfiles", data)
+}
+
+// GetHotFiles returns the hot files recorded by SetHotFiles for id, or an
+// empty slice if none have been recorded yet.
+func (is *store) GetHotFiles(id ID) ([]string, error) {
+	data, err := is.fs.GetMetadata(id.Digest(), "hotfiles")
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	var paths []string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
 func (is *store) Children(id ID) []ID {
 	is.RLock()
 	defer is.RUnlock()
@@ -44,6 +44,13 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	if err := chrootarchive.Untar(inTar, tmpDir, nil); err != nil {
 		return err
 	}
+
+	// An OCI image-layout archive has an index.json at its root; prefer it
+	// over the legacy manifest.json check below if present.
+	if _, err := os.Stat(filepath.Join(tmpDir, ociIndexFileName)); err == nil {
+		return l.loadOCI(tmpDir, outStream, progressOutput)
+	}
+
 	// read manifest, if no file then load in legacy mode
 	manifestPath, err := safePath(tmpDir, manifestFileName)
 	if err != nil {
@@ -63,6 +70,11 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 		return err
 	}
 
+	skippedDiffIDs, deltaBaseImageID, err := readDeltaManifest(tmpDir)
+	if err != nil {
+		return err
+	}
+
 	var parentLinks []parentLink
 	var imageIDsStr string
 	var imageRefCount int
@@ -110,6 +122,9 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 			r.Append(diffID)
 			newLayer, err := l.lss[os].Get(r.ChainID())
 			if err != nil {
+				if _, skipped := skippedDiffIDs[diffID]; skipped {
+					return fmt.Errorf("layer %s was omitted from this delta image because it belongs to base image %s, which is not present locally: load %s first", diffID, deltaBaseImageID, deltaBaseImageID)
+				}
 				newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), os, m.LayerSources[diffID], progressOutput)
 				if err != nil {
 					return err
@@ -162,6 +177,35 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	return nil
 }
 
+// readDeltaManifest reads the delta-manifest.json written by SaveDelta, if
+// any. It returns a nil set when tmpDir doesn't contain one, i.e. when
+// loading a regular (non-delta) tar.
+func readDeltaManifest(tmpDir string) (map[layer.DiffID]struct{}, image.ID, error) {
+	deltaManifestPath, err := safePath(tmpDir, deltaManifestFileName)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := os.Open(deltaManifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var dm deltaManifest
+	if err := json.NewDecoder(f).Decode(&dm); err != nil {
+		return nil, "", err
+	}
+
+	skipped := make(map[layer.DiffID]struct{}, len(dm.SkippedDiffIDs))
+	for _, d := range dm.SkippedDiffIDs {
+		skipped[d] = struct{}{}
+	}
+	return skipped, dm.BaseImageID, nil
+}
+
 func (l *tarexporter) setParentID(id, parentID image.ID) error {
 	img, err := l.is.Get(id)
 	if err != nil {
@@ -0,0 +1,410 @@
+package tarexport // import "github.com/docker/docker/image/tarexport"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/system"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const ociIndexFileName = "index.json"
+
+// OCIOptions configures SaveOCI.
+type OCIOptions struct {
+	// Compression selects the media type layer blobs are written with:
+	// "" (the default) writes uncompressed tar layers, and "gzip" writes
+	// gzip-compressed ones. "zstd" is a recognized value, matching the
+	// OCI image-layout spec, but is rejected here: this build vendors no
+	// zstd encoder, so SaveOCI cannot actually produce one.
+	Compression string
+}
+
+const ociLayoutVersion = "1.0.0"
+
+type ociLayoutMarker struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// SaveOCI exports names in OCI image-layout format: an oci-layout marker
+// file, a blobs/sha256 content-addressed tree, and an index.json
+// referencing one manifest per resolved image. A name that resolves to
+// more than one tag produces one index.json entry per tag, all pointing
+// at the same manifest.
+//
+// SaveOCI does not merge separately named images that happen to share a
+// platform into a single manifest list: each name in names becomes its
+// own single-platform manifest entry in index.json. Assembling a true
+// manifest list out of otherwise unrelated images is a distinct
+// operation, served by distribution.CreateAndPushManifestList against a
+// registry rather than a local layout directory.
+func (l *tarexporter) SaveOCI(names []string, outStream io.Writer, opts OCIOptions) error {
+	switch opts.Compression {
+	case "", "gzip":
+	case "zstd":
+		return errors.New("zstd compression was requested but this build vendors no zstd encoder; use \"\" (uncompressed) or \"gzip\" instead")
+	default:
+		return errors.Errorf("unsupported OCI layout compression %q", opts.Compression)
+	}
+
+	images, err := l.parseNames(names)
+	if err != nil {
+		return err
+	}
+	defer l.releaseLayerReferences(images)
+	return (&ociSaveSession{tarexporter: l, images: images, opts: opts}).save(outStream)
+}
+
+type ociSaveSession struct {
+	*tarexporter
+	outDir string
+	opts   OCIOptions
+	images map[image.ID]*imageDescriptor
+
+	// layerBlobs caches the descriptor written for each diffID, so a
+	// layer shared by two exported images is only written once.
+	layerBlobs map[layer.DiffID]v1.Descriptor
+}
+
+func (s *ociSaveSession) save(outStream io.Writer) error {
+	tempDir, err := ioutil.TempDir("", "docker-export-oci-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	s.outDir = tempDir
+	s.layerBlobs = make(map[layer.DiffID]v1.Descriptor)
+
+	if err := os.MkdirAll(filepath.Join(tempDir, "blobs", "sha256"), 0755); err != nil {
+		return err
+	}
+
+	markerJSON, err := json.Marshal(ociLayoutMarker{ImageLayoutVersion: ociLayoutVersion})
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tempDir, "oci-layout"), markerJSON, 0644); err != nil {
+		return err
+	}
+
+	index := v1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+	}
+
+	for id, imgDescr := range s.images {
+		manifestDesc, err := s.saveImage(imgDescr.image)
+		if err != nil {
+			return err
+		}
+
+		if len(imgDescr.refs) == 0 {
+			index.Manifests = append(index.Manifests, manifestDesc)
+		}
+		for _, ref := range imgDescr.refs {
+			entry := manifestDesc
+			entry.Annotations = map[string]string{
+				v1.AnnotationRefName: reference.FamiliarString(ref),
+			}
+			index.Manifests = append(index.Manifests, entry)
+		}
+
+		s.tarexporter.loggerImgEvent.LogImageEvent(id.String(), id.String(), "save")
+	}
+
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tempDir, "index.json"), indexJSON, 0644); err != nil {
+		return err
+	}
+
+	fs, err := archive.Tar(tempDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	_, err = io.Copy(outStream, fs)
+	return err
+}
+
+// saveImage writes img's config and layer blobs, and returns the
+// descriptor of its OCI image manifest.
+func (s *ociSaveSession) saveImage(img *image.Image) (v1.Descriptor, error) {
+	configDesc, err := s.writeBlob(v1.MediaTypeImageConfig, bytes.NewReader(img.RawJSON()))
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+
+	operatingSystem := img.OS
+	if operatingSystem == "" {
+		operatingSystem = runtime.GOOS
+	}
+
+	layerDescs := make([]v1.Descriptor, 0, len(img.RootFS.DiffIDs))
+	for i, diffID := range img.RootFS.DiffIDs {
+		if desc, ok := s.layerBlobs[diffID]; ok {
+			layerDescs = append(layerDescs, desc)
+			continue
+		}
+
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = rootFS.DiffIDs[:i+1]
+		desc, err := s.saveLayerBlob(operatingSystem, rootFS.ChainID())
+		if err != nil {
+			return v1.Descriptor{}, err
+		}
+		s.layerBlobs[diffID] = desc
+		layerDescs = append(layerDescs, desc)
+	}
+
+	manifest := v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    configDesc,
+		Layers:    layerDescs,
+	}
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	manifestDesc, err := s.writeBlob(v1.MediaTypeImageManifest, bytes.NewReader(manifestJSON))
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	manifestDesc.Platform = &v1.Platform{
+		Architecture: img.Architecture,
+		OS:           img.OS,
+		Variant:      img.Variant,
+		OSVersion:    img.OSVersion,
+	}
+	return manifestDesc, nil
+}
+
+func (s *ociSaveSession) saveLayerBlob(operatingSystem string, chainID layer.ChainID) (v1.Descriptor, error) {
+	l, err := s.lss[operatingSystem].Get(chainID)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	defer layer.ReleaseAndLog(s.lss[operatingSystem], l)
+
+	arch, err := l.TarStream()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	defer arch.Close()
+
+	if s.opts.Compression != "gzip" {
+		return s.writeBlob(v1.MediaTypeImageLayer, arch)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		_, copyErr := io.Copy(gz, arch)
+		closeErr := gz.Close()
+		if copyErr != nil {
+			pw.CloseWithError(copyErr)
+			return
+		}
+		pw.CloseWithError(closeErr)
+	}()
+	return s.writeBlob(v1.MediaTypeImageLayerGzip, pr)
+}
+
+// writeBlob streams r into blobs/sha256 under the OCI layout being
+// assembled and returns its descriptor, deduplicating by digest.
+func (s *ociSaveSession) writeBlob(mediaType string, r io.Reader) (v1.Descriptor, error) {
+	tmp, err := ioutil.TempFile(filepath.Join(s.outDir, "blobs", "sha256"), "blob-")
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(tmp, digester.Hash()), r)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return v1.Descriptor{}, err
+	}
+
+	dgst := digester.Digest()
+	finalPath := filepath.Join(s.outDir, "blobs", "sha256", dgst.Hex())
+	if _, err := os.Stat(finalPath); err == nil {
+		// Already written by an earlier call with identical content.
+		return v1.Descriptor{MediaType: mediaType, Digest: dgst, Size: size}, nil
+	}
+	if err := os.Rename(tmp.Name(), finalPath); err != nil {
+		return v1.Descriptor{}, err
+	}
+	if err := system.Chtimes(finalPath, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+		return v1.Descriptor{}, err
+	}
+	return v1.Descriptor{MediaType: mediaType, Digest: dgst, Size: size}, nil
+}
+
+// loadOCI imports an OCI image-layout directory (already untarred into
+// tmpDir by Load). Each manifest entry in index.json becomes one loaded
+// image; an entry carrying an OCI AnnotationRefName is tagged with it the
+// same way a docker-format RepoTags entry would be.
+//
+// loadOCI does not reconstruct multi-platform groupings: an index entry
+// whose MediaType is itself an image index (a true manifest list) is
+// skipped with a warning rather than being expanded, since a flat loaded
+// image collection has no place to record that relationship.
+func (l *tarexporter) loadOCI(tmpDir string, outStream io.Writer, progressOutput progress.Output) error {
+	index, err := l.readOCIIndex(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	var imageIDsStr string
+	var imageRefCount int
+
+	for _, desc := range index.Manifests {
+		if desc.MediaType != v1.MediaTypeImageManifest {
+			logrus.Warnf("skipping OCI index entry %s: unsupported media type %q", desc.Digest, desc.MediaType)
+			continue
+		}
+
+		manifestPath, err := l.ociBlobPath(tmpDir, desc.Digest)
+		if err != nil {
+			return err
+		}
+		manifestJSON, err := ioutil.ReadFile(manifestPath)
+		if err != nil {
+			return err
+		}
+		var manifest v1.Manifest
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			return err
+		}
+
+		configPath, err := l.ociBlobPath(tmpDir, manifest.Config.Digest)
+		if err != nil {
+			return err
+		}
+		config, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+		img, err := image.NewFromJSON(config)
+		if err != nil {
+			return err
+		}
+		if err := checkCompatibleOS(img.OS); err != nil {
+			return err
+		}
+
+		os := img.OS
+		if os == "" {
+			os = runtime.GOOS
+		}
+
+		if expected, actual := len(manifest.Layers), len(img.RootFS.DiffIDs); expected != actual {
+			return fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
+		}
+
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = nil
+		for i, layerDesc := range manifest.Layers {
+			if isZstdMediaType(layerDesc.MediaType) {
+				return errors.New("OCI layer uses zstd compression but this build vendors no zstd decoder")
+			}
+			layerPath, err := l.ociBlobPath(tmpDir, layerDesc.Digest)
+			if err != nil {
+				return err
+			}
+			diffID := img.RootFS.DiffIDs[i]
+			r := rootFS
+			r.Append(diffID)
+			newLayer, err := l.lss[os].Get(r.ChainID())
+			if err != nil {
+				newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), os, distribution.Descriptor{}, progressOutput)
+				if err != nil {
+					return err
+				}
+			}
+			defer layer.ReleaseAndLog(l.lss[os], newLayer)
+			if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+				return fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+			}
+			rootFS.Append(diffID)
+		}
+
+		imgID, err := l.is.Create(config)
+		if err != nil {
+			return err
+		}
+		imageIDsStr += fmt.Sprintf("Loaded image ID: %s\n", imgID)
+
+		imageRefCount = 0
+		if refName, ok := desc.Annotations[v1.AnnotationRefName]; ok {
+			named, err := reference.ParseNormalizedNamed(refName)
+			if err != nil {
+				return err
+			}
+			tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+			if !ok {
+				return fmt.Errorf("invalid ref name annotation %q", refName)
+			}
+			l.setLoadedTag(tagged, imgID.Digest(), outStream)
+			outStream.Write([]byte(fmt.Sprintf("Loaded image: %s\n", reference.FamiliarString(tagged))))
+			imageRefCount++
+		}
+
+		l.loggerImgEvent.LogImageEvent(imgID.String(), imgID.String(), "load")
+	}
+
+	if imageRefCount == 0 {
+		outStream.Write([]byte(imageIDsStr))
+	}
+
+	return nil
+}
+
+func (l *tarexporter) readOCIIndex(tmpDir string) (v1.Index, error) {
+	indexPath, err := safePath(tmpDir, ociIndexFileName)
+	if err != nil {
+		return v1.Index{}, err
+	}
+	raw, err := ioutil.ReadFile(indexPath)
+	if err != nil {
+		return v1.Index{}, err
+	}
+	var index v1.Index
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return v1.Index{}, err
+	}
+	return index, nil
+}
+
+func (l *tarexporter) ociBlobPath(tmpDir string, dgst digest.Digest) (string, error) {
+	return safePath(tmpDir, filepath.Join("blobs", dgst.Algorithm().String(), dgst.Hex()))
+}
+
+func isZstdMediaType(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "+zstd")
+}
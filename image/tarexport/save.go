@@ -35,6 +35,12 @@ type saveSession struct {
 	images      map[image.ID]*imageDescriptor
 	savedLayers map[string]struct{}
 	diffIDPaths map[layer.DiffID]string // cache every diffID blob to avoid duplicates
+
+	// baseImageID and baseDiffIDs are set by SaveDelta to turn this into a
+	// delta save: baseDiffIDs is nil for a regular Save.
+	baseImageID    image.ID
+	baseDiffIDs    map[layer.DiffID]struct{}
+	skippedDiffIDs []layer.DiffID
 }
 
 func (l *tarexporter) Save(names []string, outStream io.Writer) error {
@@ -48,6 +54,64 @@ func (l *tarexporter) Save(names []string, outStream io.Writer) error {
 	return (&saveSession{tarexporter: l, images: images}).save(outStream)
 }
 
+// SaveDelta is like Save, except layers already present in the local image
+// named baseRef have their content omitted from the tar rather than
+// written out again. It is meant for transferring images to a host that is
+// already known to have baseRef, e.g. a fleet of air-gapped hosts that
+// were all provisioned from the same base image.
+//
+// There is no live negotiation with the target host: the target is simply
+// expected to already have baseRef loaded, under the same layer chain IDs
+// it has here. Load refuses to import a delta tar whose omitted layers
+// aren't already present locally, rather than guessing.
+func (l *tarexporter) SaveDelta(names []string, baseRef string, outStream io.Writer) error {
+	images, err := l.parseNames(names)
+	if err != nil {
+		return err
+	}
+	defer l.releaseLayerReferences(images)
+
+	baseID, baseDiffIDs, err := l.deltaBaseDiffIDs(baseRef)
+	if err != nil {
+		return err
+	}
+
+	return (&saveSession{tarexporter: l, images: images, baseImageID: baseID, baseDiffIDs: baseDiffIDs}).save(outStream)
+}
+
+// deltaBaseDiffIDs resolves baseRef to a locally present image and returns
+// its ID along with the set of DiffIDs that make up its layer chain.
+func (l *tarexporter) deltaBaseDiffIDs(baseRef string) (image.ID, map[layer.DiffID]struct{}, error) {
+	ref, err := reference.ParseAnyReference(baseRef)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var baseID image.ID
+	if digested, ok := ref.(reference.Digested); ok {
+		baseID = image.IDFromDigest(digested.Digest())
+	} else if namedRef, ok := ref.(reference.Named); ok {
+		id, err := l.rs.Get(reference.TagNameOnly(namedRef))
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "delta base image %q not found locally", baseRef)
+		}
+		baseID = image.IDFromDigest(id)
+	} else {
+		return "", nil, errors.Errorf("invalid delta base image reference: %s", baseRef)
+	}
+
+	baseImg, err := l.is.Get(baseID)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "delta base image %q not found locally", baseRef)
+	}
+
+	diffIDs := make(map[layer.DiffID]struct{}, len(baseImg.RootFS.DiffIDs))
+	for _, d := range baseImg.RootFS.DiffIDs {
+		diffIDs[d] = struct{}{}
+	}
+	return baseID, diffIDs, nil
+}
+
 // parseNames will parse the image names to a map which contains image.ID to *imageDescriptor.
 // Each imageDescriptor holds an image top layer reference named 'layerRef'. It is taken here, should be released later.
 func (l *tarexporter) parseNames(names []string) (desc map[image.ID]*imageDescriptor, rErr error) {
@@ -281,6 +345,24 @@ func (s *saveSession) save(outStream io.Writer) error {
 		return err
 	}
 
+	if s.baseDiffIDs != nil {
+		deltaManifestFileName := filepath.Join(tempDir, deltaManifestFileName)
+		df, err := os.OpenFile(deltaManifestFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+
+		err = json.NewEncoder(df).Encode(deltaManifest{BaseImageID: s.baseImageID, SkippedDiffIDs: s.skippedDiffIDs})
+		df.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := system.Chtimes(deltaManifestFileName, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+			return err
+		}
+	}
+
 	fs, err := archive.Tar(tempDir, archive.Uncompressed)
 	if err != nil {
 		return err
@@ -384,7 +466,23 @@ func (s *saveSession) saveLayer(id layer.ChainID, legacyImg image.V1Image, creat
 	}
 	defer layer.ReleaseAndLog(s.lss[operatingSystem], l)
 
-	if oldPath, exists := s.diffIDPaths[l.DiffID()]; exists {
+	if _, skip := s.baseDiffIDs[l.DiffID()]; skip {
+		// This layer already belongs to the delta base image, so the
+		// target of a delta load is expected to have it registered
+		// locally under the same chain ID already. Omit its content to
+		// keep the delta tar small; Load refuses to proceed if that
+		// expectation doesn't hold.
+		if err := ioutil.WriteFile(layerPath, nil, 0644); err != nil {
+			return distribution.Descriptor{}, err
+		}
+		s.skippedDiffIDs = append(s.skippedDiffIDs, l.DiffID())
+
+		for _, fname := range []string{"", legacyVersionFileName, legacyConfigFileName, legacyLayerFileName} {
+			if err := system.Chtimes(filepath.Join(outDir, fname), createdTime, createdTime); err != nil {
+				return distribution.Descriptor{}, err
+			}
+		}
+	} else if oldPath, exists := s.diffIDPaths[l.DiffID()]; exists {
 		relPath, err := filepath.Rel(outDir, oldPath)
 		if err != nil {
 			return distribution.Descriptor{}, err
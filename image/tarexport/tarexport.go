@@ -1,6 +1,8 @@
 package tarexport // import "github.com/docker/docker/image/tarexport"
 
 import (
+	"io"
+
 	"github.com/docker/distribution"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -13,6 +15,7 @@ const (
 	legacyConfigFileName       = "json"
 	legacyVersionFileName      = "VERSION"
 	legacyRepositoriesFileName = "repositories"
+	deltaManifestFileName      = "delta-manifest.json"
 )
 
 type manifestItem struct {
@@ -23,6 +26,23 @@ type manifestItem struct {
 	LayerSources map[layer.DiffID]distribution.Descriptor `json:",omitempty"`
 }
 
+// deltaManifest is written alongside manifest.json by SaveDelta, and read
+// back by Load. It records which layers SaveDelta omitted the content of
+// because they were already part of the --delta-from base image, so Load
+// can refuse to proceed if the target turns out not to actually have that
+// base image's layers registered locally, rather than silently importing
+// empty layers.
+type deltaManifest struct {
+	// BaseImageID is the ID of the image SaveDelta was told to diff
+	// against. It is informational only (used in Load's error message);
+	// matching against what the target host already has is done by
+	// DiffID, not by this ID.
+	BaseImageID image.ID `json:",omitempty"`
+	// SkippedDiffIDs lists the layers whose content was omitted from
+	// this tar.
+	SkippedDiffIDs []layer.DiffID
+}
+
 type tarexporter struct {
 	is             image.Store
 	lss            map[string]layer.Store
@@ -36,8 +56,26 @@ type LogImageEvent interface {
 	LogImageEvent(imageID, refName, action string)
 }
 
+// OCIExporter extends image.Exporter with the ability to export images in
+// OCI image-layout format in addition to the legacy docker save format.
+// Load transparently accepts either format on import, so it is not part of
+// this interface.
+type OCIExporter interface {
+	image.Exporter
+	SaveOCI(names []string, outStream io.Writer, opts OCIOptions) error
+}
+
+// DeltaExporter extends OCIExporter with the ability to save a delta tar:
+// one that omits the content of layers already present in a local base
+// image, for transfer to a host that is already known to have that base
+// image. See SaveDelta for exactly what this does and does not cover.
+type DeltaExporter interface {
+	OCIExporter
+	SaveDelta(names []string, baseRef string, outStream io.Writer) error
+}
+
 // NewTarExporter returns new Exporter for tar packages
-func NewTarExporter(is image.Store, lss map[string]layer.Store, rs refstore.Store, loggerImgEvent LogImageEvent) image.Exporter {
+func NewTarExporter(is image.Store, lss map[string]layer.Store, rs refstore.Store, loggerImgEvent LogImageEvent) DeltaExporter {
 	return &tarexporter{
 		is:             is,
 		lss:            lss,
@@ -0,0 +1,146 @@
+package layer // import "github.com/docker/docker/layer"
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// journalOp identifies a step in an RWLayer's lifecycle that a crash can
+// leave half-done. Each op is journaled before the corresponding graph
+// driver/metadata operation starts ("-begin") and again once every step of
+// it has landed ("-committed"); a "-begin" with no matching "-committed"
+// after a restart means the mount was interrupted mid-flight.
+type journalOp string
+
+const (
+	journalOpCreateBegin      journalOp = "create-begin"
+	journalOpCreateCommitted  journalOp = "create-committed"
+	journalOpReleaseBegin     journalOp = "release-begin"
+	journalOpReleaseCommitted journalOp = "release-committed"
+)
+
+type journalEntry struct {
+	Op      journalOp `json:"op"`
+	Name    string    `json:"name"`
+	MountID string    `json:"mountID"`
+}
+
+// mountJournal is a write-ahead log of RWLayer create/release operations.
+// It exists so that a hard crash between the graph driver mutation and the
+// matching metadata update (or vice versa) can be detected and reconciled
+// on the next start, instead of silently leaking an orphan mount.
+type mountJournal struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newMountJournal(root string) (*mountJournal, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, err
+	}
+	return &mountJournal{path: filepath.Join(root, "mount.journal")}, nil
+}
+
+// append records entry to the journal, fsync'ing it before returning so
+// that the write survives a crash that happens immediately after.
+func (j *mountJournal) append(op journalOp, name, mountID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	b, err := json.Marshal(journalEntry{Op: op, Name: name, MountID: mountID})
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if _, err := f.Write(b); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// pending reads the journal and returns the last entry recorded for each
+// mount name whose most recent operation is a "-begin" with no matching
+// "-committed" - i.e. mounts whose create or release was interrupted.
+func (j *mountJournal) pending() ([]journalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	last := map[string]journalEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logrus.WithError(err).Warn("layer: ignoring corrupt mount journal entry")
+			continue
+		}
+		last[entry.Name] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var pending []journalEntry
+	for _, entry := range last {
+		if entry.Op == journalOpCreateBegin || entry.Op == journalOpReleaseBegin {
+			pending = append(pending, entry)
+		}
+	}
+	return pending, nil
+}
+
+// compact rewrites the journal to contain only its pending (uncommitted)
+// entries, discarding the committed history that's no longer needed once
+// it's been reconciled.
+func (j *mountJournal) compact(pending []journalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range pending {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		b = append(b, '\n')
+		if _, err := f.Write(b); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
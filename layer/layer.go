@@ -14,6 +14,7 @@ import (
 	"io"
 
 	"github.com/docker/distribution"
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/containerfs"
 	digest "github.com/opencontainers/go-digest"
@@ -196,6 +197,14 @@ type Store interface {
 	Cleanup() error
 	DriverStatus() [][2]string
 	DriverName() string
+	// DriverHealthCheck runs the storage driver's startup self-test, if the
+	// driver implements graphdriver.HealthChecker. It returns nil if the
+	// driver does not support self-checks.
+	DriverHealthCheck() []graphdriver.HealthCheckResult
+
+	// Repair reconciles mounts left inconsistent by a daemon crash between
+	// a graph driver mutation and its matching metadata update.
+	Repair() error
 }
 
 // DescribableStore represents a layer store capable of storing
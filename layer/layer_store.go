@@ -43,6 +43,12 @@ type layerStore struct {
 	// protect *RWLayer() methods from operating on the same name/id
 	locker *locker.Locker
 
+	// journal is a write-ahead log of RWLayer create/release operations,
+	// used to detect and reconcile mounts left behind by a crash that
+	// happened between a graph driver mutation and its matching metadata
+	// update. See Repair.
+	journal *mountJournal
+
 	os string
 }
 
@@ -94,12 +100,18 @@ func newStoreFromGraphDriver(root string, driver graphdriver.Driver, os string)
 		return nil, err
 	}
 
+	journal, err := newMountJournal(root)
+	if err != nil {
+		return nil, err
+	}
+
 	ls := &layerStore{
 		store:       ms,
 		driver:      driver,
 		layerMap:    map[ChainID]*roLayer{},
 		mounts:      map[string]*mountedLayer{},
 		locker:      locker.New(),
+		journal:     journal,
 		useTarSplit: !caps.ReproducesExactDiffs,
 		os:          os,
 	}
@@ -126,6 +138,10 @@ func newStoreFromGraphDriver(root string, driver graphdriver.Driver, os string)
 		}
 	}
 
+	if err := ls.Repair(); err != nil {
+		logrus.WithError(err).Warn("layer: failed to reconcile mount journal on startup")
+	}
+
 	return ls, nil
 }
 
@@ -564,12 +580,21 @@ func (ls *layerStore) CreateRWLayer(name string, parent ChainID, opts *CreateRWL
 		StorageOpt: storageOpt,
 	}
 
+	if err = ls.journal.append(journalOpCreateBegin, name, m.mountID); err != nil {
+		return
+	}
 	if err = ls.driver.CreateReadWrite(m.mountID, pid, createOpts); err != nil {
 		return
 	}
 	if err = ls.saveMount(m); err != nil {
 		return
 	}
+	if err = ls.journal.append(journalOpCreateCommitted, name, m.mountID); err != nil {
+		// The mount itself is fine; this only means a future restart's
+		// reconciliation has stale information to work from, which Repair
+		// tolerates (it re-checks driver/metadata state before acting).
+		logrus.WithError(err).WithField("mount", name).Warn("layer: failed to record committed mount journal entry")
+	}
 
 	return m.getReference(), nil
 }
@@ -621,6 +646,11 @@ func (ls *layerStore) ReleaseRWLayer(l RWLayer) ([]Metadata, error) {
 		return []Metadata{}, nil
 	}
 
+	if err := ls.journal.append(journalOpReleaseBegin, m.name, m.mountID); err != nil {
+		m.retakeReference(l)
+		return nil, err
+	}
+
 	if err := ls.driver.Remove(m.mountID); err != nil {
 		logrus.Errorf("Error removing mounted layer %s: %s", m.name, err)
 		m.retakeReference(l)
@@ -641,6 +671,10 @@ func (ls *layerStore) ReleaseRWLayer(l RWLayer) ([]Metadata, error) {
 		return nil, err
 	}
 
+	if err := ls.journal.append(journalOpReleaseCommitted, m.name, m.mountID); err != nil {
+		logrus.WithError(err).WithField("mount", m.name).Warn("layer: failed to record committed mount journal entry")
+	}
+
 	ls.mountL.Lock()
 	delete(ls.mounts, name)
 	ls.mountL.Unlock()
@@ -759,6 +793,97 @@ func (ls *layerStore) assembleTarTo(graphID string, metadata io.ReadCloser, size
 	return asm.WriteOutputTarStream(fileGetCloser, upackerCounter, w)
 }
 
+// Repair reconciles mounts left inconsistent by a daemon crash: it replays
+// the mount journal for create/release operations that never recorded a
+// matching "committed" entry, and for each one checks what actually landed
+// on disk before deciding what to clean up.
+//
+//   - An interrupted create whose driver-level mount exists but has no
+//     metadata (the daemon died before saveMount) is an orphan: the driver
+//     mount is removed.
+//   - An interrupted release whose metadata still refers to a driver-level
+//     mount that's already gone (the daemon died after driver.Remove but
+//     before RemoveMount) is orphaned metadata: it's removed so the name
+//     can be reused and the container that owned it can be recreated.
+//
+// Once a pending entry has been reconciled one way or the other, it's
+// dropped from the journal. It's also called once automatically whenever
+// the store is opened; `docker image prune --repair` runs this same journal
+// replay again on a running daemon. Only mounts recorded in the journal are
+// reconciled: mounts left behind without a journal entry (e.g. by older
+// daemon versions) are not found.
+func (ls *layerStore) Repair() error {
+	pending, err := ls.journal.pending()
+	if err != nil {
+		return err
+	}
+
+	var stillPending []journalEntry
+	for _, entry := range pending {
+		resolved, err := ls.reconcileMount(entry)
+		if err != nil {
+			logrus.WithError(err).WithField("mount", entry.Name).Warn("layer: failed to reconcile mount journal entry")
+			stillPending = append(stillPending, entry)
+			continue
+		}
+		if !resolved {
+			stillPending = append(stillPending, entry)
+		}
+	}
+
+	return ls.journal.compact(stillPending)
+}
+
+// reconcileMount inspects the current driver/metadata state for entry and,
+// if it finds an inconsistency left by a crash, fixes it. It reports
+// whether entry is now resolved and can be dropped from the journal.
+func (ls *layerStore) reconcileMount(entry journalEntry) (bool, error) {
+	ls.mountL.Lock()
+	_, hasMetadata := ls.mounts[entry.Name]
+	ls.mountL.Unlock()
+
+	driverHasMount := ls.driver.Exists(entry.MountID)
+
+	switch entry.Op {
+	case journalOpCreateBegin:
+		if hasMetadata {
+			// The create actually finished; only the "committed" record
+			// was lost. Nothing to reconcile.
+			return true, nil
+		}
+		if !driverHasMount {
+			// Nothing was ever created.
+			return true, nil
+		}
+		logrus.WithField("mount", entry.Name).Warn("layer: removing orphan mount left by an interrupted create")
+		if err := ls.driver.Remove(entry.MountID); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		return true, nil
+	case journalOpReleaseBegin:
+		if !hasMetadata {
+			// The release actually finished; only the "committed" record
+			// was lost. Nothing to reconcile.
+			return true, nil
+		}
+		if driverHasMount {
+			// The release never got as far as removing the driver mount;
+			// a retry of the normal release path will finish the job.
+			return true, nil
+		}
+		logrus.WithField("mount", entry.Name).Warn("layer: removing orphan metadata left by an interrupted release")
+		if err := ls.store.RemoveMount(entry.Name); err != nil {
+			return false, err
+		}
+		ls.mountL.Lock()
+		delete(ls.mounts, entry.Name)
+		ls.mountL.Unlock()
+		return true, nil
+	}
+
+	return true, nil
+}
+
 func (ls *layerStore) Cleanup() error {
 	orphanLayers, err := ls.store.getOrphan()
 	if err != nil {
@@ -788,6 +913,17 @@ func (ls *layerStore) DriverName() string {
 	return ls.driver.String()
 }
 
+// DriverHealthCheck runs the storage driver's self-test, if it implements
+// graphdriver.HealthChecker. It returns nil if the driver does not
+// implement the optional interface.
+func (ls *layerStore) DriverHealthCheck() []graphdriver.HealthCheckResult {
+	checker, ok := ls.driver.(graphdriver.HealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.HealthCheck()
+}
+
 type naiveDiffPathDriver struct {
 	graphdriver.Driver
 }
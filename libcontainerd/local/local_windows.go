@@ -116,43 +116,43 @@ func (c *client) Version(ctx context.Context) (containerd.Version, error) {
 //
 // Isolation=Process example:
 //
-// {
-// 	"SystemType": "Container",
-// 	"Name": "5e0055c814a6005b8e57ac59f9a522066e0af12b48b3c26a9416e23907698776",
-// 	"Owner": "docker",
-// 	"VolumePath": "\\\\\\\\?\\\\Volume{66d1ef4c-7a00-11e6-8948-00155ddbef9d}",
-// 	"IgnoreFlushesDuringBoot": true,
-// 	"LayerFolderPath": "C:\\\\control\\\\windowsfilter\\\\5e0055c814a6005b8e57ac59f9a522066e0af12b48b3c26a9416e23907698776",
-// 	"Layers": [{
-// 		"ID": "18955d65-d45a-557b-bf1c-49d6dfefc526",
-// 		"Path": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c"
-// 	}],
-// 	"HostName": "5e0055c814a6",
-// 	"MappedDirectories": [],
-// 	"HvPartition": false,
-// 	"EndpointList": ["eef2649d-bb17-4d53-9937-295a8efe6f2c"],
-// }
+//	{
+//		"SystemType": "Container",
+//		"Name": "5e0055c814a6005b8e57ac59f9a522066e0af12b48b3c26a9416e23907698776",
+//		"Owner": "docker",
+//		"VolumePath": "\\\\\\\\?\\\\Volume{66d1ef4c-7a00-11e6-8948-00155ddbef9d}",
+//		"IgnoreFlushesDuringBoot": true,
+//		"LayerFolderPath": "C:\\\\control\\\\windowsfilter\\\\5e0055c814a6005b8e57ac59f9a522066e0af12b48b3c26a9416e23907698776",
+//		"Layers": [{
+//			"ID": "18955d65-d45a-557b-bf1c-49d6dfefc526",
+//			"Path": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c"
+//		}],
+//		"HostName": "5e0055c814a6",
+//		"MappedDirectories": [],
+//		"HvPartition": false,
+//		"EndpointList": ["eef2649d-bb17-4d53-9937-295a8efe6f2c"],
+//	}
 //
 // Isolation=Hyper-V example:
 //
-// {
-// 	"SystemType": "Container",
-// 	"Name": "475c2c58933b72687a88a441e7e0ca4bd72d76413c5f9d5031fee83b98f6045d",
-// 	"Owner": "docker",
-// 	"IgnoreFlushesDuringBoot": true,
-// 	"Layers": [{
-// 		"ID": "18955d65-d45a-557b-bf1c-49d6dfefc526",
-// 		"Path": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c"
-// 	}],
-// 	"HostName": "475c2c58933b",
-// 	"MappedDirectories": [],
-// 	"HvPartition": true,
-// 	"EndpointList": ["e1bb1e61-d56f-405e-b75d-fd520cefa0cb"],
-// 	"DNSSearchList": "a.com,b.com,c.com",
-// 	"HvRuntime": {
-// 		"ImagePath": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c\\\\UtilityVM"
-// 	},
-// }
+//	{
+//		"SystemType": "Container",
+//		"Name": "475c2c58933b72687a88a441e7e0ca4bd72d76413c5f9d5031fee83b98f6045d",
+//		"Owner": "docker",
+//		"IgnoreFlushesDuringBoot": true,
+//		"Layers": [{
+//			"ID": "18955d65-d45a-557b-bf1c-49d6dfefc526",
+//			"Path": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c"
+//		}],
+//		"HostName": "475c2c58933b",
+//		"MappedDirectories": [],
+//		"HvPartition": true,
+//		"EndpointList": ["e1bb1e61-d56f-405e-b75d-fd520cefa0cb"],
+//		"DNSSearchList": "a.com,b.com,c.com",
+//		"HvRuntime": {
+//			"ImagePath": "C:\\\\control\\\\windowsfilter\\\\65bf96e5760a09edf1790cb229e2dfb2dbd0fcdc0bf7451bae099106bfbfea0c\\\\UtilityVM"
+//		},
+//	}
 func (c *client) Create(_ context.Context, id string, spec *specs.Spec, shim string, runtimeOptions interface{}, opts ...containerd.NewContainerOpts) error {
 	if ctr := c.getContainer(id); ctr != nil {
 		return errors.WithStack(errdefs.Conflict(errors.New("id already in use")))
@@ -1085,6 +1085,29 @@ func (c *client) Stats(_ context.Context, containerID string) (*libcontainerdtyp
 	}, nil
 }
 
+// AllStats handles stats requests for every container known to this client.
+// HCS has no batch statistics call, so this is a loop over Stats, but it
+// still gives callers a single place to collect from instead of having to
+// list containers themselves.
+func (c *client) AllStats(ctx context.Context) (map[string]*libcontainerdtypes.Stats, error) {
+	c.Lock()
+	ids := make([]string, 0, len(c.containers))
+	for id := range c.containers {
+		ids = append(ids, id)
+	}
+	c.Unlock()
+
+	out := make(map[string]*libcontainerdtypes.Stats, len(ids))
+	for _, id := range ids {
+		s, err := c.Stats(ctx, id)
+		if err != nil {
+			continue
+		}
+		out[id] = s
+	}
+	return out, nil
+}
+
 // Restore is the handler for restoring a container
 func (c *client) Restore(ctx context.Context, id string, attachStdio libcontainerdtypes.StdioCallback) (bool, int, libcontainerdtypes.Process, error) {
 	c.logger.WithField("container", id).Debug("restore()")
@@ -1224,6 +1247,34 @@ func (c *client) Status(ctx context.Context, containerID string) (containerd.Pro
 	return ctr.status, nil
 }
 
+// RuntimeInfo returns the runtime state of containerID's init process. There
+// is no OCI runtime bundle path or cgroups path on Windows - HCS manages
+// the container's state directly - so those fields are always empty; the
+// spec's annotations are still reported since they're set by the daemon at
+// create time same as on Linux.
+func (c *client) RuntimeInfo(ctx context.Context, containerID string) (*libcontainerdtypes.RuntimeInfo, error) {
+	c.Lock()
+	defer c.Unlock()
+	ctr := c.containers[containerID]
+	if ctr == nil {
+		return nil, errors.WithStack(errdefs.NotFound(errors.New("no such container")))
+	}
+
+	ctr.Lock()
+	defer ctr.Unlock()
+
+	info := &libcontainerdtypes.RuntimeInfo{
+		Status: ctr.status,
+	}
+	if ctr.ociSpec != nil {
+		info.Annotations = ctr.ociSpec.Annotations
+	}
+	if ctr.init != nil {
+		info.Pid = ctr.init.pid
+	}
+	return info, nil
+}
+
 func (c *client) UpdateResources(ctx context.Context, containerID string, resources *libcontainerdtypes.Resources) error {
 	// Updating resource isn't supported on Windows
 	// but we should return nil for enabling updating container
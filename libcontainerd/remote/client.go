@@ -1,3 +1,8 @@
+// Package remote implements the libcontainerd Client interface by talking
+// to a containerd daemon over its v1 tasks, events, content and snapshots
+// gRPC services, using the vendored containerd v1 client and shim v2 runc
+// options. There is no remaining dependency on containerd's older
+// api/grpc/types wire API.
 package remote // import "github.com/docker/docker/libcontainerd/remote"
 
 import (
@@ -15,6 +20,7 @@ import (
 
 	"github.com/containerd/containerd"
 	apievents "github.com/containerd/containerd/api/events"
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
 	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/archive"
 	"github.com/containerd/containerd/cio"
@@ -395,6 +401,35 @@ func (c *client) Stats(ctx context.Context, containerID string) (*libcontainerdt
 	return libcontainerdtypes.InterfaceToStats(m.Timestamp, v), nil
 }
 
+// AllStats returns metrics for every task containerd currently knows about
+// in this client's namespace, collected with a single call to containerd's
+// task metrics API rather than one round-trip per container. Containers
+// with no running task (e.g. because they are not started) are simply
+// absent from the result, not an error.
+//
+// Sourcing metrics from containerd's tasks service here (and in Stats,
+// above) rather than reading cgroups directly in the daemon keeps stats
+// collection agnostic to what's actually backing a task: a runc shim on
+// Linux cgroups today, but just as well a VM-based or other future runtime
+// shim with no host cgroup hierarchy to read from at all.
+func (c *client) AllStats(ctx context.Context) (map[string]*libcontainerdtypes.Stats, error) {
+	resp, err := c.client.TaskService().Metrics(ctx, &tasks.MetricsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*libcontainerdtypes.Stats, len(resp.Metrics))
+	for _, m := range resp.Metrics {
+		v, err := typeurl.UnmarshalAny(m.Data)
+		if err != nil {
+			c.logger.WithError(err).WithField("container", m.ID).Warn("unmarshalling containerd task metrics")
+			continue
+		}
+		out[m.ID] = libcontainerdtypes.InterfaceToStats(m.Timestamp, v)
+	}
+	return out, nil
+}
+
 func (c *client) ListPids(ctx context.Context, containerID string) ([]uint32, error) {
 	p, err := c.getProcess(ctx, containerID, libcontainerdtypes.InitProcessName)
 	if err != nil {
@@ -511,6 +546,56 @@ func (c *client) Status(ctx context.Context, containerID string) (containerd.Pro
 	return s.Status, nil
 }
 
+// RuntimeInfo returns the OCI runtime state of containerID's init process:
+// its bundle path, runtime pid, status, cgroups path and spec annotations.
+// It's meant for debugging below the Docker abstraction, so it's read
+// straight from containerd/the OCI spec rather than from anything docker
+// itself tracks.
+func (c *client) RuntimeInfo(ctx context.Context, containerID string) (*libcontainerdtypes.RuntimeInfo, error) {
+	ctr, err := c.getContainer(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := ctr.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := ctr.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		cgroupsPath string
+		annotations map[string]string
+	)
+	if spec.Linux != nil {
+		cgroupsPath = spec.Linux.CgroupsPath
+	}
+	annotations = spec.Annotations
+
+	info := &libcontainerdtypes.RuntimeInfo{
+		BundlePath:  labels[DockerContainerBundlePath],
+		CgroupsPath: cgroupsPath,
+		Annotations: annotations,
+		Status:      containerd.Unknown,
+	}
+
+	t, err := c.getProcess(ctx, containerID, libcontainerdtypes.InitProcessName)
+	if err != nil {
+		return info, nil
+	}
+	info.Pid = int(t.Pid())
+
+	s, err := t.Status(ctx)
+	if err == nil {
+		info.Status = s.Status
+	}
+
+	return info, nil
+}
+
 func (c *client) CreateCheckpoint(ctx context.Context, containerID, checkpointDir string, exit bool) error {
 	p, err := c.getProcess(ctx, containerID, libcontainerdtypes.InitProcessName)
 	if err != nil {
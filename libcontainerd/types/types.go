@@ -61,8 +61,13 @@ type Client interface {
 	Pause(ctx context.Context, containerID string) error
 	Resume(ctx context.Context, containerID string) error
 	Stats(ctx context.Context, containerID string) (*Stats, error)
+	// AllStats returns stats for every container with a running task, in a
+	// single pass, for callers (such as the stats collector) that would
+	// otherwise call Stats once per container every tick.
+	AllStats(ctx context.Context) (map[string]*Stats, error)
 	ListPids(ctx context.Context, containerID string) ([]uint32, error)
 	Summary(ctx context.Context, containerID string) ([]Summary, error)
+	RuntimeInfo(ctx context.Context, containerID string) (*RuntimeInfo, error)
 	DeleteTask(ctx context.Context, containerID string) (uint32, time.Time, error)
 	Delete(ctx context.Context, containerID string) error
 	Status(ctx context.Context, containerID string) (containerd.ProcessStatus, error)
@@ -71,6 +76,16 @@ type Client interface {
 	CreateCheckpoint(ctx context.Context, containerID, checkpointDir string, exit bool) error
 }
 
+// RuntimeInfo describes the OCI runtime state of a container's init
+// process, surfaced for operators debugging below the Docker abstraction.
+type RuntimeInfo struct {
+	BundlePath  string
+	Pid         int
+	Status      containerd.ProcessStatus
+	CgroupsPath string
+	Annotations map[string]string
+}
+
 // StdioCallback is called to connect a container or process stdio.
 type StdioCallback func(io *cio.DirectIO) (cio.IO, error)
 
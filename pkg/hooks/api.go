@@ -0,0 +1,52 @@
+package hooks // import "github.com/docker/docker/pkg/hooks"
+
+import (
+	containertypes "github.com/docker/docker/api/types/container"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// APIImplements is the name of the interface all container-hooks
+	// plugins implement.
+	APIImplements = "docker.hooks/1"
+
+	// APIContainerStart is the URL for the container-start hook call.
+	APIContainerStart = "HooksPlugin.ContainerStart"
+
+	// APIContainerStop is the URL for the container-stop hook call.
+	APIContainerStop = "HooksPlugin.ContainerStop"
+)
+
+// StartRequest holds the data passed to a container-hooks plugin's
+// ContainerStart call, made synchronously just before the daemon asks
+// containerd to create the container.
+type StartRequest struct {
+	ContainerID string
+	Config      *containertypes.Config
+	HostConfig  *containertypes.HostConfig
+
+	// Spec is the OCI runtime spec the container is about to be created
+	// with. A plugin cannot edit it directly over the wire; instead, it
+	// returns AddDevices in StartResponse to contribute entries that the
+	// daemon appends to Spec.Linux.Devices.
+	Spec *specs.Spec
+}
+
+// StartResponse is returned by a container-hooks plugin's ContainerStart
+// call.
+type StartResponse struct {
+	// Allow denies the start when false. Msg should explain why.
+	Allow bool
+	Msg   string `json:",omitempty"`
+
+	// AddDevices lists extra Linux devices to inject into the container,
+	// appended to the spec's device list after every plugin has run.
+	AddDevices []specs.LinuxDevice `json:",omitempty"`
+}
+
+// StopRequest holds the data passed to a container-hooks plugin's
+// ContainerStop call, made synchronously as part of the daemon's
+// container cleanup path.
+type StopRequest struct {
+	ContainerID string
+}
@@ -0,0 +1,90 @@
+package hooks // import "github.com/docker/docker/pkg/hooks"
+
+import (
+	"sync"
+
+	"github.com/docker/docker/pkg/plugingetter"
+	"github.com/docker/docker/pkg/plugins"
+)
+
+// Plugin allows a third party plugin to be called around container start
+// and cleanup, the same way volume and network plugins are: for admission
+// control (deny a start) and for contributing extra devices to the spec.
+type Plugin interface {
+	// Name returns the registered plugin name.
+	Name() string
+
+	// ContainerStart is called synchronously before the daemon asks
+	// containerd to create the container.
+	ContainerStart(*StartRequest) (*StartResponse, error)
+
+	// ContainerStop is called synchronously as part of the daemon's
+	// container cleanup path.
+	ContainerStop(*StopRequest) error
+}
+
+// NewPlugins constructs the configured container-hooks plugins, resolved
+// through pg. Plugins are looked up lazily, on first use, same as
+// authorization plugins.
+func NewPlugins(pg plugingetter.PluginGetter, names []string) []Plugin {
+	out := make([]Plugin, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		out = append(out, &hooksPlugin{pg: pg, name: name})
+	}
+	return out
+}
+
+// hooksPlugin is an internal adapter to the docker plugin system.
+type hooksPlugin struct {
+	pg      plugingetter.PluginGetter
+	name    string
+	client  *plugins.Client
+	initErr error
+	once    sync.Once
+}
+
+func (p *hooksPlugin) Name() string {
+	return p.name
+}
+
+func (p *hooksPlugin) ContainerStart(req *StartRequest) (*StartResponse, error) {
+	if err := p.initPlugin(); err != nil {
+		return nil, err
+	}
+	res := &StartResponse{}
+	if err := p.client.Call(APIContainerStart, req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *hooksPlugin) ContainerStop(req *StopRequest) error {
+	if err := p.initPlugin(); err != nil {
+		return err
+	}
+	return p.client.Call(APIContainerStop, req, nil)
+}
+
+func (p *hooksPlugin) initPlugin() error {
+	p.once.Do(func() {
+		var plugin plugingetter.CompatPlugin
+		var err error
+		if p.pg != nil {
+			plugin, err = p.pg.Get(p.name, APIImplements, plugingetter.Lookup)
+		} else {
+			plugin, err = plugins.Get(p.name, APIImplements)
+		}
+		if err != nil {
+			p.initErr = err
+			return
+		}
+		p.name = plugin.Name()
+		p.client = plugin.Client()
+	})
+	return p.initErr
+}
@@ -29,23 +29,88 @@ var (
 // and releases new byte slices to adjust to current needs, so the buffer
 // won't be overgrown after peak loads.
 type BytesPipe struct {
-	mu       sync.Mutex
-	wait     *sync.Cond
-	buf      []*fixedBuffer
-	bufLen   int
-	closeErr error // error to return from next Read. set to nil if not closed.
+	mu         sync.Mutex
+	wait       *sync.Cond
+	buf        []*fixedBuffer
+	bufLen     int
+	closeErr   error // error to return from next Read. set to nil if not closed.
+	maxBytes   int   // overrides blockThreshold when > 0
+	policy     OverflowPolicy
+	overflowed bool
+	onOverflow func()
 }
 
+// OverflowPolicy controls what a BytesPipe does once its buffered, unread
+// data reaches its capacity.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Write block until a Read frees up room. This is
+	// the default, and matches the behaviour of NewBytesPipe.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest unread data to make room for
+	// new writes, rather than blocking the writer.
+	OverflowDropOldest
+)
+
 // NewBytesPipe creates new BytesPipe, initialized by specified slice.
 // If buf is nil, then it will be initialized with slice which cap is 64.
 // buf will be adjusted in a way that len(buf) == 0, cap(buf) == cap(buf).
 func NewBytesPipe() *BytesPipe {
-	bp := &BytesPipe{}
+	return NewBytesPipeLimited(0, OverflowBlock)
+}
+
+// NewBytesPipeLimited creates a new BytesPipe whose buffered, unread data is
+// capped at maxBytes (or at the default blockThreshold if maxBytes <= 0),
+// applying policy once that cap is reached.
+func NewBytesPipeLimited(maxBytes int, policy OverflowPolicy) *BytesPipe {
+	bp := &BytesPipe{maxBytes: maxBytes, policy: policy}
 	bp.buf = append(bp.buf, getBuffer(minCap))
 	bp.wait = sync.NewCond(&bp.mu)
 	return bp
 }
 
+// SetOverflowCallback arranges for f to be called, at most once, the first
+// time the pipe's buffered data reaches its cap. It is used by callers that
+// need to react to an overflow themselves (for example, killing whatever is
+// producing the data) regardless of which OverflowPolicy is in effect.
+func (bp *BytesPipe) SetOverflowCallback(f func()) {
+	bp.mu.Lock()
+	bp.onOverflow = f
+	bp.mu.Unlock()
+}
+
+func (bp *BytesPipe) threshold() int {
+	if bp.maxBytes > 0 {
+		return bp.maxBytes
+	}
+	return blockThreshold
+}
+
+// dropOldestLocked discards the oldest unread buffer to make room for new
+// writes, reporting whether it freed anything. Once only the single buffer
+// currently being written to is left, there's nothing left to drop and the
+// caller falls back to blocking.
+func (bp *BytesPipe) dropOldestLocked() bool {
+	if len(bp.buf) <= 1 {
+		return false
+	}
+	b := bp.buf[0]
+	bp.bufLen -= b.Len()
+	returnBuffer(b)
+	bp.buf[0] = nil
+	bp.buf = bp.buf[1:]
+	return true
+}
+
+func (bp *BytesPipe) signalOverflowLocked() {
+	if bp.overflowed || bp.onOverflow == nil {
+		return
+	}
+	bp.overflowed = true
+	go bp.onOverflow()
+}
+
 // Write writes p to BytesPipe.
 // It can allocate new []byte slices in a process of writing.
 func (bp *BytesPipe) Write(p []byte) (int, error) {
@@ -85,7 +150,11 @@ loop0:
 		p = p[n:]
 
 		// make sure the buffer doesn't grow too big from this write
-		for bp.bufLen >= blockThreshold {
+		for bp.bufLen >= bp.threshold() {
+			bp.signalOverflowLocked()
+			if bp.policy == OverflowDropOldest && bp.dropOldestLocked() {
+				continue
+			}
 			bp.wait.Wait()
 			if bp.closeErr != nil {
 				continue loop0
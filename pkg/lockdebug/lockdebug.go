@@ -0,0 +1,283 @@
+/*
+Package lockdebug provides a drop-in replacement for sync.Mutex that, once
+enabled, tracks lock ownership and waiters so a watchdog can report locks
+held beyond a threshold and lock-wait cycles (deadlocks) across goroutines,
+logging the stack each lock was acquired from.
+
+Tracking is off by default: with Enable never called, Mutex behaves like a
+plain sync.Mutex and pays only the cost of a single atomic load per
+Lock/Unlock call.
+*/
+package lockdebug // import "github.com/docker/docker/pkg/lockdebug"
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var enabled int32
+
+// Enable turns on ownership and waiter tracking for every lockdebug.Mutex in
+// the process. It is safe to call more than once.
+func Enable() {
+	atomic.StoreInt32(&enabled, 1)
+}
+
+// Enabled reports whether tracking is currently turned on.
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) != 0
+}
+
+type holderInfo struct {
+	goroutine int64
+	stack     string
+	acquired  time.Time
+}
+
+// Mutex is a drop-in replacement for sync.Mutex. When tracking is enabled
+// (see Enable), it records the current holder (with acquisition stack) and
+// the set of goroutines waiting to acquire it.
+type Mutex struct {
+	mu sync.Mutex
+
+	trackMu sync.Mutex
+	holder  *holderInfo
+	waiters map[int64]struct{}
+}
+
+// Lock acquires the mutex, blocking until it is available.
+func (m *Mutex) Lock() {
+	if !Enabled() {
+		m.mu.Lock()
+		return
+	}
+
+	gid := goroutineID()
+	m.addWaiter(gid)
+	globalRegistry.setWaiting(gid, m)
+
+	m.mu.Lock()
+
+	globalRegistry.setWaiting(gid, nil)
+	m.removeWaiter(gid)
+	m.setHolder(gid)
+	globalRegistry.setHeld(m, true)
+}
+
+// Unlock releases the mutex.
+func (m *Mutex) Unlock() {
+	if Enabled() {
+		m.clearHolder()
+		globalRegistry.setHeld(m, false)
+	}
+	m.mu.Unlock()
+}
+
+func (m *Mutex) addWaiter(gid int64) {
+	m.trackMu.Lock()
+	if m.waiters == nil {
+		m.waiters = make(map[int64]struct{})
+	}
+	m.waiters[gid] = struct{}{}
+	m.trackMu.Unlock()
+}
+
+func (m *Mutex) removeWaiter(gid int64) {
+	m.trackMu.Lock()
+	delete(m.waiters, gid)
+	m.trackMu.Unlock()
+}
+
+func (m *Mutex) setHolder(gid int64) {
+	m.trackMu.Lock()
+	m.holder = &holderInfo{
+		goroutine: gid,
+		stack:     string(stack()),
+		acquired:  time.Now(),
+	}
+	m.trackMu.Unlock()
+}
+
+func (m *Mutex) clearHolder() {
+	m.trackMu.Lock()
+	m.holder = nil
+	m.trackMu.Unlock()
+}
+
+// snapshot returns the current holder (nil if unlocked) and waiter count.
+func (m *Mutex) snapshot() (*holderInfo, int) {
+	m.trackMu.Lock()
+	defer m.trackMu.Unlock()
+	return m.holder, len(m.waiters)
+}
+
+// registry tracks, for every goroutine currently blocked in Lock, which
+// Mutex it is waiting on. This is what lets the watchdog walk waiter ->
+// holder chains across distinct Mutex values to find lock-wait cycles.
+type registry struct {
+	mu         sync.Mutex
+	waitingFor map[int64]*Mutex
+	held       map[*Mutex]struct{}
+}
+
+var globalRegistry = &registry{
+	waitingFor: make(map[int64]*Mutex),
+	held:       make(map[*Mutex]struct{}),
+}
+
+func (r *registry) setWaiting(gid int64, m *Mutex) {
+	r.mu.Lock()
+	if m == nil {
+		delete(r.waitingFor, gid)
+	} else {
+		r.waitingFor[gid] = m
+	}
+	r.mu.Unlock()
+}
+
+func (r *registry) snapshot() map[int64]*Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[int64]*Mutex, len(r.waitingFor))
+	for gid, m := range r.waitingFor {
+		out[gid] = m
+	}
+	return out
+}
+
+func (r *registry) setHeld(m *Mutex, held bool) {
+	r.mu.Lock()
+	if held {
+		r.held[m] = struct{}{}
+	} else {
+		delete(r.held, m)
+	}
+	r.mu.Unlock()
+}
+
+// heldMutexes returns every currently locked Mutex, regardless of whether
+// any other goroutine is waiting on it.
+func (r *registry) heldMutexes() []*Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*Mutex, 0, len(r.held))
+	for m := range r.held {
+		out = append(out, m)
+	}
+	return out
+}
+
+// StartWatchdog starts a background goroutine that, every checkInterval,
+// scans all tracked mutexes for locks held longer than heldThreshold and for
+// lock-wait cycles between goroutines, logging a warning with the
+// offending holder's acquisition stack for each. It calls Enable. The
+// returned func stops the watchdog.
+func StartWatchdog(checkInterval, heldThreshold time.Duration) (stop func()) {
+	Enable()
+
+	done := make(chan struct{})
+	ticker := time.NewTicker(checkInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				checkLongHeld(heldThreshold)
+				checkCycles()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+func checkLongHeld(threshold time.Duration) {
+	for _, m := range globalRegistry.heldMutexes() {
+		holder, waiters := m.snapshot()
+		if holder == nil {
+			continue
+		}
+		held := time.Since(holder.acquired)
+		if held < threshold {
+			continue
+		}
+		logrus.Warnf(
+			"lockdebug: lock held by goroutine %d for %s (waiters: %d); acquired at:\n%s",
+			holder.goroutine, held, waiters, holder.stack,
+		)
+	}
+}
+
+// checkCycles reports deadlocks: goroutines that are waiting on a lock whose
+// holder is, transitively, itself waiting on a lock held by the first
+// goroutine.
+func checkCycles() {
+	waiting := globalRegistry.snapshot()
+	reported := make(map[int64]struct{})
+
+	for start, m := range waiting {
+		if _, ok := reported[start]; ok {
+			continue
+		}
+
+		seen := map[int64]struct{}{start: {}}
+		cur := m
+		for cur != nil {
+			holder, _ := cur.snapshot()
+			if holder == nil {
+				break
+			}
+			if holder.goroutine == start {
+				logrus.Warnf(
+					"lockdebug: possible deadlock detected: goroutine %d is waiting on a lock cycle; lock acquired at:\n%s",
+					start, holder.stack,
+				)
+				reported[start] = struct{}{}
+				break
+			}
+			if _, ok := seen[holder.goroutine]; ok {
+				break
+			}
+			seen[holder.goroutine] = struct{}{}
+			cur = waiting[holder.goroutine]
+		}
+	}
+}
+
+func stack() []byte {
+	buf := make([]byte, 8192)
+	n := runtime.Stack(buf, false)
+	return buf[:n]
+}
+
+// goroutineID returns the id of the calling goroutine by parsing it out of
+// runtime.Stack's "goroutine NNN [running]:" header. This is the same
+// well-known approach used by various debug tooling; it is only ever used
+// here to key tracking maps, never for any correctness-affecting logic.
+func goroutineID() int64 {
+	buf := make([]byte, 64)
+	n := runtime.Stack(buf, false)
+	buf = buf[:n]
+
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	idx := bytes.IndexByte(buf, ' ')
+	if idx < 0 {
+		return 0
+	}
+	id, err := strconv.ParseInt(string(buf[:idx]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,107 @@
+package lockdebug // import "github.com/docker/docker/pkg/lockdebug"
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// captureWarnings runs fn while recording logrus warnings emitted through
+// the standard logger, returning their messages.
+func captureWarnings(t *testing.T, fn func()) []string {
+	t.Helper()
+
+	var mu sync.Mutex
+	var messages []string
+	hook := &captureHook{record: func(msg string) {
+		mu.Lock()
+		messages = append(messages, msg)
+		mu.Unlock()
+	}}
+
+	old := logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(hook)
+	defer logrus.StandardLogger().ReplaceHooks(old)
+
+	fn()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return messages
+}
+
+type captureHook struct {
+	record func(string)
+}
+
+func (h *captureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *captureHook) Fire(e *logrus.Entry) error {
+	h.record(e.Message)
+	return nil
+}
+
+func TestMutexLockUnlock(t *testing.T) {
+	Enable()
+
+	var m Mutex
+	var counter int
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Lock()
+			counter++
+			m.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if counter != 50 {
+		t.Fatalf("expected counter to be 50, got %d", counter)
+	}
+}
+
+func TestCheckLongHeld(t *testing.T) {
+	Enable()
+
+	var m Mutex
+	m.Lock()
+	defer m.Unlock()
+
+	warnings := captureWarnings(t, func() {
+		checkLongHeld(0)
+	})
+	if len(warnings) == 0 {
+		t.Fatal("expected a warning about the held lock, got none")
+	}
+}
+
+func TestCheckCyclesDetectsDeadlock(t *testing.T) {
+	Enable()
+
+	var a, b Mutex
+	a.Lock()
+	b.Lock()
+
+	// Simulate goroutine 1 holding a and waiting on b, and goroutine 2
+	// holding b and waiting on a, without actually blocking the test.
+	globalRegistry.setWaiting(1, &b)
+	globalRegistry.setWaiting(2, &a)
+	a.trackMu.Lock()
+	a.holder = &holderInfo{goroutine: 1, acquired: time.Now()}
+	a.trackMu.Unlock()
+	b.trackMu.Lock()
+	b.holder = &holderInfo{goroutine: 2, acquired: time.Now()}
+	b.trackMu.Unlock()
+
+	warnings := captureWarnings(t, checkCycles)
+	if len(warnings) == 0 {
+		t.Fatal("expected a deadlock warning, got none")
+	}
+}
@@ -13,7 +13,7 @@
 // A handshake is send at /Plugin.Activate, and plugins are expected to return
 // a Manifest with a list of Docker subsystems which this plugin implements.
 //
-// In order to use a plugins, you can use the ``Get`` with the name of the
+// In order to use a plugins, you can use the “Get“ with the name of the
 // plugin and the subsystem it implements.
 //
 //	plugin, err := plugins.Get("example", "VolumeDriver")
@@ -34,6 +34,13 @@ import (
 // ProtocolSchemeHTTPV1 is the name of the protocol used for interacting with plugins using this package.
 const ProtocolSchemeHTTPV1 = "moby.plugins.http/v1"
 
+// ProtocolSchemeGRPCV2 is the name of the protocol a plugin advertises to
+// opt into the v2 gRPC streaming protocol (currently only implemented by log
+// driver plugins). Unlike ProtocolSchemeHTTPV1, the client for this scheme
+// is not provided by this package; callers that support it construct their
+// own gRPC client against the plugin's address.
+const ProtocolSchemeGRPCV2 = "moby.plugins.grpc/v2"
+
 var (
 	// ErrNotImplements is returned if the plugin does not implement the requested driver.
 	ErrNotImplements = errors.New("Plugin does not implement the requested driver")
@@ -53,6 +53,24 @@ func ChanOutput(progressChan chan<- Progress) Output {
 	return chanOutput(progressChan)
 }
 
+type teeOutput struct {
+	out Output
+	fn  func(Progress)
+}
+
+func (t teeOutput) WriteProgress(p Progress) error {
+	t.fn(p)
+	return t.out.WriteProgress(p)
+}
+
+// Tee returns an Output that forwards every write to out, after first
+// passing it to fn. It's used to observe progress updates (e.g. to
+// publish them as daemon events) without disturbing the original
+// consumer of out.
+func Tee(out Output, fn func(Progress)) Output {
+	return teeOutput{out: out, fn: fn}
+}
+
 type discardOutput struct{}
 
 func (discardOutput) WriteProgress(Progress) error {
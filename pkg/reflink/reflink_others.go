@@ -0,0 +1,29 @@
+//go:build !linux
+// +build !linux
+
+package reflink // import "github.com/docker/docker/pkg/reflink"
+
+import "os"
+
+// copyFile always performs a regular copy on platforms without a supported
+// reflink ioctl.
+func copyFile(dst, src string) error {
+	srcF, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcF.Close()
+
+	info, err := srcF.Stat()
+	if err != nil {
+		return err
+	}
+
+	dstF, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer dstF.Close()
+
+	return copyFallback(dstF, srcF)
+}
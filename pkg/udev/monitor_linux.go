@@ -0,0 +1,103 @@
+// Package udev provides a minimal client for the kernel's uevent netlink
+// broadcast (the same socket udevd itself listens on), so callers can
+// notice host devices appearing and disappearing without depending on
+// libudev or shelling out to udevadm.
+package udev
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Event is a single kernel uevent.
+type Event struct {
+	Action    string // "add", "remove", "change", ...
+	DevPath   string
+	Subsystem string
+	Vars      map[string]string
+}
+
+// DevNode returns the /dev node path the event refers to, or "" if the
+// event didn't carry a DEVNAME (e.g. devices with no device node, such as
+// most network interfaces).
+func (e Event) DevNode() string {
+	name, ok := e.Vars["DEVNAME"]
+	if !ok {
+		return ""
+	}
+	return "/dev/" + name
+}
+
+// Monitor is a connected handle to the kernel uevent broadcast socket.
+type Monitor struct {
+	fd int
+}
+
+// NewMonitor opens and binds the uevent netlink socket. The calling
+// process needs CAP_NET_ADMIN, which the daemon already holds.
+func NewMonitor() (*Monitor, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, fmt.Errorf("opening uevent netlink socket: %v", err)
+	}
+
+	// Group 1 is the kernel's own uevent group; group 2 is reserved for
+	// udevd's enriched re-broadcast. The kernel group carries everything
+	// udevd would see and works whether or not udevd is running, so it's
+	// the only one used here.
+	addr := &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: 1}
+	if err := unix.Bind(fd, addr); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("binding uevent netlink socket: %v", err)
+	}
+
+	return &Monitor{fd: fd}, nil
+}
+
+// Close closes the underlying socket, causing any blocked Read to return
+// an error.
+func (m *Monitor) Close() error {
+	return unix.Close(m.fd)
+}
+
+// Read blocks until the next uevent arrives and returns it.
+func (m *Monitor) Read() (Event, error) {
+	buf := make([]byte, 8192)
+	n, err := unix.Read(m.fd, buf)
+	if err != nil {
+		return Event{}, err
+	}
+	return parseEvent(buf[:n]), nil
+}
+
+// parseEvent decodes a raw kernel uevent message: a NUL-separated list of
+// strings whose first entry is "ACTION@DEVPATH", followed by KEY=VALUE
+// environment-style pairs.
+func parseEvent(raw []byte) Event {
+	ev := Event{Vars: map[string]string{}}
+	for i, part := range bytes.Split(raw, []byte{0}) {
+		if len(part) == 0 {
+			continue
+		}
+		s := string(part)
+		if i == 0 {
+			if idx := strings.IndexByte(s, '@'); idx >= 0 {
+				ev.Action = s[:idx]
+				ev.DevPath = s[idx+1:]
+			}
+			continue
+		}
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		ev.Vars[kv[0]] = kv[1]
+		if kv[0] == "SUBSYSTEM" {
+			ev.Subsystem = kv[1]
+		}
+	}
+	return ev
+}
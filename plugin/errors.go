@@ -64,3 +64,11 @@ func (e alreadyExistsError) Error() string {
 }
 
 func (alreadyExistsError) Conflict() {}
+
+type errUnhealthy string
+
+func (name errUnhealthy) Error() string {
+	return fmt.Sprintf("plugin %s is not responding to health checks", string(name))
+}
+
+func (errUnhealthy) Unavailable() {}
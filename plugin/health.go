@@ -0,0 +1,67 @@
+package plugin // import "github.com/docker/docker/plugin"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	v2 "github.com/docker/docker/plugin/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// healthCheckInterval is the time between liveness probes of enabled plugins.
+	healthCheckInterval = 30 * time.Second
+
+	// healthCheckTimeout bounds how long a single liveness probe may take.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// startHealthChecks kicks off a background goroutine that periodically
+// probes every enabled managed plugin and marks it degraded if it stops
+// responding. It is started once, when the manager comes up, and runs for
+// the lifetime of the daemon.
+func (pm *Manager) startHealthChecks() {
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			pm.probeAll()
+		}
+	}()
+}
+
+// probeAll runs a liveness probe against every enabled plugin concurrently.
+func (pm *Manager) probeAll() {
+	var wg sync.WaitGroup
+	for _, p := range pm.config.Store.GetAll() {
+		if !p.IsEnabled() {
+			continue
+		}
+		wg.Add(1)
+		go func(p *v2.Plugin) {
+			defer wg.Done()
+			pm.probeOne(p)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// probeOne sends a single liveness probe to p and updates its health state.
+// A plugin transitioning to or from unhealthy is logged as a plugin event
+// so that operators can see it happen, rather than only discovering it when
+// the next volume mount or network operation fails.
+func (pm *Manager) probeOne(p *v2.Plugin) {
+	err := p.Client().CallWithOptions("Plugin.Activate", nil, &plugins.Manifest{}, plugins.WithRequestTimeout(healthCheckTimeout))
+	healthy := err == nil
+
+	if changed := p.SetHealthy(healthy); changed {
+		if healthy {
+			logrus.WithField("id", p.GetID()).Warn("plugin health check recovered")
+			pm.config.LogPluginEvent(p.GetID(), p.Name(), "health_check_passed")
+		} else {
+			logrus.WithError(err).WithField("id", p.GetID()).Warn("plugin health check failed, marking plugin degraded")
+			pm.config.LogPluginEvent(p.GetID(), p.Name(), "health_check_failed")
+		}
+	}
+}
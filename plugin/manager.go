@@ -129,6 +129,7 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	}
 
 	manager.publisher = pubsub.NewPublisher(0, 0)
+	manager.startHealthChecks()
 	return manager, nil
 }
 
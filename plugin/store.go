@@ -141,6 +141,9 @@ func (ps *Store) Get(name, capability string, mode int) (plugingetter.CompatPlug
 		p, err := ps.GetV2Plugin(name)
 		if err == nil {
 			if p.IsEnabled() {
+				if !p.IsHealthy() {
+					return nil, errUnhealthy(name)
+				}
 				fp, err := p.FilterByCap(capability)
 				if err != nil {
 					return nil, err
@@ -32,6 +32,11 @@ type Plugin struct {
 	SwarmServiceID string
 	timeout        time.Duration
 	addr           net.Addr
+
+	// unhealthy is set when periodic liveness probing determines the
+	// plugin is not responding. A freshly loaded or restored plugin is
+	// assumed healthy until a probe says otherwise.
+	unhealthy bool
 }
 
 const defaultPluginRuntimeDestination = "/run/docker/plugins"
@@ -211,6 +216,26 @@ func (p *Plugin) IsEnabled() bool {
 	return p.PluginObj.Enabled
 }
 
+// IsHealthy returns false if the plugin has failed its most recent
+// liveness probes and should be considered degraded.
+func (p *Plugin) IsHealthy() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return !p.unhealthy
+}
+
+// SetHealthy updates the plugin's health state, as determined by periodic
+// liveness probing. It returns true if the health state changed.
+func (p *Plugin) SetHealthy(healthy bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	changed := p.unhealthy == healthy
+	p.unhealthy = !healthy
+	return changed
+}
+
 // GetID returns the plugin's ID.
 func (p *Plugin) GetID() string {
 	p.mu.RLock()
@@ -18,6 +18,19 @@ func GetDefaultProfile(rs *specs.Spec) (*specs.LinuxSeccomp, error) {
 	return setupSeccomp(DefaultProfile(), rs)
 }
 
+// GenerateProfile returns a profile based on the default profile, except
+// that syscalls outside the default allow-list are logged to the kernel
+// audit log (SCMP_ACT_LOG) and allowed to proceed, rather than being
+// blocked. Running a container with this profile lets an operator learn,
+// from the resulting audit log entries, which syscalls beyond the default
+// set that workload actually needs, so a tighter profile can be written for
+// it afterwards.
+func GenerateProfile(rs *specs.Spec) (*specs.LinuxSeccomp, error) {
+	profile := DefaultProfile()
+	profile.DefaultAction = types.ActLog
+	return setupSeccomp(profile, rs)
+}
+
 // LoadProfile takes a json string and decodes the seccomp profile.
 func LoadProfile(body string, rs *specs.Spec) (*specs.LinuxSeccomp, error) {
 	var config types.Seccomp
@@ -0,0 +1,85 @@
+package registry // import "github.com/docker/docker/registry"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+// credentialHelpers maps a registry hostname to the name of the
+// docker-credential-helpers-compatible binary (without its
+// "docker-credential-" prefix) that serves credentials for it, as
+// configured by CommonConfig.CredentialHelpers. It is consulted by
+// CredentialHelperAuthConfig for pulls that have no client-supplied
+// AuthConfig, such as those triggered by a container's restart policy or a
+// swarm task, where the client that originally authenticated is long gone.
+var (
+	credentialHelpersMu sync.Mutex
+	credentialHelpers   = map[string]string{}
+)
+
+// SetCredentialHelpers installs helpers as the process-wide hostname ->
+// credential helper program mapping, replacing whatever was configured
+// before. See config.CommonConfig.CredentialHelpers for the map's format.
+func SetCredentialHelpers(helpers map[string]string) {
+	credentialHelpersMu.Lock()
+	defer credentialHelpersMu.Unlock()
+	credentialHelpers = make(map[string]string, len(helpers))
+	for host, program := range helpers {
+		credentialHelpers[host] = program
+	}
+}
+
+// CredentialHelperAuthConfig looks up the credential helper configured for
+// hostname and, if one is configured, runs it to fetch fresh credentials.
+// It returns ok=false, with no error, when no helper is configured for
+// hostname, which callers should treat the same as "no credentials
+// available" rather than a failure.
+func CredentialHelperAuthConfig(ctx context.Context, hostname string) (authConfig *types.AuthConfig, ok bool, err error) {
+	credentialHelpersMu.Lock()
+	program, ok := credentialHelpers[hostname]
+	credentialHelpersMu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	auth, err := getCredentials(ctx, program, hostname)
+	if err != nil {
+		return nil, false, err
+	}
+	return auth, true, nil
+}
+
+// getCredentials runs "docker-credential-<program> get" with hostname on
+// stdin, the same wire protocol a CLI config.json's credHelpers entry
+// uses, and decodes the username/secret it returns.
+func getCredentials(ctx context.Context, program, hostname string) (*types.AuthConfig, error) {
+	cmd := exec.CommandContext(ctx, "docker-credential-"+program, "get")
+	cmd.Stdin = strings.NewReader(hostname)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s get: %w", program, err)
+	}
+
+	var resp struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s get: %w", program, err)
+	}
+
+	return &types.AuthConfig{
+		Username:      resp.Username,
+		Password:      resp.Secret,
+		ServerAddress: hostname,
+	}, nil
+}
@@ -47,32 +47,37 @@ func validateNetContainerMode(c *container.Config, hc *container.HostConfig) err
 			return validationError("Invalid network mode: invalid container format container:<name|id>")
 		}
 	}
+	if parts[0] == "pod" {
+		if len(parts) < 2 || parts[1] == "" {
+			return validationError("Invalid network mode: invalid pod format pod:<name>")
+		}
+	}
 
-	if hc.NetworkMode.IsContainer() && c.Hostname != "" {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && c.Hostname != "" {
 		return ErrConflictNetworkHostname
 	}
 
-	if hc.NetworkMode.IsContainer() && len(hc.Links) > 0 {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && len(hc.Links) > 0 {
 		return ErrConflictContainerNetworkAndLinks
 	}
 
-	if hc.NetworkMode.IsContainer() && len(hc.DNS) > 0 {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && len(hc.DNS) > 0 {
 		return ErrConflictNetworkAndDNS
 	}
 
-	if hc.NetworkMode.IsContainer() && len(hc.ExtraHosts) > 0 {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && len(hc.ExtraHosts) > 0 {
 		return ErrConflictNetworkHosts
 	}
 
-	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsHost()) && c.MacAddress != "" {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod() || hc.NetworkMode.IsHost()) && c.MacAddress != "" {
 		return ErrConflictContainerNetworkAndMac
 	}
 
-	if hc.NetworkMode.IsContainer() && (len(hc.PortBindings) > 0 || hc.PublishAllPorts) {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && (len(hc.PortBindings) > 0 || hc.PublishAllPorts) {
 		return ErrConflictNetworkPublishPorts
 	}
 
-	if hc.NetworkMode.IsContainer() && len(c.ExposedPorts) > 0 {
+	if (hc.NetworkMode.IsContainer() || hc.NetworkMode.IsPod()) && len(c.ExposedPorts) > 0 {
 		return ErrConflictNetworkExposePorts
 	}
 	return nil
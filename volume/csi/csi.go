@@ -0,0 +1,236 @@
+// Package csi adapts CSI node plugins into docker volume drivers, so that
+// containers run by the daemon directly (without a cluster orchestrator) can
+// consume the CSI node-plugin ecosystem.
+//
+// CSI splits provisioning into a Controller service (create/delete/attach
+// the underlying storage, typically cluster-wide) and a Node service
+// (NodeStageVolume/NodePublishVolume/NodeUnpublishVolume/NodeUnstageVolume,
+// which make already-provisioned storage available on one host). This
+// package only bridges the Node service, mapping it onto volume.Driver's
+// Create/Mount/Unmount/Remove: Create just registers a volume for a
+// pre-existing volume ID (there is no controller here to provision new
+// storage), and Mount/Unmount drive the plugin's staging and publish calls.
+//
+// This build does not vendor github.com/container-storage-interface/spec,
+// so there is no concrete gRPC-backed NodeClient here that dials a plugin's
+// unix socket. NodeClient is defined as a plain Go interface matching the
+// shape of the CSI Node service RPCs this bridge needs, so a caller that
+// does have the CSI protobuf/gRPC stubs available can supply an
+// implementation that wraps them; everything downstream of NodeClient
+// (staging/publish bookkeeping, volume.Driver semantics) is fully
+// implemented and usable today with a hand-written or test NodeClient.
+package csi // import "github.com/docker/docker/volume/csi"
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/volume"
+	"github.com/pkg/errors"
+)
+
+// NodeClient is the subset of the CSI Node service that this bridge drives.
+// Its methods mirror the corresponding CSI NodeXxxVolume RPCs.
+type NodeClient interface {
+	// NodeStageVolume makes volumeID available at stagingTargetPath, a
+	// node-local staging directory from which it can later be published
+	// (potentially multiple times, for multi-use volumes).
+	NodeStageVolume(ctx context.Context, volumeID, stagingTargetPath string, opts map[string]string) error
+	// NodeUnstageVolume reverses NodeStageVolume once nothing has it published.
+	NodeUnstageVolume(ctx context.Context, volumeID, stagingTargetPath string) error
+	// NodePublishVolume bind-mounts the staged volume into targetPath for a
+	// single consumer.
+	NodePublishVolume(ctx context.Context, volumeID, stagingTargetPath, targetPath string, readonly bool, opts map[string]string) error
+	// NodeUnpublishVolume reverses NodePublishVolume.
+	NodeUnpublishVolume(ctx context.Context, volumeID, targetPath string) error
+}
+
+// Driver adapts a CSI node plugin, reached through client, into a
+// volume.Driver. Because CSI node plugins operate node-local storage,
+// Driver's scope is always volume.LocalScope.
+type Driver struct {
+	name   string
+	client NodeClient
+	root   string
+
+	m       sync.Mutex
+	volumes map[string]*csiVolume
+}
+
+// New creates a Driver named name that drives client to stage and publish
+// volumes under root, which is used to lay out the per-volume staging and
+// target directories.
+func New(name string, client NodeClient, root string) *Driver {
+	return &Driver{
+		name:    name,
+		client:  client,
+		root:    root,
+		volumes: make(map[string]*csiVolume),
+	}
+}
+
+// Name returns the name this driver is registered under.
+func (d *Driver) Name() string {
+	return d.name
+}
+
+// Create registers a volume backed by the CSI volume ID passed as name.
+// There is no controller plugin here to provision new backing storage, so
+// the named volume must already exist on the storage backend; opts are
+// passed through to NodeStageVolume/NodePublishVolume at Mount time.
+func (d *Driver) Create(name string, opts map[string]string) (volume.Volume, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if v, exists := d.volumes[name]; exists {
+		return v, nil
+	}
+
+	v := &csiVolume{
+		driver:     d,
+		name:       name,
+		opts:       opts,
+		createdAt:  time.Now(),
+		stagingDir: filepath.Join(d.root, "staging", name),
+		targetDir:  filepath.Join(d.root, "published", name),
+	}
+	d.volumes[name] = v
+	return v, nil
+}
+
+// Remove unregisters a volume. It is an error to remove a volume that still
+// has active mounts.
+func (d *Driver) Remove(v volume.Volume) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	cv, ok := v.(*csiVolume)
+	if !ok {
+		return errdefs.System(errors.Errorf("unknown volume type %T", v))
+	}
+
+	cv.m.Lock()
+	refs := cv.refs
+	cv.m.Unlock()
+	if refs > 0 {
+		return errdefs.System(errors.New("volume has active mounts"))
+	}
+
+	delete(d.volumes, cv.name)
+	return nil
+}
+
+// List lists the volumes known to this driver.
+func (d *Driver) List() ([]volume.Volume, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	ls := make([]volume.Volume, 0, len(d.volumes))
+	for _, v := range d.volumes {
+		ls = append(ls, v)
+	}
+	return ls, nil
+}
+
+// Get retrieves the volume with the requested name.
+func (d *Driver) Get(name string) (volume.Volume, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	v, exists := d.volumes[name]
+	if !exists {
+		return nil, errdefs.NotFound(errors.Errorf("volume %q not found", name))
+	}
+	return v, nil
+}
+
+// Scope returns volume.LocalScope: CSI node plugins only ever make storage
+// available on the node they run on.
+func (d *Driver) Scope() string {
+	return volume.LocalScope
+}
+
+// csiVolume implements volume.Volume on top of a CSI node plugin.
+type csiVolume struct {
+	driver    *Driver
+	name      string
+	opts      map[string]string
+	createdAt time.Time
+
+	stagingDir string
+	targetDir  string
+
+	m      sync.Mutex
+	refs   int
+	staged bool
+}
+
+func (v *csiVolume) Name() string {
+	return v.name
+}
+
+func (v *csiVolume) DriverName() string {
+	return v.driver.name
+}
+
+func (v *csiVolume) Path() string {
+	return v.targetDir
+}
+
+// Mount stages the volume on its first use and publishes it for id, returning
+// the path containers should bind-mount from.
+func (v *csiVolume) Mount(id string) (string, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	ctx := context.Background()
+	if !v.staged {
+		if err := v.driver.client.NodeStageVolume(ctx, v.name, v.stagingDir, v.opts); err != nil {
+			return "", errdefs.System(errors.Wrap(err, "NodeStageVolume failed"))
+		}
+		v.staged = true
+	}
+
+	if err := v.driver.client.NodePublishVolume(ctx, v.name, v.stagingDir, v.targetDir, false, v.opts); err != nil {
+		return "", errdefs.System(errors.Wrap(err, "NodePublishVolume failed"))
+	}
+	v.refs++
+	return v.targetDir, nil
+}
+
+// Unmount dereferences id, unpublishing and (once nothing else references
+// the volume) unstaging it.
+func (v *csiVolume) Unmount(id string) error {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	ctx := context.Background()
+	if err := v.driver.client.NodeUnpublishVolume(ctx, v.name, v.targetDir); err != nil {
+		return errdefs.System(errors.Wrap(err, "NodeUnpublishVolume failed"))
+	}
+	if v.refs > 0 {
+		v.refs--
+	}
+	if v.refs > 0 {
+		return nil
+	}
+
+	if v.staged {
+		if err := v.driver.client.NodeUnstageVolume(ctx, v.name, v.stagingDir); err != nil {
+			return errdefs.System(errors.Wrap(err, "NodeUnstageVolume failed"))
+		}
+		v.staged = false
+	}
+	return nil
+}
+
+func (v *csiVolume) CreatedAt() (time.Time, error) {
+	return v.createdAt, nil
+}
+
+func (v *csiVolume) Status() map[string]interface{} {
+	return nil
+}
@@ -0,0 +1,73 @@
+package csi // import "github.com/docker/docker/volume/csi"
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+type fakeNodeClient struct {
+	staged    map[string]bool
+	published map[string]bool
+}
+
+func newFakeNodeClient() *fakeNodeClient {
+	return &fakeNodeClient{staged: make(map[string]bool), published: make(map[string]bool)}
+}
+
+func (f *fakeNodeClient) NodeStageVolume(ctx context.Context, volumeID, stagingTargetPath string, opts map[string]string) error {
+	f.staged[volumeID] = true
+	return nil
+}
+
+func (f *fakeNodeClient) NodeUnstageVolume(ctx context.Context, volumeID, stagingTargetPath string) error {
+	f.staged[volumeID] = false
+	return nil
+}
+
+func (f *fakeNodeClient) NodePublishVolume(ctx context.Context, volumeID, stagingTargetPath, targetPath string, readonly bool, opts map[string]string) error {
+	f.published[volumeID] = true
+	return nil
+}
+
+func (f *fakeNodeClient) NodeUnpublishVolume(ctx context.Context, volumeID, targetPath string) error {
+	f.published[volumeID] = false
+	return nil
+}
+
+func TestMountStagesOnceAndUnmountUnstagesAtZeroRefs(t *testing.T) {
+	client := newFakeNodeClient()
+	d := New("csi-test", client, t.TempDir())
+
+	v, err := d.Create("vol1", nil)
+	assert.NilError(t, err)
+
+	_, err = v.Mount("ref1")
+	assert.NilError(t, err)
+	assert.Equal(t, client.staged["vol1"], true)
+	assert.Equal(t, client.published["vol1"], true)
+
+	_, err = v.Mount("ref2")
+	assert.NilError(t, err)
+
+	assert.NilError(t, v.Unmount("ref2"))
+	assert.Equal(t, client.staged["vol1"], true, "volume should remain staged while still referenced")
+
+	assert.NilError(t, v.Unmount("ref1"))
+	assert.Equal(t, client.staged["vol1"], false)
+}
+
+func TestRemoveFailsWithActiveMounts(t *testing.T) {
+	client := newFakeNodeClient()
+	d := New("csi-test", client, t.TempDir())
+
+	v, err := d.Create("vol1", nil)
+	assert.NilError(t, err)
+
+	_, err = v.Mount("ref1")
+	assert.NilError(t, err)
+
+	err = d.Remove(v)
+	assert.ErrorContains(t, err, "active mounts")
+}
@@ -21,6 +21,12 @@ const extName = "VolumeDriver"
 // volumeDriver defines the available functions that volume plugins must implement.
 // This interface is only defined to generate the proxy objects.
 // It's not intended to be public or reused.
+//
+// Note: volume.SnapshotCapable (Snapshot/Clone) is intentionally not part of
+// this interface. Adding it here would require regenerating proxy.go with
+// pluginrpc-gen to get a wire-compatible RPC proxy, so out-of-process volume
+// plugins can't advertise native snapshot support today; only in-process
+// drivers (currently just the local driver) can implement it.
 // nolint: deadcode
 type volumeDriver interface {
 	// Create a volume with the given name
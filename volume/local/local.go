@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -17,11 +18,18 @@ import (
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/volume"
+	units "github.com/docker/go-units"
 	"github.com/moby/sys/mount"
 	"github.com/moby/sys/mountinfo"
 	"github.com/pkg/errors"
 )
 
+// sizeOptName is the `--opt` key used to request a size quota on a volume,
+// e.g. `docker volume create --opt size=10G`. It's handled separately from
+// the other opts (which setOpts hands to the platform-specific mount-options
+// parser) since it isn't a mount option at all.
+const sizeOptName = "size"
+
 // VolumeDataPathName is the name of the directory where the volume data is stored.
 // It uses a very distinctive name to avoid collisions migrating data between
 // Docker versions.
@@ -60,6 +68,7 @@ func New(scope string, rootIdentity idtools.Identity) (*Root, error) {
 		volumes:      make(map[string]*localVolume),
 		rootIdentity: rootIdentity,
 	}
+	setupRootQuota(r)
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
 	if err != nil {
@@ -102,6 +111,7 @@ func New(scope string, rootIdentity idtools.Identity) (*Root, error) {
 // manages the creation/removal of volumes. It uses only standard vfs
 // commands to create/remove dirs within its provided scope.
 type Root struct {
+	rootQuota
 	m            sync.Mutex
 	scope        string
 	path         string
@@ -165,16 +175,30 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	}
 
 	if len(opts) != 0 {
-		if err = setOpts(v, opts); err != nil {
-			return nil, err
-		}
-		var b []byte
-		b, err = json.Marshal(v.opts)
+		mountOpts, quotaSize, err := extractSizeOpt(opts)
 		if err != nil {
-			return nil, err
+			return nil, errdefs.InvalidParameter(err)
+		}
+
+		if quotaSize > 0 {
+			if err = r.setQuota(path, quotaSize); err != nil {
+				return nil, errdefs.InvalidParameter(errors.Wrapf(err, "failed to set size quota for volume %q", name))
+			}
+			v.quotaSize = quotaSize
 		}
-		if err = ioutil.WriteFile(filepath.Join(filepath.Dir(path), "opts.json"), b, 0600); err != nil {
-			return nil, errdefs.System(errors.Wrap(err, "error while persisting volume options"))
+
+		if len(mountOpts) != 0 {
+			if err = setOpts(v, mountOpts); err != nil {
+				return nil, err
+			}
+			var b []byte
+			b, err = json.Marshal(v.opts)
+			if err != nil {
+				return nil, err
+			}
+			if err = ioutil.WriteFile(filepath.Join(filepath.Dir(path), "opts.json"), b, 0600); err != nil {
+				return nil, errdefs.System(errors.Wrap(err, "error while persisting volume options"))
+			}
 		}
 	}
 
@@ -182,6 +206,30 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	return v, nil
 }
 
+// extractSizeOpt pulls the "size" opt (if present) out of opts, parsing it as
+// a human-readable byte size (e.g. "10G"), and returns the remaining opts
+// for the platform-specific mount-options parser along with the requested
+// size in bytes (0 if "size" wasn't set).
+func extractSizeOpt(opts map[string]string) (map[string]string, uint64, error) {
+	raw, ok := opts[sizeOptName]
+	if !ok {
+		return opts, 0, nil
+	}
+
+	size, err := units.RAMInBytes(raw)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid value for %q", sizeOptName)
+	}
+
+	rest := make(map[string]string, len(opts)-1)
+	for k, v := range opts {
+		if k != sizeOptName {
+			rest[k] = v
+		}
+	}
+	return rest, uint64(size), nil
+}
+
 // Remove removes the specified volume and all underlying data. If the
 // given volume does not belong to this driver and an error is
 // returned. The volume is reference counted, if all references are
@@ -233,6 +281,43 @@ func removePath(path string) error {
 	return nil
 }
 
+// Snapshot creates a new volume seeded with a point-in-time copy of v's
+// data, using a reflink copy where the underlying filesystem supports it so
+// the copy is cheap and space-efficient, falling back to a regular copy
+// otherwise.
+func (r *Root) Snapshot(v volume.Volume, name string) (volume.Volume, error) {
+	return r.copyVolume(v, name)
+}
+
+// Clone behaves like Snapshot; the local driver makes no distinction
+// between a short-lived snapshot and a volume meant for independent,
+// ongoing use.
+func (r *Root) Clone(v volume.Volume, name string) (volume.Volume, error) {
+	return r.copyVolume(v, name)
+}
+
+func (r *Root) copyVolume(v volume.Volume, name string) (volume.Volume, error) {
+	lv, ok := v.(*localVolume)
+	if !ok {
+		return nil, errdefs.InvalidParameter(errors.Errorf("volume %q is not managed by the local driver", v.Name()))
+	}
+	if lv.opts != nil {
+		return nil, errdefs.InvalidParameter(errors.New("cannot snapshot or clone a volume backed by mount options"))
+	}
+
+	nv, err := r.Create(name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := copyVolumeData(lv.path, nv.Path()); err != nil {
+		r.Remove(nv)
+		return nil, errdefs.System(errors.Wrapf(err, "error copying data from volume %q to %q", v.Name(), name))
+	}
+
+	return nv, nil
+}
+
 // Get looks up the volume for the given name and returns it if found
 func (r *Root) Get(name string) (volume.Volume, error) {
 	r.m.Lock()
@@ -271,6 +356,12 @@ type localVolume struct {
 	driverName string
 	// opts is the parsed list of options used to create the volume
 	opts *optsConfig
+	// quotaSize is the size quota requested via the `size` opt, in bytes.
+	// It is 0 if no quota was requested. It does not survive a daemon
+	// restart: the quota itself stays enforced by the filesystem, but this
+	// driver currently has no on-disk record of which volumes have one, so
+	// it won't be reported again by Status() until the volume is recreated.
+	quotaSize uint64
 	// active refcounts the active mounts
 	active activeMount
 }
@@ -346,7 +437,10 @@ func (v *localVolume) unmount() error {
 }
 
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	if v.quotaSize == 0 {
+		return nil
+	}
+	return map[string]interface{}{"size": strconv.FormatUint(v.quotaSize, 10)}
 }
 
 // getAddress finds out address/hostname from options
@@ -30,6 +30,35 @@ func TestGetAddress(t *testing.T) {
 
 }
 
+func TestExtractSizeOpt(t *testing.T) {
+	rest, size, err := extractSizeOpt(map[string]string{"size": "10m", "foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 10*1024*1024 {
+		t.Fatalf("expected size 10MB, got %d", size)
+	}
+	if !reflect.DeepEqual(rest, map[string]string{"foo": "bar"}) {
+		t.Fatalf("expected size opt to be stripped, got %v", rest)
+	}
+
+	opts := map[string]string{"foo": "bar"}
+	rest, size, err = extractSizeOpt(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 0 {
+		t.Fatalf("expected no size, got %d", size)
+	}
+	if !reflect.DeepEqual(rest, opts) {
+		t.Fatalf("expected opts to be unchanged, got %v", rest)
+	}
+
+	if _, _, err := extractSizeOpt(map[string]string{"size": "not-a-size"}); err == nil {
+		t.Fatal("expected an error for an invalid size")
+	}
+}
+
 func TestRemove(t *testing.T) {
 	skip.If(t, runtime.GOOS == "windows", "FIXME: investigate why this test fails on CI")
 	rootDir, err := ioutil.TempDir("", "local-volume-test")
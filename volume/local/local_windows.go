@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/pkg/errors"
 )
 
@@ -44,3 +45,12 @@ func (v *localVolume) CreatedAt() (time.Time, error) {
 	ft := fileInfo.Sys().(*syscall.Win32FileAttributeData).CreationTime
 	return time.Unix(0, ft.Nanoseconds()), nil
 }
+
+// copyVolumeData copies the contents of src into the already-created
+// directory dst. ReFS block cloning would give this the same space
+// efficiency as the reflink copy used on Linux, but driving it requires the
+// FSCTL_DUPLICATE_EXTENTS_TO_FILE control code, which is out of scope here;
+// this does a plain data copy instead.
+func copyVolumeData(src, dst string) error {
+	return archive.NewDefaultArchiver().CopyWithTar(src, dst)
+}
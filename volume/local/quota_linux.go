@@ -0,0 +1,33 @@
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"github.com/docker/docker/daemon/graphdriver/quota"
+	"github.com/sirupsen/logrus"
+)
+
+//nolint:structcheck
+type rootQuota struct {
+	quotaCtl *quota.Control
+}
+
+// setupRootQuota tries to set up project quota support rooted at the volumes
+// directory. If the backing filesystem doesn't support project quotas (most
+// commonly: it isn't XFS), quota-limited volumes are simply not available and
+// Create returns quota.ErrQuotaNotSupported for any volume that asks for one.
+func setupRootQuota(r *Root) {
+	quotaCtl, err := quota.NewControl(r.path)
+	if err != nil {
+		if err != quota.ErrQuotaNotSupported {
+			logrus.WithError(err).Warn("Unable to set up volume quota support")
+		}
+		return
+	}
+	r.quotaCtl = quotaCtl
+}
+
+func (r *Root) setQuota(dir string, size uint64) error {
+	if r.quotaCtl == nil {
+		return quota.ErrQuotaNotSupported
+	}
+	return r.quotaCtl.SetQuota(dir, quota.Quota{Size: size})
+}
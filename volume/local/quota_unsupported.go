@@ -0,0 +1,13 @@
+// +build !linux
+
+package local // import "github.com/docker/docker/volume/local"
+
+import "github.com/docker/docker/daemon/graphdriver/quota"
+
+type rootQuota struct{}
+
+func setupRootQuota(r *Root) {}
+
+func (r *Root) setQuota(dir string, size uint64) error {
+	return quota.ErrQuotaNotSupported
+}
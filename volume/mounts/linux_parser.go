@@ -109,6 +109,22 @@ func (p *linuxParser) validateMountConfigImpl(mnt *mount.Mount, validateBindSour
 		if _, err := p.ConvertTmpfsOptions(mnt.TmpfsOptions, mnt.ReadOnly); err != nil {
 			return &errMountConfig{mnt, err}
 		}
+	case mount.TypeImage:
+		if mnt.BindOptions != nil {
+			return &errMountConfig{mnt, errExtraField("BindOptions")}
+		}
+		if mnt.VolumeOptions != nil {
+			return &errMountConfig{mnt, errExtraField("VolumeOptions")}
+		}
+		if len(mnt.Source) == 0 {
+			return &errMountConfig{mnt, errMissingField("Source")}
+		}
+		if mnt.ImageOptions != nil && mnt.ImageOptions.Subpath != "" {
+			subpath := path.Clean(filepath.ToSlash(mnt.ImageOptions.Subpath))
+			if path.IsAbs(subpath) || strings.HasPrefix(subpath, "..") {
+				return &errMountConfig{mnt, fmt.Errorf("invalid subpath: '%s' must be a relative path within the image", mnt.ImageOptions.Subpath)}
+			}
+		}
 	default:
 		return &errMountConfig{mnt, errors.New("mount type unknown")}
 	}
@@ -323,6 +339,11 @@ func (p *linuxParser) parseMountSpec(cfg mount.Mount, validateBindSourceExists b
 		}
 	case mount.TypeTmpfs:
 		// NOP
+	case mount.TypeImage:
+		// image mounts are always read-only: they are views onto content
+		// that isn't meant to be mutated by the container using them.
+		mp.RW = false
+		mp.Name = cfg.Source
 	}
 	return mp, nil
 }
@@ -76,6 +76,40 @@ func (s *VolumesService) Create(ctx context.Context, name, driverName string, op
 	return &apiV, nil
 }
 
+// Snapshot creates a new volume named name whose initial contents are a
+// point-in-time copy of the volume named src. The source volume's driver
+// must implement volume.SnapshotCapable; an errdefs.ErrNotImplemented is
+// returned otherwise.
+func (s *VolumesService) Snapshot(ctx context.Context, src, name string, createOpts ...opts.CreateOption) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateRandomID()
+	}
+	v, err := s.vs.Snapshot(ctx, src, name, createOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventLogger.LogVolumeEvent(v.Name(), "snapshot", map[string]string{"driver": v.DriverName()})
+	apiV := volumeToAPIType(v)
+	return &apiV, nil
+}
+
+// Clone behaves like Snapshot, but the result is intended for independent,
+// ongoing use rather than as a transient backup.
+func (s *VolumesService) Clone(ctx context.Context, src, name string, createOpts ...opts.CreateOption) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateRandomID()
+	}
+	v, err := s.vs.Clone(ctx, src, name, createOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventLogger.LogVolumeEvent(v.Name(), "clone", map[string]string{"driver": v.DriverName()})
+	apiV := volumeToAPIType(v)
+	return &apiV, nil
+}
+
 // Get returns details about a volume
 func (s *VolumesService) Get(ctx context.Context, name string, getOpts ...opts.GetOption) (*types.Volume, error) {
 	v, err := s.vs.Get(ctx, name, getOpts...)
@@ -622,6 +622,95 @@ func (s *VolumeStore) create(ctx context.Context, name, driverName string, opts,
 	return volumeWrapper{v, labels, vd.Scope(), opts}, nil
 }
 
+// Snapshot creates a new volume named name whose initial contents are a
+// point-in-time copy of the volume named src. It requires src's driver to
+// implement volume.SnapshotCapable; drivers that don't are reported via a
+// NotImplemented error rather than falling back to a generic copy, since a
+// generic copy would need to go through the mount/unmount machinery at the
+// API layer, not the store.
+func (s *VolumeStore) Snapshot(ctx context.Context, src, name string, createOpts ...opts.CreateOption) (volume.Volume, error) {
+	return s.copyVolume(ctx, src, name, createOpts, func(vd volume.SnapshotCapable, srcVol volume.Volume, name string) (volume.Volume, error) {
+		return vd.Snapshot(srcVol, name)
+	})
+}
+
+// Clone behaves like Snapshot, but asks the driver for a volume intended for
+// independent, ongoing use rather than a transient backup.
+func (s *VolumeStore) Clone(ctx context.Context, src, name string, createOpts ...opts.CreateOption) (volume.Volume, error) {
+	return s.copyVolume(ctx, src, name, createOpts, func(vd volume.SnapshotCapable, srcVol volume.Volume, name string) (volume.Volume, error) {
+		return vd.Clone(srcVol, name)
+	})
+}
+
+func (s *VolumeStore) copyVolume(ctx context.Context, src, name string, createOpts []opts.CreateOption, do func(volume.SnapshotCapable, volume.Volume, string) (volume.Volume, error)) (volume.Volume, error) {
+	var cfg opts.CreateConfig
+	for _, o := range createOpts {
+		o(&cfg)
+	}
+
+	src = normalizeVolumeName(src)
+	name = normalizeVolumeName(name)
+	if src == name {
+		return nil, &OpErr{Name: name, Op: "snapshot", Err: errdefs.InvalidParameter(errors.New("source and destination volume names must differ"))}
+	}
+
+	s.locks.Lock(src)
+	defer s.locks.Unlock(src)
+
+	srcVol, err := s.getVolume(ctx, src, "")
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: src, Op: "snapshot"}
+	}
+
+	vd, err := s.drivers.GetDriver(srcVol.DriverName())
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: src, Op: "snapshot"}
+	}
+	sc, ok := vd.(volume.SnapshotCapable)
+	if !ok {
+		return nil, &OpErr{Name: src, Op: "snapshot", Err: errdefs.NotImplemented(errors.Errorf("volume driver %q does not support snapshot/clone", vd.Name()))}
+	}
+
+	wrapped, ok := srcVol.(volumeWrapper)
+	if !ok {
+		return nil, &OpErr{Name: src, Op: "snapshot", Err: errdefs.System(errors.Errorf("unexpected volume type %T", srcVol))}
+	}
+
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	if v, err := s.checkConflict(ctx, name, vd.Name()); err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: "snapshot"}
+	} else if v != nil {
+		return nil, &OpErr{Name: name, Op: "snapshot", Err: errdefs.Conflict(errors.Errorf("volume %q already exists", name))}
+	}
+
+	nv, err := do(sc, wrapped.Volume, name)
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: "snapshot"}
+	}
+
+	s.globalLock.Lock()
+	s.labels[name] = cfg.Labels
+	s.options[name] = cfg.Options
+	s.refs[name] = make(map[string]struct{})
+	s.globalLock.Unlock()
+
+	metadata := volumeMetadata{
+		Name:    name,
+		Driver:  vd.Name(),
+		Labels:  cfg.Labels,
+		Options: cfg.Options,
+	}
+	if err := s.setMeta(name, metadata); err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: "snapshot"}
+	}
+
+	result := volumeWrapper{nv, cfg.Labels, vd.Scope(), cfg.Options}
+	s.setNamed(result, cfg.Reference)
+	return result, nil
+}
+
 // Get looks if a volume with the given name exists and returns it if so
 func (s *VolumeStore) Get(ctx context.Context, name string, getOptions ...opts.GetOption) (volume.Volume, error) {
 	var cfg opts.GetConfig
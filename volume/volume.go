@@ -67,3 +67,20 @@ type DetailedVolume interface {
 	Scope() string
 	Volume
 }
+
+// SnapshotCapable is an optional capability a Driver can implement to
+// produce a new volume whose initial contents are a copy of an existing
+// one, without the volume service having to fall back to a full
+// mount-and-copy at the API layer. Drivers that don't implement this
+// interface simply don't support `docker volume snapshot`/`clone`.
+type SnapshotCapable interface {
+	// Snapshot creates a new volume named name, seeded with the contents
+	// of vol as of now. It is intended for short-lived, space-efficient
+	// point-in-time copies (e.g. backing up before a risky write).
+	Snapshot(vol Volume, name string) (Volume, error)
+	// Clone behaves like Snapshot, but the result is intended for
+	// independent, ongoing use rather than as a transient backup. Drivers
+	// that make no distinction between the two may implement Clone by
+	// calling Snapshot.
+	Clone(vol Volume, name string) (Volume, error)
+}